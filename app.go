@@ -12,6 +12,9 @@ import (
 	"github.com/melodiez14/meiko/src/util/conn"
 	"github.com/melodiez14/meiko/src/util/env"
 	"github.com/melodiez14/meiko/src/util/jsonconfig"
+	"github.com/melodiez14/meiko/src/util/ldap"
+	"github.com/melodiez14/meiko/src/util/oidc"
+	"github.com/melodiez14/meiko/src/util/sms"
 	"github.com/melodiez14/meiko/src/webserver"
 	"github.com/melodiez14/meiko/src/webserver/handler/bot"
 )
@@ -23,6 +26,9 @@ type configuration struct {
 	Webserver webserver.Config      `json:"webserver"`
 	Email     email.Config          `json:"email"`
 	Auth      auth.Config           `json:"auth"`
+	OIDC      oidc.Config           `json:"oidc"`
+	LDAP      ldap.Config           `json:"ldap"`
+	SMS       sms.Config            `json:"sms"`
 }
 
 func init() {
@@ -48,5 +54,8 @@ func main() {
 	cron.Init()
 	auth.Init(config.Auth)
 	email.Init(config.Email)
+	oidc.Init(config.OIDC)
+	ldap.Init(config.LDAP)
+	sms.Init(config.SMS)
 	webserver.Start(config.Webserver)
 }
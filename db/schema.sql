@@ -17,6 +17,26 @@
 SET NAMES utf8mb4;
 SET FOREIGN_KEY_CHECKS = 0;
 
+-- ----------------------------
+-- Table structure for api_keys
+-- ----------------------------
+DROP TABLE IF EXISTS `api_keys`;
+CREATE TABLE `api_keys` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `users_id` int(10) unsigned NOT NULL,
+  `name` varchar(50) NOT NULL,
+  `key_prefix` varchar(16) NOT NULL,
+  `key_hash` char(64) NOT NULL,
+  `scopes` text NOT NULL,
+  `last_used_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `unique_api_keys_key_hash` (`key_hash`) USING BTREE,
+  KEY `fk_api_keys_users` (`users_id`) USING BTREE,
+  CONSTRAINT `fk_api_keys_users` FOREIGN KEY (`users_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 -- ----------------------------
 -- Table structure for assignments
 -- ----------------------------
@@ -52,6 +72,24 @@ CREATE TABLE `attendances` (
   CONSTRAINT `fk_attendances_users` FOREIGN KEY (`users_id`) REFERENCES `users` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
+-- ----------------------------
+-- Table structure for audit_logs
+-- ----------------------------
+DROP TABLE IF EXISTS `audit_logs`;
+CREATE TABLE `audit_logs` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `actor_id` int(10) unsigned NOT NULL,
+  `action` varchar(10) NOT NULL,
+  `target_table` varchar(45) NOT NULL,
+  `target_id` bigint(20) unsigned NOT NULL,
+  `before_value` text,
+  `after_value` text,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  KEY `index_audit_logs_actor` (`actor_id`) USING BTREE,
+  KEY `index_audit_logs_target` (`target_table`,`target_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 -- ----------------------------
 -- Table structure for bot_logs
 -- ----------------------------
@@ -168,6 +206,24 @@ CREATE TABLE `log_books` (
   CONSTRAINT `fk_log_books_researches` FOREIGN KEY (`researches_id`) REFERENCES `researches` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
+-- ----------------------------
+-- Table structure for lockout_logs
+-- ----------------------------
+DROP TABLE IF EXISTS `lockout_logs`;
+CREATE TABLE `lockout_logs` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `email` varchar(45) NOT NULL,
+  `ip` varchar(45) NOT NULL,
+  `scope` varchar(20) NOT NULL,
+  `event` tinyint(3) unsigned NOT NULL,
+  `failures` int(10) unsigned NOT NULL DEFAULT '0',
+  `locked_until` datetime NOT NULL,
+  `actor_id` int(10) unsigned DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  KEY `index_lockout_logs_email` (`email`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 -- ----------------------------
 -- Table structure for meetings
 -- ----------------------------
@@ -207,6 +263,22 @@ CREATE TABLE `notifications` (
   CONSTRAINT `fk_notifications_users1` FOREIGN KEY (`users_id`) REFERENCES `users` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
+-- ----------------------------
+-- Table structure for p_courses_prerequisites
+-- ----------------------------
+DROP TABLE IF EXISTS `p_courses_prerequisites`;
+CREATE TABLE `p_courses_prerequisites` (
+  `courses_id` varchar(40) NOT NULL,
+  `prerequisites_id` varchar(40) NOT NULL,
+  `min_grade` decimal(5,2) unsigned NOT NULL DEFAULT '0.00',
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`courses_id`,`prerequisites_id`) USING BTREE,
+  KEY `fk_prerequisites_courses` (`prerequisites_id`) USING BTREE,
+  CONSTRAINT `fk_courses_prerequisites` FOREIGN KEY (`courses_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION,
+  CONSTRAINT `fk_prerequisites_courses` FOREIGN KEY (`prerequisites_id`) REFERENCES `courses` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 -- ----------------------------
 -- Table structure for p_users_assignments
 -- ----------------------------
@@ -300,6 +372,21 @@ CREATE TABLE `researches` (
   CONSTRAINT `fk_researches_research_categories` FOREIGN KEY (`research_categories_id`) REFERENCES `research_categories` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8;
 
+-- ----------------------------
+-- Table structure for recovery_codes
+-- ----------------------------
+DROP TABLE IF EXISTS `recovery_codes`;
+CREATE TABLE `recovery_codes` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `users_id` int(10) unsigned NOT NULL,
+  `code` varchar(64) NOT NULL,
+  `used_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  KEY `fk_recovery_codes_users` (`users_id`) USING BTREE,
+  CONSTRAINT `fk_recovery_codes_users` FOREIGN KEY (`users_id`) REFERENCES `users` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 -- ----------------------------
 -- Table structure for rolegroups
 -- ----------------------------
@@ -307,6 +394,7 @@ DROP TABLE IF EXISTS `rolegroups`;
 CREATE TABLE `rolegroups` (
   `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
   `name` varchar(15) NOT NULL,
+  `is_2fa_required` tinyint(1) unsigned NOT NULL DEFAULT '0',
   `created_at` datetime NOT NULL,
   `updated_at` datetime NOT NULL,
   PRIMARY KEY (`id`) USING BTREE
@@ -318,7 +406,7 @@ CREATE TABLE `rolegroups` (
 DROP TABLE IF EXISTS `rolegroups_modules`;
 CREATE TABLE `rolegroups_modules` (
   `rolegroups_id` int(10) unsigned NOT NULL AUTO_INCREMENT,
-  `module` enum('users','courses','attendances','roles','schedules','assignments','informations','tutorials') NOT NULL,
+  `module` enum('users','courses','attendances','roles','schedules','assignments','informations','tutorials','audits') NOT NULL,
   `ability` enum('CREATE','READ','UPDATE','DELETE','XCREATE','XREAD','XUPDATE','XDELETE') NOT NULL,
   `created_at` datetime NOT NULL,
   `updated_at` datetime NOT NULL,
@@ -340,8 +428,10 @@ CREATE TABLE `schedules` (
   `class` char(1) NOT NULL,
   `semester` tinyint(2) NOT NULL,
   `year` smallint(4) unsigned NOT NULL,
+  `capacity` smallint(5) unsigned DEFAULT NULL,
   `courses_id` varchar(40) NOT NULL,
   `places_id` varchar(30) NOT NULL,
+  `terms_id` int(10) unsigned DEFAULT NULL,
   `created_by` int(10) unsigned NOT NULL,
   `created_at` datetime NOT NULL,
   `updated_at` datetime NOT NULL,
@@ -350,11 +440,34 @@ CREATE TABLE `schedules` (
   KEY `fk_courses_places` (`places_id`) USING BTREE,
   KEY `fk_courses_users` (`created_by`) USING BTREE,
   KEY `fk_schedules_courses` (`courses_id`) USING BTREE,
+  KEY `fk_schedules_terms` (`terms_id`) USING BTREE,
   CONSTRAINT `fk_courses_places` FOREIGN KEY (`places_id`) REFERENCES `places` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION,
   CONSTRAINT `fk_courses_users` FOREIGN KEY (`created_by`) REFERENCES `users` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION,
-  CONSTRAINT `fk_schedules_courses` FOREIGN KEY (`courses_id`) REFERENCES `courses` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
+  CONSTRAINT `fk_schedules_courses` FOREIGN KEY (`courses_id`) REFERENCES `courses` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT `fk_schedules_terms` FOREIGN KEY (`terms_id`) REFERENCES `terms` (`id`) ON DELETE SET NULL ON UPDATE NO ACTION
 ) ENGINE=InnoDB AUTO_INCREMENT=100193 DEFAULT CHARSET=utf8;
 
+-- ----------------------------
+-- Table structure for terms
+-- ----------------------------
+DROP TABLE IF EXISTS `terms`;
+CREATE TABLE `terms` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `semester` tinyint(2) NOT NULL,
+  `year` smallint(4) unsigned NOT NULL,
+  `status` tinyint(4) unsigned NOT NULL DEFAULT '0',
+  `start_date` date NOT NULL,
+  `end_date` date NOT NULL,
+  `registration_start_date` date NOT NULL,
+  `registration_end_date` date NOT NULL,
+  `grading_start_date` date NOT NULL,
+  `grading_end_date` date NOT NULL,
+  `created_at` datetime NOT NULL,
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`) USING BTREE,
+  UNIQUE KEY `uq_terms` (`semester`,`year`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 -- ----------------------------
 -- Table structure for tutorials
 -- ----------------------------
@@ -380,23 +493,57 @@ CREATE TABLE `users` (
   `name` varchar(50) NOT NULL,
   `gender` tinyint(3) unsigned NOT NULL DEFAULT '0',
   `email` varchar(45) NOT NULL,
-  `password` varchar(45) NOT NULL,
+  `password` varchar(255) NOT NULL,
   `note` varchar(100) NOT NULL DEFAULT '',
   `rolegroups_id` int(11) unsigned DEFAULT NULL,
   `status` tinyint(3) unsigned NOT NULL DEFAULT '0',
   `phone` varchar(14) DEFAULT NULL,
+  `phone_verified` tinyint(1) unsigned NOT NULL DEFAULT '0',
+  `phone_verification_code` smallint(4) unsigned DEFAULT NULL,
+  `phone_verification_expire_date` datetime DEFAULT NULL,
+  `phone_verification_attempt` tinyint(1) unsigned DEFAULT NULL,
   `line_id` varchar(45) DEFAULT NULL,
   `identity_code` varchar(18) NOT NULL,
   `email_verification_code` smallint(4) unsigned DEFAULT NULL,
   `email_verification_expire_date` datetime DEFAULT NULL,
   `email_verification_attempt` tinyint(1) unsigned DEFAULT NULL,
+  `email_change` varchar(45) DEFAULT NULL,
+  `email_change_code` smallint(4) unsigned DEFAULT NULL,
+  `email_change_expire_date` datetime DEFAULT NULL,
+  `email_change_attempt` tinyint(1) unsigned DEFAULT NULL,
+  `totp_secret` varchar(32) DEFAULT NULL,
+  `totp_enabled_at` datetime DEFAULT NULL,
+  `sso_subject` varchar(255) DEFAULT NULL,
+  `calendar_token` char(64) DEFAULT NULL,
+  `program` varchar(100) DEFAULT NULL,
+  `cohort` smallint(4) unsigned DEFAULT NULL,
+  `advisor_id` int(10) unsigned DEFAULT NULL,
   `created_at` datetime NOT NULL,
   `updated_at` datetime NOT NULL,
   PRIMARY KEY (`id`) USING BTREE,
   UNIQUE KEY `unique_users_email` (`email`) USING BTREE,
   UNIQUE KEY `unique_users_identity_code` (`identity_code`) USING BTREE,
+  UNIQUE KEY `unique_users_sso_subject` (`sso_subject`) USING BTREE,
+  UNIQUE KEY `unique_users_calendar_token` (`calendar_token`) USING BTREE,
   KEY `fk_users_role_groups` (`rolegroups_id`) USING BTREE,
-  CONSTRAINT `fk_users_role_groups` FOREIGN KEY (`rolegroups_id`) REFERENCES `rolegroups` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
+  KEY `index_users_program_cohort` (`program`,`cohort`) USING BTREE,
+  KEY `fk_users_advisor` (`advisor_id`) USING BTREE,
+  CONSTRAINT `fk_users_role_groups` FOREIGN KEY (`rolegroups_id`) REFERENCES `rolegroups` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION,
+  CONSTRAINT `fk_users_advisor` FOREIGN KEY (`advisor_id`) REFERENCES `users` (`id`) ON DELETE SET NULL ON UPDATE NO ACTION
 ) ENGINE=InnoDB AUTO_INCREMENT=2000000005 DEFAULT CHARSET=utf8;
 
+-- ----------------------------
+-- Table structure for waitlists
+-- ----------------------------
+DROP TABLE IF EXISTS `waitlists`;
+CREATE TABLE `waitlists` (
+  `users_id` int(10) unsigned NOT NULL,
+  `schedules_id` int(10) unsigned NOT NULL,
+  `created_at` datetime NOT NULL,
+  PRIMARY KEY (`users_id`,`schedules_id`) USING BTREE,
+  KEY `index_waitlists_schedules_created` (`schedules_id`,`created_at`) USING BTREE,
+  CONSTRAINT `fk_waitlists_schedules` FOREIGN KEY (`schedules_id`) REFERENCES `schedules` (`id`) ON DELETE CASCADE ON UPDATE NO ACTION,
+  CONSTRAINT `fk_waitlists_users` FOREIGN KEY (`users_id`) REFERENCES `users` (`id`) ON DELETE NO ACTION ON UPDATE NO ACTION
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
 SET FOREIGN_KEY_CHECKS = 1;
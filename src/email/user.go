@@ -2,11 +2,12 @@ package email
 
 import "github.com/melodiez14/meiko/src/util/alias"
 
-// SendEmailValidation is used for sending an email validation
-func SendEmailValidation(name, email string, code uint16) {
+// SendEmailValidation is used for sending an email validation, the link is omitted if empty
+func SendEmailValidation(name, email string, code uint16, link string) {
 
 	data := map[string]interface{}{
 		"code": code,
+		"link": link,
 	}
 
 	NewRequest(email, "Email Validation").
@@ -14,11 +15,12 @@ func SendEmailValidation(name, email string, code uint16) {
 		Deliver()
 }
 
-// SendForgotPassword is used for sending an email validation
-func SendForgotPassword(name, email string, code uint16) {
+// SendForgotPassword is used for sending the code to reset the password, the link is omitted if empty
+func SendForgotPassword(name, email string, code uint16, link string) {
 
 	data := map[string]interface{}{
 		"code": code,
+		"link": link,
 	}
 
 	NewRequest(email, "Forgot Password").
@@ -35,3 +37,28 @@ func SendAccountCreated(name, email string, code uint16) {
 		SetTemplate(alias.Dir["email"]+"/account_created.html", data).
 		Deliver()
 }
+
+// SendEmailChange is used for sending the code to confirm the new email
+func SendEmailChange(name, email string, code uint16) {
+	data := map[string]interface{}{
+		"name":  name,
+		"email": email,
+		"code":  code,
+	}
+
+	NewRequest(email, "Email Change Confirmation").
+		SetTemplate(alias.Dir["email"]+"/email_change.html", data).
+		Deliver()
+}
+
+// SendEmailChanged is used for notifying the old email that the email of the account has been changed
+func SendEmailChanged(name, oldEmail, newEmail string) {
+	data := map[string]interface{}{
+		"name":  name,
+		"email": newEmail,
+	}
+
+	NewRequest(oldEmail, "Your email has been changed").
+		SetTemplate(alias.Dir["email"]+"/email_changed.html", data).
+		Deliver()
+}
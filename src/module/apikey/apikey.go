@@ -0,0 +1,157 @@
+package apikey
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// Insert saves a new API key of the user
+/*
+	@params:
+		userID	= int64
+		name	= string
+		prefix	= string
+		hash	= string
+		scopes	= string, json encoded map of module and abilities
+	@example:
+		userID	= 2000000001
+		name	= grade sync script
+		prefix	= mk_X3k9aQ2b
+		hash	= 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+		scopes	= {"courses":["READ"]}
+	@return
+		id		= 1
+*/
+func Insert(userID int64, name, prefix, hash, scopes string) (int64, error) {
+	query := `
+		INSERT INTO
+			api_keys (
+				users_id,
+				name,
+				key_prefix,
+				key_hash,
+				scopes,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+	result, err := conn.Exec(query, userID, name, prefix, hash, scopes)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// SelectByUserID returns all API keys of the user, newest first
+func SelectByUserID(userID int64) ([]APIKey, error) {
+	var keys []APIKey
+	query := `
+		SELECT
+			id,
+			users_id,
+			name,
+			key_prefix,
+			key_hash,
+			scopes,
+			last_used_at,
+			created_at
+		FROM
+			api_keys
+		WHERE
+			users_id = (?)
+		ORDER BY
+			id DESC;
+		`
+	err := conn.Select(&keys, query, userID)
+	if err != nil {
+		return keys, err
+	}
+	return keys, nil
+}
+
+// GetByHash returns the API key which has the hash
+func GetByHash(hash string) (APIKey, error) {
+	var key APIKey
+	query := `
+		SELECT
+			id,
+			users_id,
+			name,
+			key_prefix,
+			key_hash,
+			scopes,
+			last_used_at,
+			created_at
+		FROM
+			api_keys
+		WHERE
+			key_hash = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&key, query, hash)
+	if err != nil {
+		return key, err
+	}
+	return key, nil
+}
+
+// UpdateLastUsed marks the API key as used now
+func UpdateLastUsed(id int64) error {
+	query := `
+		UPDATE
+			api_keys
+		SET
+			last_used_at = NOW()
+		WHERE
+			id = (?);
+		`
+	_, err := conn.Exec(query, id)
+	return err
+}
+
+// Delete revokes the API key of the user
+func Delete(id, userID int64) error {
+	query := `
+		DELETE FROM
+			api_keys
+		WHERE
+			id = (?) AND
+			users_id = (?);
+		`
+	result, err := conn.Exec(query, id, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// DeleteByUserID revokes all API keys of the user
+func DeleteByUserID(userID int64, tx ...*sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			api_keys
+		WHERE
+			users_id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	_, err := conn.TxExec(t, query, userID)
+	return err
+}
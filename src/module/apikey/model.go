@@ -0,0 +1,20 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// APIKey is a long lived personal key which authenticate its owner through the Authorization header.
+// The key itself is never stored, only its SHA-256 hash and a short prefix to recognize it
+type APIKey struct {
+	ID         int64          `db:"id"`
+	UserID     int64          `db:"users_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"key_prefix"`
+	Hash       string         `db:"key_hash"`
+	Scopes     string         `db:"scopes"`
+	LastUsedAt mysql.NullTime `db:"last_used_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/melodiez14/meiko/src/util/helper"
-
 	"github.com/jmoiron/sqlx"
 	"github.com/melodiez14/meiko/src/util/conn"
 )
@@ -15,8 +13,7 @@ import (
 // GetByCourseID func ...
 func GetByCourseID(courseID int64) ([]Assignment, error) {
 	var assignments []Assignment
-	query := fmt.Sprintf(queryGetByCourseID, courseID)
-	err := conn.DB.Select(&assignments, query)
+	err := conn.Select(&assignments, queryGetByCourseID, courseID)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -26,8 +23,7 @@ func GetByCourseID(courseID int64) ([]Assignment, error) {
 // GetIncompleteByUserID func ...
 func GetIncompleteByUserID(userID int64) ([]Assignment, error) {
 	var assignments []Assignment
-	query := fmt.Sprintf(queryGetIncompleteByUserID, userID, userID)
-	err := conn.DB.Select(&assignments, query)
+	err := conn.Select(&assignments, queryGetIncompleteByUserID, userID, userID)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -37,8 +33,7 @@ func GetIncompleteByUserID(userID int64) ([]Assignment, error) {
 // GetCompleteByUserID func ...
 func GetCompleteByUserID(userID int64) ([]int64, error) {
 	var assignmentsID []int64
-	query := fmt.Sprintf(queryGetCompleteByUserID, userID)
-	err := conn.DB.Select(&assignmentsID, query)
+	err := conn.Select(&assignmentsID, queryGetCompleteByUserID, userID)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -48,16 +43,16 @@ func GetCompleteByUserID(userID int64) ([]int64, error) {
 // IsExistByGradeParameterID func ...
 func IsExistByGradeParameterID(gpID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id
 		FROM
 			assignments
 		WHERE
-			grade_parameters_id = (%d)
+			grade_parameters_id = (?)
 		LIMIT 1;
-	`, gpID)
-	err := conn.DB.Get(&x, query)
+	`
+	err := conn.Get(&x, query, gpID)
 	if err == sql.ErrNoRows {
 		return false
 	}
@@ -66,22 +61,22 @@ func IsExistByGradeParameterID(gpID int64) bool {
 
 // GetGradeParameterID ..
 func GetGradeParameterID(assignmentID int64) int64 {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			grade_parameters_id
 		FROM
 			assignments
 		WHERE
-			id=(%d);
-		`, assignmentID)
+			id=(?);
+		`
 	var result int64
-	_ = conn.DB.Get(&result, query)
+	_ = conn.Get(&result, query, assignmentID)
 	return result
 }
 
 // GetAssignmentByID ..
 func GetAssignmentByID(assignmentID int64) ConciseAssignment {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			due_date,
@@ -90,23 +85,20 @@ func GetAssignmentByID(assignmentID int64) ConciseAssignment {
 		FROM
 			assignments
 		WHERE
-			id=(%d)
-		`, assignmentID)
+			id=(?)
+		`
 	var result ConciseAssignment
-	_ = conn.DB.Get(&result, query)
+	_ = conn.Get(&result, query, assignmentID)
 	return result
 }
 
 // Insert function is ...
 func Insert(name string, desc sql.NullString, gps, maxSize, maxFile int64, duedate string, status int8, tx *sqlx.Tx) (int64, error) {
 	var id int64
-	queryDesc := fmt.Sprintf("(NULL)")
-	if desc.Valid {
-		queryDesc = fmt.Sprintf("('%s')", desc.String)
-	}
 	var query string
+	var args []interface{}
 	if status == 1 {
-		query = fmt.Sprintf(`
+		query = `
 			INSERT INTO
 				assignments(
 					name,
@@ -120,19 +112,20 @@ func Insert(name string, desc sql.NullString, gps, maxSize, maxFile int64, dueda
 					updated_at
 				)
 			VALUES(
-				('%s'),
-				('%s'),
-				(%d),
-				('%s'),
-				(%d),
-				(%d),
-				(%d),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-			`, name, queryDesc, status, duedate, gps, maxSize, maxFile)
+			`
+		args = []interface{}{name, desc, status, duedate, gps, maxSize, maxFile}
 	} else {
-		query = fmt.Sprintf(`
+		query = `
 			INSERT INTO
 				assignments(
 					name,
@@ -144,19 +137,18 @@ func Insert(name string, desc sql.NullString, gps, maxSize, maxFile int64, dueda
 					updated_at
 				)
 			VALUES(
-				('%s'),
-				(%s),
-				(%d),
-				('%s'),
-				(%d),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-			`, name, queryDesc, status, duedate, gps)
+			`
+		args = []interface{}{name, desc, status, duedate, gps}
 	}
-	var result sql.Result
-	var err error
-	result, err = tx.Exec(query)
+	result, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return id, err
 	}
@@ -174,51 +166,42 @@ func Insert(name string, desc sql.NullString, gps, maxSize, maxFile int64, dueda
 // Update ..
 func Update(name string, desc sql.NullString, id, gps, maxSize, maxFile int64, duedate time.Time, status int8, tx *sqlx.Tx) error {
 
-	var result sql.Result
-	var err error
-	queryDescription := fmt.Sprintf("NULL")
-	if desc.Valid {
-		queryDescription = fmt.Sprintf(desc.String)
-	}
 	var query string
-	layout := `2006-01-02 15:04:05`
-	timeQuery := duedate.Format(layout)
+	var args []interface{}
 	if gps > 0 {
-		query = fmt.Sprintf(`
+		query = `
 			UPDATE 
 				assignments
 			SET
-			name = ('%s'),
-			description = ('%s'),
-			status = (%d),
-			due_date = ('%s'),
-			grade_parameters_id = (%d),
-			max_size = (%d),
-			max_file = (%d),
+			name = (?),
+			description = (?),
+			status = (?),
+			due_date = (?),
+			grade_parameters_id = (?),
+			max_size = (?),
+			max_file = (?),
 			updated_at = NOW()
 			WHERE
-				id = (%d);
-			`, name, queryDescription, status, timeQuery, gps, maxSize, maxFile, id)
+				id = (?);
+			`
+		args = []interface{}{name, desc, status, duedate, gps, maxSize, maxFile, id}
 	} else {
-		query = fmt.Sprintf(`
+		query = `
 			UPDATE 
 				assignments
 			SET
-			name = ('%s'),
-			description = ('%s'),
-			status = (%d),
-			due_date = ('%s'),
-			grade_parameters_id = (%d),
+			name = (?),
+			description = (?),
+			status = (?),
+			due_date = (?),
+			grade_parameters_id = (?),
 			updated_at = NOW()
 			WHERE
-				id = (%d);
-			`, name, queryDescription, status, timeQuery, gps, id)
-	}
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
+				id = (?);
+			`
+		args = []interface{}{name, desc, status, duedate, gps, id}
 	}
+	result, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -232,16 +215,16 @@ func Update(name string, desc sql.NullString, id, gps, maxSize, maxFile int64, d
 // IsFileIDExist func ...
 func IsFileIDExist(ID string) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id
 		FROM
 			files
 		WHERE
-			id = ('%s')
+			id = (?)
 		LIMIT 1;
-			`, ID)
-	err := conn.DB.Get(&x, query)
+			`
+	err := conn.Get(&x, query, ID)
 	if err == sql.ErrNoRows {
 		return false
 	}
@@ -252,8 +235,7 @@ func IsFileIDExist(ID string) bool {
 func SelectByPage(gpID []int64, limit, offset int, isCount bool) ([]Assignment, int, error) {
 	var assignment []Assignment
 	var count int
-	queryGP := strings.Join(helper.Int64ToStringSlice(gpID), ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			grade_parameters_id,
@@ -266,12 +248,12 @@ func SelectByPage(gpID []int64, limit, offset int, isCount bool) ([]Assignment,
 		FROM
 			assignments
 		WHERE
-			grade_parameters_id IN (%s)
+			grade_parameters_id IN (?)
 		ORDER BY updated_at DESC
-		LIMIT %d
-		OFFSET %d;`, queryGP, limit, offset)
+		LIMIT ?
+		OFFSET ?;`
 
-	err := conn.DB.Select(&assignment, query)
+	err := conn.Select(&assignment, query, gpID, limit, offset)
 	if err != nil {
 		return assignment, count, err
 	}
@@ -280,15 +262,15 @@ func SelectByPage(gpID []int64, limit, offset int, isCount bool) ([]Assignment,
 		return assignment, count, nil
 	}
 
-	query = fmt.Sprintf(`
+	query = `
 		SELECT
 			COUNT(*)
 		FROM
 			assignments
 		WHERE
-			grade_parameters_id IN (%s)
-		;`, queryGP)
-	err = conn.DB.Get(&count, query)
+			grade_parameters_id IN (?)
+		;`
+	err = conn.Get(&count, query, gpID)
 	if err != nil {
 		return assignment, count, err
 	}
@@ -298,15 +280,7 @@ func SelectByPage(gpID []int64, limit, offset int, isCount bool) ([]Assignment,
 
 // GetByGradeParametersID func ...
 func GetByGradeParametersID(gradeParametersID []int64, limit, offset uint16) ([]Assignment, error) {
-	var gradeParametersQuery string
-	for i, value := range gradeParametersID {
-		if i+1 == len(gradeParametersID) {
-			gradeParametersQuery = fmt.Sprintf("%s%d", gradeParametersQuery, value)
-		} else {
-			gradeParametersQuery = fmt.Sprintf("%s%d, ", gradeParametersQuery, value)
-		}
-	}
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			due_date,
@@ -316,12 +290,12 @@ func GetByGradeParametersID(gradeParametersID []int64, limit, offset uint16) ([]
 		FROM
 			assignments
 		WHERE
-			grade_parameters_id IN (%s)
-		LIMIT %d OFFSET %d		
-		;`, gradeParametersQuery, limit, offset)
+			grade_parameters_id IN (?)
+		LIMIT ? OFFSET ?		
+		;`
 
 	var result []Assignment
-	err := conn.DB.Select(&result, query)
+	err := conn.Select(&result, query, gradeParametersID, limit, offset)
 	if err != nil {
 		return result, err
 	}
@@ -331,15 +305,7 @@ func GetByGradeParametersID(gradeParametersID []int64, limit, offset uint16) ([]
 
 // SelectByGradeParametersID func ...
 func SelectByGradeParametersID(gradeParametersID []int64) []Assignment {
-	var gradeParametersQuery string
-	for i, value := range gradeParametersID {
-		if i+1 == len(gradeParametersID) {
-			gradeParametersQuery = fmt.Sprintf("%s%d", gradeParametersQuery, value)
-		} else {
-			gradeParametersQuery = fmt.Sprintf("%s%d, ", gradeParametersQuery, value)
-		}
-	}
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			due_date,
@@ -349,11 +315,11 @@ func SelectByGradeParametersID(gradeParametersID []int64) []Assignment {
 		FROM
 			assignments
 		WHERE
-			grade_parameters_id IN (%s)	
-		;`, gradeParametersQuery)
+			grade_parameters_id IN (?)	
+		;`
 
 	var result []Assignment
-	err := conn.DB.Select(&result, query)
+	err := conn.Select(&result, query, gradeParametersID)
 	if err != nil {
 		return result
 	}
@@ -364,7 +330,7 @@ func SelectByGradeParametersID(gradeParametersID []int64) []Assignment {
 // GetByID ..
 func GetByID(id int64) (Assignment, error) {
 	var assignment Assignment
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			name,
@@ -379,10 +345,10 @@ func GetByID(id int64) (Assignment, error) {
 		FROM
 			assignments
 		WHERE
-			id = (%d)
-		LIMIT 1;`, id)
+			id = (?)
+		LIMIT 1;`
 
-	err := conn.DB.Get(&assignment, query)
+	err := conn.Get(&assignment, query, id)
 	if err != nil {
 		return assignment, err
 	}
@@ -391,14 +357,14 @@ func GetByID(id int64) (Assignment, error) {
 
 // SelectCountByID ..
 func SelectCountByID(id int64) (int, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT COUNT(*) FROM
 			assignments
 		WHERE
-			id = (%d);
-		`, id)
+			id = (?);
+		`
 	var count int
-	err := conn.DB.Get(&count, query)
+	err := conn.Get(&count, query, id)
 	if err != nil {
 		return count, err
 	}
@@ -409,15 +375,15 @@ func SelectCountByID(id int64) (int, error) {
 func IsAssignmentExist(AssignmentID int64) bool {
 
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			assignments
 		WHERE
-			id = (%d)
-		LIMIT 1;`, AssignmentID)
-	err := conn.DB.Get(&x, query)
+			id = (?)
+		LIMIT 1;`
+	err := conn.Get(&x, query, AssignmentID)
 	if err != nil {
 		return false
 	}
@@ -427,15 +393,15 @@ func IsAssignmentExist(AssignmentID int64) bool {
 // IsAssignmentExistByGradeParameterID func ...
 func IsAssignmentExistByGradeParameterID(assignmentID, gradeParameterID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			assignments
 		WHERE
-			id = (%d) AND grade_parameters_id =(%d)
-		LIMIT 1;`, assignmentID, gradeParameterID)
-	err := conn.DB.Get(&x, query)
+			id = (?) AND grade_parameters_id =(?)
+		LIMIT 1;`
+	err := conn.Get(&x, query, assignmentID, gradeParameterID)
 	if err != nil {
 		return false
 	}
@@ -444,30 +410,18 @@ func IsAssignmentExistByGradeParameterID(assignmentID, gradeParameterID int64) b
 
 // UpdateSubmit ...
 func UpdateSubmit(id, userID int64, desc sql.NullString, tx *sqlx.Tx) error {
-	var result sql.Result
-	var err error
-
-	queryDesc := fmt.Sprintf("(NULL)")
-	if desc.Valid {
-		queryDesc = fmt.Sprintf("('%s')", desc.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE  
 			p_users_assignments 
 		SET
-			description = %s,
+			description = (?),
 			updated_at = NOW()
 		WHERE
-			assignments_id = (%d) AND
-			users_id = (%d);
-		`, queryDesc, id, userID)
+			assignments_id = (?) AND
+			users_id = (?);
+		`
 
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, desc, id, userID)
 	if err != nil {
 		return err
 	}
@@ -481,15 +435,7 @@ func UpdateSubmit(id, userID int64, desc sql.NullString, tx *sqlx.Tx) error {
 // InsertSubmit ...
 func InsertSubmit(id, userID int64, desc sql.NullString, tx *sqlx.Tx) error {
 
-	var result sql.Result
-	var err error
-
-	queryDesc := fmt.Sprintf("(NULL)")
-	if desc.Valid {
-		queryDesc = fmt.Sprintf("('%s')", desc.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO 
 			p_users_assignments (
 				assignments_id,
@@ -499,19 +445,15 @@ func InsertSubmit(id, userID int64, desc sql.NullString, tx *sqlx.Tx) error {
 				updated_at
 			)
 		VALUES(
-				(%d),
-				(%d),
-				%s,
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-	`, id, userID, queryDesc)
+	`
 
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, id, userID, desc)
 	if err != nil {
 		return err
 	}
@@ -530,7 +472,6 @@ func SelectByGP(gpsID []int64, isSort bool) ([]Assignment, error) {
 	if len(gpsID) < 1 {
 		return assignments, nil
 	}
-	queryGP := strings.Join(helper.Int64ToStringSlice(gpsID), ", ")
 	querySort := ""
 	if isSort {
 		querySort = "ORDER BY due_date ASC"
@@ -550,10 +491,10 @@ func SelectByGP(gpsID []int64, isSort bool) ([]Assignment, error) {
 		WHERE
 			grade_parameters_id
 		IN
-			(%s)
+			(?)
 		%s;
-		`, queryGP, querySort)
-	err := conn.DB.Select(&assignments, query)
+		`, querySort)
+	err := conn.Select(&assignments, query, gpsID)
 	if err != nil {
 		return assignments, err
 	}
@@ -563,7 +504,7 @@ func SelectByGP(gpsID []int64, isSort bool) ([]Assignment, error) {
 // GetSubmittedByUser ...
 func GetSubmittedByUser(id int64, userID int64) (*UserAssignment, error) {
 	assignment := &UserAssignment{}
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			assignments_id,
 			users_id,
@@ -574,11 +515,11 @@ func GetSubmittedByUser(id int64, userID int64) (*UserAssignment, error) {
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d) AND
-			users_id = (%d)
-		LIMIT 1;`, id, userID)
+			assignments_id = (?) AND
+			users_id = (?)
+		LIMIT 1;`
 
-	err := conn.DB.Get(assignment, query)
+	err := conn.Get(assignment, query, id, userID)
 	if err != nil {
 		if err != sql.ErrNoRows {
 			return nil, err
@@ -595,8 +536,7 @@ func SelectSubmittedByUser(id []int64, userID int64) ([]UserAssignment, error) {
 		return assignment, nil
 	}
 
-	queryID := strings.Join(helper.Int64ToStringSlice(id), ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			assignments_id,
 			users_id,
@@ -607,10 +547,10 @@ func SelectSubmittedByUser(id []int64, userID int64) ([]UserAssignment, error) {
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id IN (%s) AND
-			users_id = (%d)`, queryID, userID)
+			assignments_id IN (?) AND
+			users_id = (?)`
 
-	err := conn.DB.Select(&assignment, query)
+	err := conn.Select(&assignment, query, id, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -620,7 +560,7 @@ func SelectSubmittedByUser(id []int64, userID int64) ([]UserAssignment, error) {
 // SelectUserAssignmentByID ..
 func SelectUserAssignmentByID(assignmentID int64, limit, offset int) ([]UserAssignment, error) {
 	var assignment []UserAssignment
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			assignments_id,
 			users_id,
@@ -631,10 +571,10 @@ func SelectUserAssignmentByID(assignmentID int64, limit, offset int) ([]UserAssi
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d)
-		LIMIT %d
-		OFFSET %d`, assignmentID, limit, offset)
-	err := conn.DB.Select(&assignment, query)
+			assignments_id = (?)
+		LIMIT ?
+		OFFSET ?`
+	err := conn.Select(&assignment, query, assignmentID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -644,7 +584,7 @@ func SelectUserAssignmentByID(assignmentID int64, limit, offset int) ([]UserAssi
 // SelectUserScoreByID ..
 func SelectUserScoreByID(assignmentID int64) ([]UserScore, error) {
 	var assignment []UserScore
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			users_id,
 			score,
@@ -652,10 +592,10 @@ func SelectUserScoreByID(assignmentID int64) ([]UserScore, error) {
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d)
+			assignments_id = (?)
 		ORDER BY users_id;
-		`, assignmentID)
-	err := conn.DB.Select(&assignment, query)
+		`
+	err := conn.Select(&assignment, query, assignmentID)
 	if err != nil {
 		return nil, err
 	}
@@ -664,15 +604,15 @@ func SelectUserScoreByID(assignmentID int64) ([]UserScore, error) {
 
 // SelectCountUsrAsgByID ..
 func SelectCountUsrAsgByID(assignmentID int64) (int, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT COUNT(*)
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d)
-		`, assignmentID)
+			assignments_id = (?)
+		`
 	var count int
-	err := conn.DB.Get(&count, query)
+	err := conn.Get(&count, query, assignmentID)
 	if err != nil {
 		return count, err
 	}
@@ -682,16 +622,16 @@ func SelectCountUsrAsgByID(assignmentID int64) (int, error) {
 // IsExistSubmitted used for check that are there any student which have uploaded it assignments
 func IsExistSubmitted(id int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d)
-		LIMIT 1`, id)
+			assignments_id = (?)
+		LIMIT 1`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, id)
 	if err != nil {
 		return false
 	}
@@ -701,16 +641,16 @@ func IsExistSubmitted(id int64) bool {
 // IsUserHaveUploadedAsssignment func ...
 func IsUserHaveUploadedAsssignment(AssignmentID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT 
 			'x'
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d)
+			assignments_id = (?)
 		LIMIT 1;
-		`, AssignmentID)
-	err := conn.DB.Get(&x, query)
+		`
+	err := conn.Get(&x, query, AssignmentID)
 	if err != nil {
 		return false
 	}
@@ -719,20 +659,14 @@ func IsUserHaveUploadedAsssignment(AssignmentID int64) bool {
 
 // DeleteAssignment func ...
 func DeleteAssignment(AssignmentID int64, tx *sqlx.Tx) error {
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			assignments
 		WHERE
-			id=(%d)
-		;`, AssignmentID)
+			id=(?)
+		;`
 
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, AssignmentID)
 	if err != nil {
 		return err
 	}
@@ -745,7 +679,7 @@ func DeleteAssignment(AssignmentID int64, tx *sqlx.Tx) error {
 
 // GetAllUserAssignmentByAssignmentID func ...
 func GetAllUserAssignmentByAssignmentID(AssignmentID, limit, offset int64) ([]DetailUploadedAssignment, error) {
-	query := fmt.Sprintf(`
+	query, args, err := conn.In(`
 		SELECT 
 			pus.assignments_id,
 			pus.score,
@@ -760,12 +694,16 @@ func GetAllUserAssignmentByAssignmentID(AssignmentID, limit, offset int64) ([]De
 		ON
 			asg.id=pus.assignments_id
 		WHERE
-			pus.assignments_id = (%d)
-		LIMIT %d OFFSET %d;
+			pus.assignments_id = (?)
+		LIMIT ? OFFSET ?;
 			`, AssignmentID, limit, offset)
 
 	var assignment []DetailUploadedAssignment
-	rows, err := conn.DB.Query(query)
+	if err != nil {
+		return assignment, err
+	}
+
+	rows, err := conn.DB.Query(query, args...)
 	if err != nil {
 		return assignment, err
 	}
@@ -795,22 +733,16 @@ func GetAllUserAssignmentByAssignmentID(AssignmentID, limit, offset int64) ([]De
 
 // UpdateScoreAssignment func ...
 func UpdateScoreAssignment(assignmentID, userID int64, score float32, tx *sqlx.Tx) error {
-	var result sql.Result
-	var err error
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE  
 			p_users_assignments 
 		SET
-				score = (%g),
+				score = (?),
 				updated_at = NOW()
 		WHERE
-			assignments_id = (%d) AND users_id = (%d)
-		;`, score, assignmentID, userID)
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+			assignments_id = (?) AND users_id = (?)
+		;`
+	result, err := conn.TxExec(tx, query, score, assignmentID, userID)
 	if err != nil {
 		return err
 	}
@@ -824,17 +756,17 @@ func UpdateScoreAssignment(assignmentID, userID int64, score float32, tx *sqlx.T
 // IsAssignmentMustUpload func ...
 func IsAssignmentMustUpload(assingmentID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			assignments
 		WHERE
-			id=(%d) AND status=1
+			id=(?) AND status=1
 		LIMIT 1;
-		`, assingmentID)
+		`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, assingmentID)
 	if err == sql.ErrNoRows {
 		return false
 	}
@@ -844,7 +776,7 @@ func IsAssignmentMustUpload(assingmentID int64) bool {
 // // SelectUserAssignmentsByStatusID func ..
 // func SelectUserAssignmentsByStatusID(assignmentID int64) ([]UserAssignmentDetail, error) {
 // 	var assignment []UserAssignmentDetail
-// 	query := fmt.Sprintf(`
+// 	query := `
 // 			SELECT
 // 				usr.identity_code,
 // 				usr.name,
@@ -858,9 +790,9 @@ func IsAssignmentMustUpload(assingmentID int64) bool {
 // 			ON
 // 				pas.users_id=usr.id
 // 			WHERE
-// 				pas.assignments_id = (%d)
-// 				`, assignmentID)
-// 	err := conn.DB.Select(&assignment, query)
+// 				pas.assignments_id = (?)
+// 				`
+// 	err := conn.Select(&assignment, query, assignmentID)
 // 	if err != nil {
 // 		return assignment, err
 // 	}
@@ -871,9 +803,11 @@ func IsAssignmentMustUpload(assingmentID int64) bool {
 func CreateScore(assignmentID int64, usersID []int64, score []float32, tx *sqlx.Tx) error {
 
 	var value []string
+	var args []interface{}
 	length := len(usersID)
 	for i := 0; i < length; i++ {
-		value = append(value, fmt.Sprintf("(%d, %d, %v, NOW(), NOW())", assignmentID, usersID[i], score[i]))
+		value = append(value, "(?, ?, ?, NOW(), NOW())")
+		args = append(args, assignmentID, usersID[i], score[i])
 	}
 	queryValue := strings.Join(value, ", ")
 	query := fmt.Sprintf(`
@@ -890,12 +824,7 @@ func CreateScore(assignmentID int64, usersID []int64, score []float32, tx *sqlx.
 		ON DUPLICATE KEY UPDATE
 		score=VALUES(score), updated_at=VALUES(updated_at)
 		;`, queryValue)
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	_, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -905,17 +834,17 @@ func CreateScore(assignmentID int64, usersID []int64, score []float32, tx *sqlx.
 
 // // GetDueDateAssignment func ...
 // func GetDueDateAssignment(assignmentID int64) (time.Time, error) {
-// 	query := fmt.Sprintf(`
+// 	query := `
 // 			SELECT
 // 				due_date
 // 			FROM
 // 				assignments
 // 			WHERE
-// 				id=(%d)
+// 				id=(?)
 // 			LIMIT 1;
-// 		`, assignmentID)
+// 		`
 // 	var dueDate time.Time
-// 	err := conn.DB.Get(&dueDate, query)
+// 	err := conn.Get(&dueDate, query, assignmentID)
 // 	if err != nil {
 // 		return dueDate, err
 // 	}
@@ -924,16 +853,16 @@ func CreateScore(assignmentID int64, usersID []int64, score []float32, tx *sqlx.
 
 // // SelectAssignmentIDByGradeParameter func ..
 // func SelectAssignmentIDByGradeParameter(gradeParameterID int64) ([]int64, error) {
-// 	query := fmt.Sprintf(`
+// 	query := `
 // 		SELECT DISTINCT
 // 			id
 // 		FROM
 // 			assignments
 // 		WHERE
-// 			grade_parameters_id=(%d);
-// 		`, gradeParameterID)
+// 			grade_parameters_id=(?);
+// 		`
 // 	var id []int64
-// 	err := conn.DB.Select(&id, query)
+// 	err := conn.Select(&id, query, gradeParameterID)
 // 	if err != nil {
 // 		return id, err
 // 	}
@@ -1094,3 +1023,49 @@ func CreateScore(assignmentID int64, usersID []int64, score []float32, tx *sqlx.
 // 	return res
 
 // }
+
+// SelectSubmissionByUserID returns the grades and the submissions of the user on every assignment
+func SelectSubmissionByUserID(userID int64) ([]Submission, error) {
+	var submissions []Submission
+	query := `
+		SELECT
+			pua.assignments_id,
+			asg.name,
+			gp.schedules_id,
+			pua.score,
+			pua.description,
+			pua.created_at,
+			pua.updated_at
+		FROM
+			p_users_assignments pua
+		INNER JOIN
+			assignments asg
+		ON
+			pua.assignments_id = asg.id
+		INNER JOIN
+			grade_parameters gp
+		ON
+			asg.grade_parameters_id = gp.id
+		WHERE
+			pua.users_id = (?)
+		ORDER BY
+			pua.created_at ASC;
+		`
+	err := conn.Select(&submissions, query, userID)
+	if err != nil {
+		return submissions, err
+	}
+	return submissions, nil
+}
+
+// DeleteSubmissionByUserID removes the grades and the submissions of the user on every assignment
+func DeleteSubmissionByUserID(userID int64, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			p_users_assignments
+		WHERE
+			users_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, userID)
+	return err
+}
@@ -0,0 +1,106 @@
+package assignment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// SelectTemplateByGP returns the assignments of the grade parameters with their upload limits, the earliest is the first
+/*
+	@params:
+		gpsID	= []int64
+	@example:
+		gpsID	= [1231232]
+	@return
+		[]Assignment
+*/
+func SelectTemplateByGP(gpsID []int64) ([]Assignment, error) {
+	var assignments []Assignment
+	if len(gpsID) < 1 {
+		return assignments, nil
+	}
+
+	query := `
+		SELECT
+			id,
+			name,
+			status,
+			description,
+			grade_parameters_id,
+			due_date,
+			max_size,
+			max_file,
+			created_at,
+			updated_at
+		FROM
+			assignments
+		WHERE
+			grade_parameters_id IN (?)
+		ORDER BY
+			id ASC;
+		`
+	err := conn.Select(&assignments, query, gpsID)
+	if err != nil {
+		return assignments, err
+	}
+	return assignments, nil
+}
+
+// Clone function to copy the assignment into the grade parameter with a new due date, the submissions are not copied
+/*
+	@params:
+		assignment	= Assignment
+		gpID		= int64
+		dueDate		= time.Time
+	@example:
+		assignment	= {Name: Tugas 1, Status: 1, MaxSize: 5, MaxFile: 1}
+		gpID		= 1231240
+		dueDate		= 2018-09-10 23:59:59
+	@return
+		id			= the id of the copy
+*/
+func Clone(assignment Assignment, gpID int64, dueDate time.Time, tx *sqlx.Tx) (int64, error) {
+	query := `
+		INSERT INTO
+			assignments (
+				name,
+				description,
+				status,
+				due_date,
+				grade_parameters_id,
+				max_size,
+				max_file,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+	result, err := conn.TxExec(tx, query,
+		assignment.Name,
+		assignment.Description,
+		assignment.Status,
+		dueDate,
+		gpID,
+		assignment.MaxSize,
+		assignment.MaxFile)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("No rows affected")
+	}
+	return id, nil
+}
@@ -97,3 +97,14 @@ type DetailUploadedAssignment struct {
 	DueDate               string
 	PathFile              sql.NullString
 }
+
+// Submission is the grade and the submission of the user on the assignment
+type Submission struct {
+	AssignmentID   int64           `db:"assignments_id"`
+	AssignmentName string          `db:"name"`
+	ScheduleID     int64           `db:"schedules_id"`
+	Score          sql.NullFloat64 `db:"score"`
+	Description    sql.NullString  `db:"description"`
+	CreatedAt      time.Time       `db:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at"`
+}
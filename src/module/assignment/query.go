@@ -22,7 +22,7 @@ const queryGetIncompleteByUserID = `
 					FROM
 						p_users_courses
 					WHERE
-						users_id = (%d)
+						users_id = (?)
 				)
 		) AND id NOT IN (
 			SELECT
@@ -30,7 +30,7 @@ const queryGetIncompleteByUserID = `
 			FROM
 				p_users_assignments
 			WHERE
-				users_id = (%d)
+				users_id = (?)
 		);
 `
 
@@ -50,7 +50,7 @@ const queryGetByCourseID = `
 			FROM
 				grade_parameters
 			WHERE
-				courses_id = (%d)
+				courses_id = (?)
 		);
 `
 
@@ -60,5 +60,5 @@ const queryGetCompleteByUserID = `
 	FROM
 		p_users_assignments
 	WHERE
-		users_id = (%d);
+		users_id = (?);
 `
@@ -0,0 +1,33 @@
+package assignment
+
+// Score returns the average score of the assignments of a grade parameter. An assignment which isn't submitted
+// scores zero, and a submitted assignment which isn't scored yet is left out of the average
+/*
+	@params:
+		assignments	= []Assignment
+		submitted	= map[int64]UserAssignment, keyed by the assignment id
+	@example:
+		assignments	= [{ID: 1}, {ID: 2}]
+		submitted	= {1: {Score: 80}, 2: {Score: 90}}
+	@return
+		score		= 85
+*/
+func Score(assignments []Assignment, submitted map[int64]UserAssignment) float64 {
+	score := float64(0)
+	count := len(assignments)
+	for _, assignment := range assignments {
+		submit, exist := submitted[assignment.ID]
+		if !exist {
+			continue
+		}
+		if submit.Score.Valid {
+			score += submit.Score.Float64
+		} else {
+			count--
+		}
+	}
+	if count > 0 {
+		score = score / float64(count)
+	}
+	return score
+}
@@ -0,0 +1,52 @@
+package assignment
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	assignments := []Assignment{{ID: 1}, {ID: 2}, {ID: 3}}
+	tests := []struct {
+		name      string
+		submitted map[int64]UserAssignment
+		want      float64
+	}{
+		{
+			name: "All Scored",
+			submitted: map[int64]UserAssignment{
+				1: {Score: sql.NullFloat64{Float64: 80, Valid: true}},
+				2: {Score: sql.NullFloat64{Float64: 90, Valid: true}},
+				3: {Score: sql.NullFloat64{Float64: 100, Valid: true}},
+			},
+			want: 90,
+		},
+		{
+			name: "Not Submitted",
+			submitted: map[int64]UserAssignment{
+				1: {Score: sql.NullFloat64{Float64: 90, Valid: true}},
+			},
+			want: 30,
+		},
+		{
+			name: "Not Scored",
+			submitted: map[int64]UserAssignment{
+				1: {Score: sql.NullFloat64{Float64: 90, Valid: true}},
+				2: {},
+			},
+			want: 45,
+		},
+		{
+			name:      "Nothing Scored",
+			submitted: map[int64]UserAssignment{1: {}, 2: {}, 3: {}},
+			want:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(assignments, tt.submitted); got != tt.want {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
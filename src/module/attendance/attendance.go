@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/melodiez14/meiko/src/util/helper"
-
 	"github.com/jmoiron/sqlx"
 
 	"github.com/melodiez14/meiko/src/util/conn"
@@ -16,7 +14,7 @@ import (
 func GetMeeting(meetingNumber uint8, scheduleID int64) (Meeting, error) {
 
 	var meeting Meeting
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			description,
@@ -24,11 +22,11 @@ func GetMeeting(meetingNumber uint8, scheduleID int64) (Meeting, error) {
 		FROM
 			meetings
 		WHERE
-			number = (%d) AND
-			schedules_id = (%d)
+			number = (?) AND
+			schedules_id = (?)
 		LIMIT 1;
-		`, meetingNumber, scheduleID)
-	err := conn.DB.Get(&meeting, query)
+		`
+	err := conn.Get(&meeting, query, meetingNumber, scheduleID)
 	if err != nil {
 		return meeting, err
 	}
@@ -38,7 +36,7 @@ func GetMeeting(meetingNumber uint8, scheduleID int64) (Meeting, error) {
 
 func GetMeetingByID(id uint64) (Meeting, error) {
 	var meeting Meeting
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			subject,
@@ -48,9 +46,9 @@ func GetMeetingByID(id uint64) (Meeting, error) {
 			schedules_id
 		FROM
 			meetings
-		WHERE id = (%d)
-		LIMIT 1`, id)
-	err := conn.DB.Get(&meeting, query)
+		WHERE id = (?)
+		LIMIT 1`
+	err := conn.Get(&meeting, query, id)
 	if err != nil {
 		return meeting, err
 	}
@@ -59,8 +57,8 @@ func GetMeetingByID(id uint64) (Meeting, error) {
 
 func IsExistMeeting(number uint8, scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf(`SELECT 'x' FROM meetings WHERE number = (%d) AND schedules_id = (%d) LIMIT 1`, number, scheduleID)
-	err := conn.DB.Get(&x, query)
+	query := `SELECT 'x' FROM meetings WHERE number = (?) AND schedules_id = (?) LIMIT 1`
+	err := conn.Get(&x, query, number, scheduleID)
 	if err != nil {
 		return false
 	}
@@ -69,8 +67,8 @@ func IsExistMeeting(number uint8, scheduleID int64) bool {
 
 func IsExistByMeetingID(meetingID uint64) bool {
 	var x string
-	query := fmt.Sprintf(`SELECT 'x' FROM attendances WHERE meetings_id = (%d) LIMIT 1`, meetingID)
-	err := conn.DB.Get(&x, query)
+	query := `SELECT 'x' FROM attendances WHERE meetings_id = (?) LIMIT 1`
+	err := conn.Get(&x, query, meetingID)
 	if err != nil {
 		return false
 	}
@@ -84,8 +82,10 @@ func Insert(usersID []int64, meetingID uint64, tx *sqlx.Tx) error {
 	}
 
 	var value []string
+	var args []interface{}
 	for _, val := range usersID {
-		value = append(value, fmt.Sprintf("(%d, %d, NOW(), NOW())", meetingID, val))
+		value = append(value, "(?, ?, NOW(), NOW())")
+		args = append(args, meetingID, val)
 	}
 
 	queryValue := strings.Join(value, ", ")
@@ -99,12 +99,7 @@ func Insert(usersID []int64, meetingID uint64, tx *sqlx.Tx) error {
 			) VALUES %s;
 	`, queryValue)
 
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	_, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -119,17 +114,11 @@ func Delete(usersID []int64, meetingID uint64, tx *sqlx.Tx) error {
 		return fmt.Errorf("User ID cannot be empty")
 	}
 
-	queryUsers := strings.Join(helper.Int64ToStringSlice(usersID), ", ")
-	query := fmt.Sprintf(`
-		DELETE FROM attendances WHERE meetings_id = (%d) AND users_id IN (%s);
-	`, meetingID, queryUsers)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	query := `
+		DELETE FROM attendances WHERE meetings_id = (?) AND users_id IN (?);
+	`
+
+	_, err := conn.TxExec(tx, query, meetingID, usersID)
 	if err != nil {
 		return err
 	}
@@ -140,12 +129,7 @@ func Delete(usersID []int64, meetingID uint64, tx *sqlx.Tx) error {
 // InsertMeeting ...
 func InsertMeeting(subject string, number uint8, description sql.NullString, date time.Time, scheduleID int64, tx *sqlx.Tx) (uint64, error) {
 
-	desc := "(NULL)"
-	if description.Valid {
-		desc = fmt.Sprintf(`('%s')`, description.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			meetings (
 				subject,
@@ -156,23 +140,17 @@ func InsertMeeting(subject string, number uint8, description sql.NullString, dat
 				created_at,
 				updated_at
 			) VALUES (
-				('%s'),
-				(%d),
-				%s,
-				('%v'),
-				(%d),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-	`, subject, number, desc, date, scheduleID)
-
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	`
+
+	result, err := conn.TxExec(tx, query, subject, number, description, date, scheduleID)
 	if err != nil {
 		return 0, err
 	}
@@ -187,31 +165,20 @@ func InsertMeeting(subject string, number uint8, description sql.NullString, dat
 
 func UpdateMeeting(id uint64, subject string, number uint8, description sql.NullString, date time.Time, tx *sqlx.Tx) error {
 
-	desc := "(NULL)"
-	if description.Valid {
-		desc = fmt.Sprintf(`('%s')`, description.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			meetings
 		SET
-			subject = ('%s'),
-			number = (%d),
-			description = %s,
-			date = ('%v'),
+			subject = (?),
+			number = (?),
+			description = (?),
+			date = (?),
 			updated_at = NOW()
 		WHERE
-			id = (%d);
-	`, subject, number, desc, date, id)
-
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+			id = (?);
+	`
+
+	result, err := conn.TxExec(tx, query, subject, number, description, date, id)
 	if err != nil {
 		return err
 	}
@@ -224,20 +191,14 @@ func UpdateMeeting(id uint64, subject string, number uint8, description sql.Null
 }
 
 func DeleteByMeetingID(meetingID uint64, tx *sqlx.Tx) error {
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			attendances
 		WHERE
-			meetings_id = (%d);
-	`, meetingID)
-
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+			meetings_id = (?);
+	`
+
+	result, err := conn.TxExec(tx, query, meetingID)
 	if err != nil {
 		return err
 	}
@@ -251,20 +212,14 @@ func DeleteByMeetingID(meetingID uint64, tx *sqlx.Tx) error {
 
 func DeleteMeeting(id uint64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			meetings
 		WHERE
-			id = (%d);
-	`, id)
-
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+			id = (?);
+	`
+
+	result, err := conn.TxExec(tx, query, id)
 	if err != nil {
 		return err
 	}
@@ -281,7 +236,7 @@ func SelectMeetingByPage(scheduleID int64, limit, offset int, isCount bool) ([]M
 
 	meetings := []Meeting{}
 	var count int
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			m.id,
 			m.number,
@@ -291,13 +246,13 @@ func SelectMeetingByPage(scheduleID int64, limit, offset int, isCount bool) ([]M
 			count(a.meetings_id) as total
 		FROM attendances a
 		RIGHT JOIN meetings m ON m.id = a.meetings_id
-		WHERE m.schedules_id = (%d)
+		WHERE m.schedules_id = (?)
 		GROUP BY m.id
 		ORDER BY m.number ASC
-		LIMIT %d OFFSET %d
-	`, scheduleID, limit, offset)
+		LIMIT ? OFFSET ?
+	`
 
-	err := conn.DB.Select(&meetings, query)
+	err := conn.Select(&meetings, query, scheduleID, limit, offset)
 	if err != nil {
 		return meetings, count, err
 	}
@@ -306,16 +261,16 @@ func SelectMeetingByPage(scheduleID int64, limit, offset int, isCount bool) ([]M
 		return meetings, count, nil
 	}
 
-	query = fmt.Sprintf(`
+	query = `
 		SELECT
 			COUNT(*)
 		FROM
 			meetings
 		WHERE
-			schedules_id = (%d)
-	`, scheduleID)
+			schedules_id = (?)
+	`
 
-	err = conn.DB.Get(&count, query)
+	err = conn.Get(&count, query, scheduleID)
 	if err != nil {
 		return meetings, count, err
 	}
@@ -325,15 +280,15 @@ func SelectMeetingByPage(scheduleID int64, limit, offset int, isCount bool) ([]M
 
 func SelectUserIDByMeetingID(meetingID uint64) ([]int64, error) {
 	usersID := []int64{}
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			users_id
 		FROM
 			attendances
 		WHERE
-			meetings_id = (%d);	
-	`, meetingID)
-	err := conn.DB.Select(&usersID, query)
+			meetings_id = (?);	
+	`
+	err := conn.Select(&usersID, query, meetingID)
 	if err != nil {
 		return usersID, err
 	}
@@ -343,16 +298,16 @@ func SelectUserIDByMeetingID(meetingID uint64) ([]int64, error) {
 func SelectMeetingIDByScheduleID(userID, scheduleID int64) ([]int64, error) {
 
 	var meetingsID []int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id
 		FROM
 			meetings
 		WHERE
-			schedules_id = (%d);
-	`, scheduleID)
+			schedules_id = (?);
+	`
 
-	err := conn.DB.Select(&meetingsID, query)
+	err := conn.Select(&meetingsID, query, scheduleID)
 	if err != nil {
 		return meetingsID, err
 	}
@@ -363,19 +318,17 @@ func SelectMeetingIDByScheduleID(userID, scheduleID int64) ([]int64, error) {
 func CountByUserMeeting(userID int64, meetingsID []int64) (int, error) {
 
 	var count int
-	meetingsString := helper.Int64ToStringSlice(meetingsID)
-	queryMeetingsID := strings.Join(meetingsString, ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			COUNT(*)
 		FROM
 			attendances
 		WHERE
-			users_id = (%d) AND
-			meetings_id IN (%s);
-	`, userID, queryMeetingsID)
+			users_id = (?) AND
+			meetings_id IN (?);
+	`
 
-	err := conn.DB.Get(&count, query)
+	err := conn.Get(&count, query, userID, meetingsID)
 	if err != nil {
 		return count, err
 	}
@@ -386,18 +339,21 @@ func CountByUserMeeting(userID int64, meetingsID []int64) (int, error) {
 func CountByUserSchedule(userID int64, schedulesID []int64) (map[int64]AttendanceReport, error) {
 
 	report := map[int64]AttendanceReport{}
-	querySchID := strings.Join(helper.Int64ToStringSlice(schedulesID), ", ")
-	query := fmt.Sprintf(`
+	query, args, err := conn.In(`
 		SELECT
 			m.schedules_id,
 			count(m.id) as meeting_total,
 			count(a.meetings_id) as attendance_total
 		FROM meetings m
-		LEFT JOIN attendances a ON m.id = a.meetings_id AND users_id = (%d)
-		WHERE m.schedules_id IN (%s)
+		LEFT JOIN attendances a ON m.id = a.meetings_id AND users_id = (?)
+		WHERE m.schedules_id IN (?)
 		GROUP BY m.schedules_id
-	`, userID, querySchID)
-	rows, err := conn.DB.Queryx(query)
+	`, userID, schedulesID)
+	if err != nil {
+		return report, err
+	}
+
+	rows, err := conn.DB.Queryx(query, args...)
 	if err != nil {
 		return report, err
 	}
@@ -416,3 +372,81 @@ func CountByUserSchedule(userID int64, schedulesID []int64) (map[int64]Attendanc
 
 	return report, nil
 }
+
+// SelectByUserID returns the meetings attended by the user
+func SelectByUserID(userID int64) ([]UserAttendance, error) {
+	var attendances []UserAttendance
+	query := `
+		SELECT
+			a.meetings_id,
+			m.number,
+			m.subject,
+			m.date,
+			m.schedules_id,
+			a.created_at
+		FROM
+			attendances a
+		INNER JOIN
+			meetings m
+		ON
+			a.meetings_id = m.id
+		WHERE
+			a.users_id = (?)
+		ORDER BY
+			m.date ASC;
+	`
+	err := conn.Select(&attendances, query, userID)
+	if err != nil {
+		return attendances, err
+	}
+	return attendances, nil
+}
+
+// DeleteByUserID removes all attendances of the user
+func DeleteByUserID(userID int64, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			attendances
+		WHERE
+			users_id = (?);
+	`
+	_, err := conn.TxExec(tx, query, userID)
+	return err
+}
+
+// SelectMeetingBySchedule returns the meetings of the schedules ordered by date
+/*
+	@params:
+		schedulesID	= []int64
+	@example:
+		schedulesID	= [100192, 100193]
+	@return
+		[]Meeting
+*/
+func SelectMeetingBySchedule(schedulesID []int64) ([]Meeting, error) {
+	var meetings []Meeting
+	if len(schedulesID) < 1 {
+		return meetings, nil
+	}
+
+	query := `
+		SELECT
+			id,
+			subject,
+			number,
+			description,
+			date,
+			schedules_id
+		FROM
+			meetings
+		WHERE
+			schedules_id IN (?)
+		ORDER BY
+			date ASC;
+		`
+	err := conn.Select(&meetings, query, schedulesID)
+	if err != nil {
+		return meetings, err
+	}
+	return meetings, nil
+}
@@ -30,3 +30,12 @@ type AttendanceReport struct {
 	MeetingTotal    int `db:"meeting_total"`
 	AttendanceTotal int `db:"attendance_total"`
 }
+
+type UserAttendance struct {
+	MeetingID  uint64    `db:"meetings_id"`
+	Number     uint8     `db:"number"`
+	Subject    string    `db:"subject"`
+	Date       time.Time `db:"date"`
+	ScheduleID int64     `db:"schedules_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
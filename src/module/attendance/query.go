@@ -9,6 +9,6 @@ const queryGetByUserCourse = `
 	FROM
 		attendances
 	WHERE
-		p_users_courses_users_id = (%d) AND
-		p_users_courses_courses_id = (%d);
+		p_users_courses_users_id = (?) AND
+		p_users_courses_courses_id = (?);
 `
@@ -0,0 +1,201 @@
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// Insert records the change inside tx, so the log is only kept when the change is committed
+/*
+	@params:
+		actorID		= int64
+		action		= string
+		table		= string
+		targetID	= int64
+		before		= interface{}, nil on create
+		after		= interface{}, nil on delete
+		tx			= *sqlx.Tx
+	@example:
+		actorID		= 1
+		action		= UPDATE
+		table		= users
+		targetID	= 12
+		before		= {"status":1}
+		after		= {"status":2}
+	@return
+*/
+func Insert(actorID int64, action, table string, targetID int64, before, after interface{}, tx *sqlx.Tx) error {
+	beforeValue, err := toJSON(before)
+	if err != nil {
+		return err
+	}
+
+	afterValue, err := toJSON(after)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO
+			audit_logs (
+				actor_id,
+				action,
+				target_table,
+				target_id,
+				before_value,
+				after_value,
+				created_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				NOW()
+			);
+		`
+	_, err = conn.TxExec(tx, query, actorID, action, table, targetID, beforeValue, afterValue)
+	return err
+}
+
+// Redact removes the values of the logs of the target, the logs are kept to show what was changed and by whom
+/*
+	@params:
+		table		= string
+		targetID	= int64
+		tx			= *sqlx.Tx
+	@example:
+		table		= users
+		targetID	= 12
+	@return
+*/
+func Redact(table string, targetID int64, tx *sqlx.Tx) error {
+	query := `
+		UPDATE
+			audit_logs
+		SET
+			before_value = NULL,
+			after_value = NULL
+		WHERE
+			target_table = (?) AND
+			target_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, table, targetID)
+	return err
+}
+
+// SelectByPage returns the logs matched by the filter, the newest first
+/*
+	@params:
+		filter	= Filter
+		limit	= int
+		offset	= int
+		isCount	= bool
+	@example:
+		filter	= {TargetTable: users, TargetID: 12}
+		limit	= 10
+		offset	= 0
+		isCount	= true
+	@return
+		logs	= []Log
+		count	= 25
+*/
+func SelectByPage(filter Filter, limit, offset int, isCount bool) ([]Log, int, error) {
+	logs := []Log{}
+	var count int
+
+	where, args := filter.where()
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			actor_id,
+			action,
+			target_table,
+			target_id,
+			before_value,
+			after_value,
+			created_at
+		FROM
+			audit_logs
+		%s
+		ORDER BY
+			id DESC
+		LIMIT ?
+		OFFSET ?;
+		`, where)
+	err := conn.Select(&logs, query, append(args, limit, offset)...)
+	if err != nil {
+		return logs, count, err
+	}
+
+	if !isCount {
+		return logs, count, nil
+	}
+
+	query = fmt.Sprintf(`
+		SELECT
+			COUNT(*)
+		FROM
+			audit_logs
+		%s;
+		`, where)
+	err = conn.Get(&count, query, args...)
+	if err != nil {
+		return logs, count, err
+	}
+
+	return logs, count, nil
+}
+
+// where returns the WHERE clause of the filter and its arguments
+func (f Filter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	if f.ActorID > 0 {
+		conds = append(conds, "actor_id = (?)")
+		args = append(args, f.ActorID)
+	}
+	if len(f.Action) > 0 {
+		conds = append(conds, "action = (?)")
+		args = append(args, f.Action)
+	}
+	if len(f.TargetTable) > 0 {
+		conds = append(conds, "target_table = (?)")
+		args = append(args, f.TargetTable)
+	}
+	if f.TargetID > 0 {
+		conds = append(conds, "target_id = (?)")
+		args = append(args, f.TargetID)
+	}
+	if !f.Since.IsZero() {
+		conds = append(conds, "created_at >= (?)")
+		args = append(args, f.Since)
+	}
+	if !f.Until.IsZero() {
+		conds = append(conds, "created_at < (?)")
+		args = append(args, f.Until)
+	}
+
+	if len(conds) < 1 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+func toJSON(value interface{}) (sql.NullString, error) {
+	if value == nil {
+		return sql.NullString{}, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFilterWhere(t *testing.T) {
+	since := time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   Filter
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:   "Empty",
+			filter: Filter{},
+			want:   "",
+		},
+		{
+			name:     "Target",
+			filter:   Filter{TargetTable: "users", TargetID: 12},
+			want:     "WHERE target_table = (?) AND target_id = (?)",
+			wantArgs: []interface{}{"users", int64(12)},
+		},
+		{
+			name:     "Actor and time",
+			filter:   Filter{ActorID: 1, Action: ActionDelete, Since: since},
+			want:     "WHERE actor_id = (?) AND action = (?) AND created_at >= (?)",
+			wantArgs: []interface{}{int64(1), ActionDelete, since},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, args := tt.filter.where()
+			if got != tt.want {
+				t.Errorf("Filter.where() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Filter.where() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	got, err := toJSON(nil)
+	if err != nil || got.Valid {
+		t.Errorf("toJSON(nil) = %v, %v", got, err)
+	}
+
+	got, err = toJSON(map[string]int8{"status": 2})
+	if err != nil || !got.Valid || got.String != `{"status":2}` {
+		t.Errorf("toJSON() = %v, %v", got, err)
+	}
+}
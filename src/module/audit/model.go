@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	ActionCreate = "CREATE"
+	ActionUpdate = "UPDATE"
+	ActionDelete = "DELETE"
+	ActionErase  = "ERASE"
+)
+
+// Log is a change made by an administrative action. Before and After are the JSON
+// of the changed values, Before is null on create and After is null on delete
+type Log struct {
+	ID          int64          `db:"id"`
+	ActorID     int64          `db:"actor_id"`
+	Action      string         `db:"action"`
+	TargetTable string         `db:"target_table"`
+	TargetID    int64          `db:"target_id"`
+	Before      sql.NullString `db:"before_value"`
+	After       sql.NullString `db:"after_value"`
+	CreatedAt   time.Time      `db:"created_at"`
+}
+
+// Filter narrows down the logs, zero value fields are ignored
+type Filter struct {
+	ActorID     int64
+	Action      string
+	TargetTable string
+	TargetID    int64
+	Since       time.Time
+	Until       time.Time
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/melodiez14/meiko/src/util/helper"
 
 	"github.com/melodiez14/meiko/src/util/conn"
@@ -61,7 +62,7 @@ func LoadByUserID(userID int64) ([]Log, error) {
 
 	var log []Log
 
-	query := fmt.Sprintf(`
+	query := `
 			SELECT
 				id,
 				message,
@@ -70,12 +71,12 @@ func LoadByUserID(userID int64) ([]Log, error) {
 			FROM
 				bot_logs
 			WHERE
-				users_id = (%d)
+				users_id = (?)
 			ORDER BY id DESC
 			LIMIT 20;
-		`, userID)
+		`
 
-	err := conn.DB.Select(&log, query)
+	err := conn.Select(&log, query, userID)
 	if err != nil {
 		return log, err
 	}
@@ -93,7 +94,7 @@ func LoadByID(id, userID int64) ([]Log, error) {
 
 	var log []Log
 
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			message,
@@ -102,13 +103,13 @@ func LoadByID(id, userID int64) ([]Log, error) {
 		FROM
 			bot_logs
 		WHERE
-			id < (%d) AND
-			users_id = (%d)
+			id < (?) AND
+			users_id = (?)
 		ORDER BY id DESC
 		LIMIT 20;
-	`, id, userID)
+	`
 
-	err := conn.DB.Select(&log, query)
+	err := conn.Select(&log, query, id, userID)
 	if err != nil {
 		return log, err
 	}
@@ -128,18 +129,17 @@ func SelectAssistantWithCourse(userID int64, rgxCourse sql.NullString, days []in
 	var assistants []Assistant
 	var queryCourse string
 	var queryDay string
+	args := []interface{}{userID}
 
 	// validate regex
 	if rgxCourse.Valid {
-		queryCourse = fmt.Sprintf(`LOWER(c.name) REGEXP '%s'`, rgxCourse.String)
+		queryCourse = `LOWER(c.name) REGEXP (?)`
+		args = append(args, rgxCourse.String)
 	}
 
 	if len(days) > 0 {
-		daysString := []string{}
-		for _, val := range days {
-			daysString = append(daysString, strconv.FormatInt(int64(val), 10))
-		}
-		queryDay = fmt.Sprintf(`s.day IN (%s)`, strings.Join(daysString, ", "))
+		queryDay = `s.day IN (?)`
+		args = append(args, days)
 	}
 
 	var queryWhere string
@@ -174,7 +174,7 @@ func SelectAssistantWithCourse(userID int64, rgxCourse sql.NullString, days []in
 					FROM
 						p_users_schedules
 					WHERE
-						users_id = (%d) AND
+						users_id = (?) AND
 						status = 1
 				)
 		) as pus ON pus.users_id = u.id
@@ -182,9 +182,9 @@ func SelectAssistantWithCourse(userID int64, rgxCourse sql.NullString, days []in
 		LEFT JOIN courses c ON s.courses_id = c.id
 		LEFT JOIN files f ON f.users_id = u.id AND f.status = 1 AND f.type = 'PL-IMG-T'
 		%s;
-	`, userID, queryWhere)
+	`, queryWhere)
 
-	err := conn.DB.Select(&assistants, query)
+	err := conn.Select(&assistants, query, args...)
 	if err != nil {
 		return assistants, err
 	}
@@ -197,21 +197,21 @@ func SelectScheduleWithCourse(userID int64, rgxCourse sql.NullString, days []int
 	var schedules []Schedule
 	var queryCourse string
 	var queryDay string
-
-	// course query regex
-	if rgxCourse.Valid {
-		queryCourse = fmt.Sprintf(`LOWER(c.name) REGEXP '%s' AND`, rgxCourse.String)
-	}
+	args := []interface{}{}
 
 	// days query
 	if len(days) > 0 {
-		daysString := []string{}
-		for _, val := range days {
-			daysString = append(daysString, strconv.FormatInt(int64(val), 10))
-		}
-		queryDay = fmt.Sprintf(`s.day IN (%s) AND`, strings.Join(daysString, ", "))
+		queryDay = `s.day IN (?) AND`
+		args = append(args, days)
 	}
 
+	// course query regex
+	if rgxCourse.Valid {
+		queryCourse = `LOWER(c.name) REGEXP (?) AND`
+		args = append(args, rgxCourse.String)
+	}
+	args = append(args, userID)
+
 	query := fmt.Sprintf(`
 		SELECT
 			c.name,
@@ -231,12 +231,12 @@ func SelectScheduleWithCourse(userID int64, rgxCourse sql.NullString, days []int
 				FROM
 					p_users_schedules
 				WHERE
-					users_id = (%d) AND
+					users_id = (?) AND
 					status = 1
 			);
-		`, queryDay, queryCourse, userID)
+		`, queryDay, queryCourse)
 
-	err := conn.DB.Select(&schedules, query)
+	err := conn.Select(&schedules, query, args...)
 	if err != nil {
 		return schedules, err
 	}
@@ -249,19 +249,24 @@ func SelectAssignmentWithCourse(userID int64, rgxCourse sql.NullString, t []time
 	var assignments []Assignment
 	var queryCourse string
 	var queryTime string
-
-	// course query regex
-	if rgxCourse.Valid {
-		queryCourse = fmt.Sprintf(`LOWER(c.name) REGEXP '%s' AND`, rgxCourse.String)
-	}
+	args := []interface{}{}
 
 	// days query
 	switch len(t) {
 	case 1:
-		queryTime = fmt.Sprintf("date(a.due_date) = ('%s') AND", t[0].Format("2006-01-02"))
+		queryTime = "date(a.due_date) = (?) AND"
+		args = append(args, t[0].Format("2006-01-02"))
 	case 2:
-		queryTime = fmt.Sprintf("date(a.due_date) BETWEEN ('%s') AND ('%s') AND", t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
+		queryTime = "date(a.due_date) BETWEEN (?) AND (?) AND"
+		args = append(args, t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
+	}
+
+	// course query regex
+	if rgxCourse.Valid {
+		queryCourse = `LOWER(c.name) REGEXP (?) AND`
+		args = append(args, rgxCourse.String)
 	}
+	args = append(args, userID, userID)
 
 	query := fmt.Sprintf(`
 		SELECT
@@ -283,7 +288,7 @@ func SelectAssignmentWithCourse(userID int64, rgxCourse sql.NullString, t []time
 				FROM
 					p_users_assignments
 				WHERE
-					users_id = (%d)
+					users_id = (?)
 			) AND
 			s.id IN (
 				SELECT
@@ -291,14 +296,14 @@ func SelectAssignmentWithCourse(userID int64, rgxCourse sql.NullString, t []time
 				FROM
 					p_users_schedules
 				WHERE
-					users_id = (%d) AND
+					users_id = (?) AND
 					status = 1
 				)
 		ORDER BY a.due_date ASC
 		LIMIT 5;
-		`, queryTime, queryCourse, userID, userID)
+		`, queryTime, queryCourse)
 
-	err := conn.DB.Select(&assignments, query)
+	err := conn.Select(&assignments, query, args...)
 	if err != nil {
 		return assignments, err
 	}
@@ -311,20 +316,25 @@ func SelectGradeWithCourse(userID int64, rgxCourse sql.NullString, t []time.Time
 	var grades []Grade
 	var queryCourse string
 	var queryTime string
-
-	// course query regex
-	if rgxCourse.Valid {
-		queryCourse = fmt.Sprintf(`LOWER(c.name) REGEXP '%s' AND`, rgxCourse.String)
-	}
+	args := []interface{}{}
 
 	// days query
 	switch len(t) {
 	case 1:
-		queryTime = fmt.Sprintf("date(p.updated_at) = ('%s') AND", t[0].Format("2006-01-02"))
+		queryTime = "date(p.updated_at) = (?) AND"
+		args = append(args, t[0].Format("2006-01-02"))
 	case 2:
-		queryTime = fmt.Sprintf("date(p.updated_at) BETWEEN ('%s') AND ('%s') AND", t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
+		queryTime = "date(p.updated_at) BETWEEN (?) AND (?) AND"
+		args = append(args, t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
 	}
 
+	// course query regex
+	if rgxCourse.Valid {
+		queryCourse = `LOWER(c.name) REGEXP (?) AND`
+		args = append(args, rgxCourse.String)
+	}
+	args = append(args, userID, userID)
+
 	query := fmt.Sprintf(`
 		SELECT
 			a.id,
@@ -340,7 +350,7 @@ func SelectGradeWithCourse(userID int64, rgxCourse sql.NullString, t []time.Time
 		INNER JOIN p_users_assignments p ON p.assignments_id = a.id
 		WHERE
 			%s %s
-			p.users_id = (%d) AND
+			p.users_id = (?) AND
 			p.score IS NOT NULL AND
 			s.id IN (
 				SELECT
@@ -348,14 +358,14 @@ func SelectGradeWithCourse(userID int64, rgxCourse sql.NullString, t []time.Time
 				FROM
 					p_users_schedules
 				WHERE
-					users_id = (%d) AND
+					users_id = (?) AND
 					status = 1
 				)
 		ORDER BY p.updated_at ASC
 		LIMIT 5;
-		`, queryTime, queryCourse, userID, userID)
+		`, queryTime, queryCourse)
 
-	err := conn.DB.Select(&grades, query)
+	err := conn.Select(&grades, query, args...)
 	if err != nil {
 		return grades, err
 	}
@@ -367,22 +377,23 @@ func SelectGradeWithCourse(userID int64, rgxCourse sql.NullString, t []time.Time
 func SelectInfoWithFile(scheduleID []int64, t []time.Time) ([]Information, error) {
 
 	var info []Information
-	d := helper.Int64ToStringSlice(scheduleID)
 
 	if len(scheduleID) < 1 {
 		return info, nil
 	}
 
 	var queryTime string
+	args := []interface{}{scheduleID}
 	if len(t) == 1 {
-		queryTime = fmt.Sprintf("AND date(i.created_at) = ('%s')", t[0].Format("2006-01-02"))
+		queryTime = "AND date(i.created_at) = (?)"
+		args = append(args, t[0].Format("2006-01-02"))
 	} else if len(t) == 2 {
-		queryTime = fmt.Sprintf("AND date(i.created_at) BETWEEN ('%s') AND ('%s')", t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
+		queryTime = "AND date(i.created_at) BETWEEN (?) AND (?)"
+		args = append(args, t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
 	} else if len(t) > 2 {
 		return info, fmt.Errorf("date more than two")
 	}
 
-	ids := strings.Join(d, ", ")
 	query := fmt.Sprintf(`
 		SELECT
 			id,
@@ -394,13 +405,47 @@ func SelectInfoWithFile(scheduleID []int64, t []time.Time) ([]Information, error
 		WHERE
 			(
 				schedules_id IS NULL OR
-				schedules_id IN (%s)
+				schedules_id IN (?)
 			) %s
 		ORDER BY i.created_at DESC
-		LIMIT 5`, ids, queryTime)
-	err := conn.DB.Select(&info, query)
+		LIMIT 5`, queryTime)
+	err := conn.Select(&info, query, args...)
 	if err != nil {
 		return info, err
 	}
 	return info, nil
 }
+
+// SelectLogByUserID returns the whole conversation of the user with the bot
+func SelectLogByUserID(userID int64) ([]Log, error) {
+	var log []Log
+	query := `
+		SELECT
+			id,
+			message,
+			status,
+			created_at
+		FROM
+			bot_logs
+		WHERE
+			users_id = (?)
+		ORDER BY id ASC;
+		`
+	err := conn.Select(&log, query, userID)
+	if err != nil {
+		return log, err
+	}
+	return log, nil
+}
+
+// DeleteLogByUserID removes the whole conversation of the user with the bot
+func DeleteLogByUserID(userID int64, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			bot_logs
+		WHERE
+			users_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, userID)
+	return err
+}
@@ -0,0 +1,47 @@
+package course
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// CloneGradeParameter function to copy the grade parameter into the schedule
+/*
+	@params:
+		gp			= GradeParameter, only Type and Percentage are used
+		scheduleID	= int64
+	@example:
+		gp			= {Type: ASSIGNMENT, Percentage: 30}
+		scheduleID	= 100193
+	@return
+		id			= the id of the copy
+*/
+func CloneGradeParameter(gp GradeParameter, scheduleID int64, tx *sqlx.Tx) (int64, error) {
+	query := `
+		INSERT INTO
+			grade_parameters (
+				type,
+				percentage,
+				schedules_id,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+	result, err := conn.TxExec(tx, query, gp.Type, gp.Percentage, scheduleID)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("No rows affected")
+	}
+	return id, nil
+}
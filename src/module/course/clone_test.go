@@ -0,0 +1,30 @@
+package course
+
+import (
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCloneGradeParameter(t *testing.T) {
+	query := `^\s*INSERT\s*INTO\s*grade_parameters\s*\(\s*type,\s*percentage,\s*schedules_id,.*\)\s*VALUES.*;$`
+
+	db, _ := conn.InitDBMock()
+	db.ExpectBegin()
+	db.ExpectExec(query).
+		WithArgs(GradeParameterAssignment, 30.0, 100193).
+		WillReturnResult(sqlmock.NewResult(1231240, 1))
+
+	tx := conn.DB.MustBegin()
+	id, err := CloneGradeParameter(GradeParameter{ID: 1231232, Type: GradeParameterAssignment, Percentage: 30, ScheduleID: 100192}, 100193, tx)
+	if err != nil {
+		t.Fatalf("CloneGradeParameter() error = %v", err)
+	}
+	if id != 1231240 {
+		t.Errorf("CloneGradeParameter() = %v, want %v", id, 1231240)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("CloneGradeParameter() %s", err.Error())
+	}
+}
@@ -0,0 +1,122 @@
+package course
+
+import (
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// SelectPlaceConflict returns the schedules which use the place at an overlapping time of the slot.
+// The deleted schedules and the checked schedule are excluded
+/*
+	@params:
+		placeID		= string
+		slot		= TimeSlot
+		scheduleID	= int64, 0 when the schedule is being created
+	@example:
+		placeID		= UDJT-102
+		slot		= {Semester: 1, Year: 2017, Day: 1, StartTime: 600, EndTime: 800}
+		scheduleID	= 100192
+	@return
+		[]Conflict
+*/
+func SelectPlaceConflict(placeID string, slot TimeSlot, scheduleID int64) ([]Conflict, error) {
+	var conflicts []Conflict
+	query := `
+		SELECT
+			s.id,
+			s.courses_id,
+			c.name,
+			s.class,
+			s.day,
+			s.start_time,
+			s.end_time,
+			s.places_id
+		FROM
+			schedules s
+		INNER JOIN
+			courses c
+		ON
+			s.courses_id = c.id
+		WHERE
+			s.places_id = (?) AND
+			s.semester = (?) AND
+			s.year = (?) AND
+			s.day = (?) AND
+			s.start_time < (?) AND
+			s.end_time > (?) AND
+			s.status != (?) AND
+			s.id != (?)
+		ORDER BY
+			s.start_time ASC,
+			s.id ASC;
+		`
+	err := conn.Select(&conflicts, query, placeID, slot.Semester, slot.Year, slot.Day,
+		slot.EndTime, slot.StartTime, StatusScheduleDeleted, scheduleID)
+	if err != nil {
+		return conflicts, err
+	}
+	return conflicts, nil
+}
+
+// SelectUserConflict returns the schedules which are attended by the users as a student or an assistant
+// at an overlapping time of the slot. The deleted schedules and the checked schedule are excluded
+/*
+	@params:
+		usersID		= []int64
+		slot		= TimeSlot
+		scheduleID	= int64, 0 when the schedule is being created
+	@example:
+		usersID		= [12, 13]
+		slot		= {Semester: 1, Year: 2017, Day: 1, StartTime: 600, EndTime: 800}
+		scheduleID	= 100192
+	@return
+		[]Conflict
+*/
+func SelectUserConflict(usersID []int64, slot TimeSlot, scheduleID int64) ([]Conflict, error) {
+	var conflicts []Conflict
+	if len(usersID) < 1 {
+		return conflicts, nil
+	}
+
+	query := `
+		SELECT
+			s.id,
+			s.courses_id,
+			c.name,
+			s.class,
+			s.day,
+			s.start_time,
+			s.end_time,
+			s.places_id,
+			pus.users_id
+		FROM
+			schedules s
+		INNER JOIN
+			courses c
+		ON
+			s.courses_id = c.id
+		INNER JOIN
+			p_users_schedules pus
+		ON
+			s.id = pus.schedules_id
+		WHERE
+			pus.users_id IN (?) AND
+			pus.status IN (?) AND
+			s.semester = (?) AND
+			s.year = (?) AND
+			s.day = (?) AND
+			s.start_time < (?) AND
+			s.end_time > (?) AND
+			s.status != (?) AND
+			s.id != (?)
+		ORDER BY
+			pus.users_id ASC,
+			s.start_time ASC,
+			s.id ASC;
+		`
+	err := conn.Select(&conflicts, query, usersID, []int8{PStatusStudent, PStatusAssistant},
+		slot.Semester, slot.Year, slot.Day, slot.EndTime, slot.StartTime, StatusScheduleDeleted, scheduleID)
+	if err != nil {
+		return conflicts, err
+	}
+	return conflicts, nil
+}
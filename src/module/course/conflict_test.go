@@ -0,0 +1,61 @@
+package course
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSelectPlaceConflict(t *testing.T) {
+	query := `^\s*SELECT.*FROM\s*schedules\s*s\s*INNER\s*JOIN\s*courses\s*c.*WHERE\s*s\.places_id\s*=\s*\(\?\)\s*AND.*s\.start_time\s*<\s*\(\?\)\s*AND\s*s\.end_time\s*>\s*\(\?\)\s*AND.*;$`
+	slot := TimeSlot{Semester: 1, Year: 2017, Day: 1, StartTime: 600, EndTime: 800}
+	columns := []string{"id", "courses_id", "name", "class", "day", "start_time", "end_time", "places_id"}
+
+	db, _ := conn.InitDBMock()
+	db.ExpectQuery(query).
+		WithArgs("UDJT-102", 1, 2017, 1, 800, 600, StatusScheduleDeleted, 100192).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(100190, "D10K-7D01", "Algoritma", "A", 1, 700, 900, "UDJT-102"))
+
+	got, err := SelectPlaceConflict("UDJT-102", slot, 100192)
+	if err != nil {
+		t.Fatalf("SelectPlaceConflict() error = %v", err)
+	}
+	want := []Conflict{{ScheduleID: 100190, CourseID: "D10K-7D01", Name: "Algoritma", Class: "A", Day: 1, StartTime: 700, EndTime: 900, PlaceID: "UDJT-102"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectPlaceConflict() = %v, want %v", got, want)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("SelectPlaceConflict() %s", err.Error())
+	}
+}
+
+func TestSelectUserConflict(t *testing.T) {
+	query := `^\s*SELECT.*INNER\s*JOIN\s*p_users_schedules\s*pus.*WHERE\s*pus\.users_id\s*IN\s*\(\?,\s*\?\)\s*AND\s*pus\.status\s*IN\s*\(\?,\s*\?\)\s*AND.*;$`
+	slot := TimeSlot{Semester: 1, Year: 2017, Day: 1, StartTime: 600, EndTime: 800}
+	columns := []string{"id", "courses_id", "name", "class", "day", "start_time", "end_time", "places_id", "users_id"}
+
+	got, err := SelectUserConflict(nil, slot, 0)
+	if err != nil || len(got) != 0 {
+		t.Errorf("SelectUserConflict() without users = %v, %v", got, err)
+	}
+
+	db, _ := conn.InitDBMock()
+	db.ExpectQuery(query).
+		WithArgs(12, 13, PStatusStudent, PStatusAssistant, 1, 2017, 1, 800, 600, StatusScheduleDeleted, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(100190, "D10K-7D01", "Algoritma", "A", 1, 500, 700, "UDJT-101", 13))
+
+	got, err = SelectUserConflict([]int64{12, 13}, slot, 0)
+	if err != nil {
+		t.Fatalf("SelectUserConflict() error = %v", err)
+	}
+	want := []Conflict{{ScheduleID: 100190, CourseID: "D10K-7D01", Name: "Algoritma", Class: "A", Day: 1, StartTime: 500, EndTime: 700, PlaceID: "UDJT-101", UserID: sql.NullInt64{Int64: 13, Valid: true}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectUserConflict() = %v, want %v", got, want)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("SelectUserConflict() %s", err.Error())
+	}
+}
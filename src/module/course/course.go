@@ -15,13 +15,15 @@ import (
 
 func SelectIDByUserID(userID int64, status ...int8) ([]int64, error) {
 	var st string
+	args := []interface{}{userID}
 	if len(status) == 1 {
-		st = fmt.Sprintf("AND status = (%d)", status[0])
+		st = "AND status = (?)"
+		args = append(args, status[0])
 	}
 
 	var scheduleID []int64
-	query := fmt.Sprintf(`SELECT schedules_id FROM p_users_schedules WHERE users_id = (%d) %s`, userID, st)
-	err := conn.DB.Select(&scheduleID, query)
+	query := fmt.Sprintf(`SELECT schedules_id FROM p_users_schedules WHERE users_id = (?) %s`, st)
+	err := conn.Select(&scheduleID, query, args...)
 	if err != nil && err != sql.ErrNoRows {
 		return scheduleID, err
 	}
@@ -32,13 +34,15 @@ func SelectIDByUserID(userID int64, status ...int8) ([]int64, error) {
 func SelectScheduleIDByUserID(userID int64, status ...int8) ([]int64, error) {
 
 	var st string
+	args := []interface{}{userID}
 	if len(status) == 1 {
-		st = fmt.Sprintf("AND status = (%d)", status[0])
+		st = "AND status = (?)"
+		args = append(args, status[0])
 	}
 
 	var scheduleIDs []int64
-	query := fmt.Sprintf(`SELECT schedules_id FROM p_users_schedules WHERE users_id = (%d) %s;`, userID, st)
-	err := conn.DB.Select(&scheduleIDs, query)
+	query := fmt.Sprintf(`SELECT schedules_id FROM p_users_schedules WHERE users_id = (?) %s;`, st)
+	err := conn.Select(&scheduleIDs, query, args...)
 	if err != nil && err != sql.ErrNoRows {
 		return scheduleIDs, err
 	}
@@ -48,10 +52,8 @@ func SelectScheduleIDByUserID(userID int64, status ...int8) ([]int64, error) {
 // CountEnrolled ...
 func CountEnrolled(usersID []int64, scheduleID int64) (int, error) {
 	var count int
-	ids := helper.Int64ToStringSlice(usersID)
-	queryID := strings.Join(ids, ", ")
-	query := fmt.Sprintf("SELECT COUNT(*) FROM p_users_schedules WHERE users_id IN (%s) AND schedules_id = (%d) AND status = (%d) LIMIT 1", queryID, scheduleID, PStatusStudent)
-	err := conn.DB.Get(&count, query)
+	query := "SELECT COUNT(*) FROM p_users_schedules WHERE users_id IN (?) AND schedules_id = (?) AND status = (?) LIMIT 1"
+	err := conn.Get(&count, query, usersID, scheduleID, PStatusStudent)
 	if err != nil {
 		return count, err
 	}
@@ -60,8 +62,8 @@ func CountEnrolled(usersID []int64, scheduleID int64) (int, error) {
 
 func IsEnrolled(userID, scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf("SELECT 'x' FROM p_users_schedules WHERE users_id = (%d) AND schedules_id = (%d) AND status = (%d) LIMIT 1", userID, scheduleID, PStatusStudent)
-	err := conn.DB.Get(&x, query)
+	query := "SELECT 'x' FROM p_users_schedules WHERE users_id = (?) AND schedules_id = (?) AND status = (?) LIMIT 1"
+	err := conn.Get(&x, query, userID, scheduleID, PStatusStudent)
 	if err != nil {
 		return false
 	}
@@ -70,18 +72,18 @@ func IsEnrolled(userID, scheduleID int64) bool {
 
 func IsAssistant(userID, scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			p_users_schedules
 		WHERE
-			users_id = (%d) AND
-			schedules_id = (%d) AND
-			status = (%d)
+			users_id = (?) AND
+			schedules_id = (?) AND
+			status = (?)
 		LIMIT 1;
-	`, userID, scheduleID, PStatusAssistant)
-	err := conn.DB.Get(&x, query)
+	`
+	err := conn.Get(&x, query, userID, scheduleID, PStatusAssistant)
 	if err != nil {
 		return false
 	}
@@ -90,18 +92,18 @@ func IsAssistant(userID, scheduleID int64) bool {
 
 func IsUnapproved(userID, scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			p_users_schedules
 		WHERE
-			users_id = (%d) AND
-			schedules_id = (%d) AND
-			status = (%d)
+			users_id = (?) AND
+			schedules_id = (?) AND
+			status = (?)
 		LIMIT 1;
-	`, userID, scheduleID, PStatusUnapproved)
-	err := conn.DB.Get(&x, query)
+	`
+	err := conn.Get(&x, query, userID, scheduleID, PStatusUnapproved)
 	if err != nil {
 		return false
 	}
@@ -110,34 +112,39 @@ func IsUnapproved(userID, scheduleID int64) bool {
 
 func IsCreator(userID, scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			schedules
 		WHERE
-			id = (%d) AND
-			created_by = (%d)
+			id = (?) AND
+			created_by = (?)
 		LIMIT 1;
-	`, scheduleID, userID)
-	err := conn.DB.Get(&x, query)
+	`
+	err := conn.Get(&x, query, scheduleID, userID)
 	if err != nil {
 		return false
 	}
 	return true
 }
 
-func SelectAssistantID(scheduleID int64) ([]int64, error) {
+func SelectAssistantID(scheduleID int64, tx ...*sqlx.Tx) ([]int64, error) {
 
 	userIDs := []int64{}
-	query := fmt.Sprintf(`SELECT
+	query := `SELECT
 		p.users_id
 	FROM
 		p_users_schedules p
 	WHERE 
-		p.status = (%d) AND
-		p.schedules_id = (%d);`, PStatusAssistant, scheduleID)
-	err := conn.DB.Select(&userIDs, query)
+		p.status = (?) AND
+		p.schedules_id = (?);`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	err := conn.TxSelect(t, &userIDs, query, PStatusAssistant, scheduleID)
 	if err != nil && err != sql.ErrNoRows {
 		return userIDs, err
 	}
@@ -148,13 +155,13 @@ func SelectAssistantID(scheduleID int64) ([]int64, error) {
 func SelectAllAssistantID() ([]int64, error) {
 
 	userIDs := []int64{}
-	query := fmt.Sprintf(`SELECT
+	query := `SELECT
 		users_id
 	FROM
 		p_users_schedules
 	WHERE 
-		status = (%d);`, PStatusAssistant)
-	err := conn.DB.Select(&userIDs, query)
+		status = (?);`
+	err := conn.Select(&userIDs, query, PStatusAssistant)
 	if err != nil && err != sql.ErrNoRows {
 		return userIDs, err
 	}
@@ -164,14 +171,14 @@ func SelectAllAssistantID() ([]int64, error) {
 
 func SelectEnrolledStudentID(scheduleID int64) ([]int64, error) {
 	userIDs := []int64{}
-	query := fmt.Sprintf(`SELECT
+	query := `SELECT
 			users_id
 		FROM
 			p_users_schedules
 		WHERE 
-			status = (%d) AND
-			schedules_id = (%d);`, PStatusStudent, scheduleID)
-	err := conn.DB.Select(&userIDs, query)
+			status = (?) AND
+			schedules_id = (?);`
+	err := conn.Select(&userIDs, query, PStatusStudent, scheduleID)
 	if err != nil && err != sql.ErrNoRows {
 		return userIDs, err
 	}
@@ -182,8 +189,8 @@ func SelectEnrolledStudentID(scheduleID int64) ([]int64, error) {
 func SelectAllName() ([]string, error) {
 
 	var names []string
-	query := fmt.Sprintf(`SELECT name FROM courses;`)
-	err := conn.DB.Select(&names, query)
+	query := `SELECT name FROM courses;`
+	err := conn.Select(&names, query)
 	if err != nil && err != sql.ErrNoRows {
 		return names, err
 	}
@@ -194,8 +201,8 @@ func SelectAllName() ([]string, error) {
 func IsExist(courseID string) bool {
 
 	var x string
-	query := fmt.Sprintf(`SELECT 'x' FROM courses WHERE id = ('%s') LIMIT 1;`, courseID)
-	err := conn.DB.Get(&x, query)
+	query := `SELECT 'x' FROM courses WHERE id = (?) LIMIT 1;`
+	err := conn.Get(&x, query, courseID)
 	if err != nil {
 		return false
 	}
@@ -205,30 +212,23 @@ func IsExist(courseID string) bool {
 
 func Update(courseID, name string, description sql.NullString, ucu int8, tx ...*sqlx.Tx) error {
 
-	queryDescription := fmt.Sprintf("NULL")
-	if description.Valid {
-		queryDescription = fmt.Sprintf("('%s')", description.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			courses
 		SET
-			name = ('%s'),
-			description = %s,
-			ucu = (%d),
+			name = (?),
+			description = (?),
+			ucu = (?),
 			updated_at = NOW()
 		WHERE
-			id = ('%s');
-		`, name, queryDescription, ucu, courseID)
+			id = (?);
+		`
 
-	var result sql.Result
-	var err error
+	var t *sqlx.Tx
 	if len(tx) == 1 {
-		result, err = tx[0].Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
+		t = tx[0]
 	}
+	result, err := conn.TxExec(t, query, name, description, ucu, courseID)
 	if err != nil {
 		return err
 	}
@@ -243,12 +243,7 @@ func Update(courseID, name string, description sql.NullString, ucu int8, tx ...*
 
 func Insert(courseID, name string, description sql.NullString, ucu int8, tx ...*sqlx.Tx) error {
 
-	queryDescription := fmt.Sprintf("NULL")
-	if description.Valid {
-		queryDescription = fmt.Sprintf("('%s')", description.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			courses (
 				id,
@@ -259,21 +254,19 @@ func Insert(courseID, name string, description sql.NullString, ucu int8, tx ...*
 				updated_at
 			)
 		VALUES (
-			('%s'),
-			('%s'),
-			%s,
-			(%d),
+			(?),
+			(?),
+			(?),
+			(?),
 			NOW(),
 			NOW()
-		);`, courseID, name, queryDescription, ucu)
+		);`
 
-	var result sql.Result
-	var err error
+	var t *sqlx.Tx
 	if len(tx) == 1 {
-		result, err = tx[0].Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
+		t = tx[0]
 	}
+	result, err := conn.TxExec(t, query, courseID, name, description, ucu)
 	if err != nil {
 		return err
 	}
@@ -289,8 +282,10 @@ func Insert(courseID, name string, description sql.NullString, ucu int8, tx ...*
 func IsExistSchedule(semester int8, year int16, courseID, class string, scheduleID ...int64) bool {
 
 	var sc string
+	args := []interface{}{semester, year, courseID, class}
 	if len(scheduleID) == 1 {
-		sc = fmt.Sprintf(" AND id != (%d) ", scheduleID[0])
+		sc = " AND id != (?) "
+		args = append(args, scheduleID[0])
 	}
 
 	var x string
@@ -300,12 +295,12 @@ func IsExistSchedule(semester int8, year int16, courseID, class string, schedule
 		FROM
 			schedules
 		WHERE
-			semester = (%d) AND
-			year = (%d) AND
-			courses_id = ('%s') AND
-			class = ('%s') %s
-		LIMIT 1;`, semester, year, courseID, class, sc)
-	err := conn.DB.Get(&x, query)
+			semester = (?) AND
+			year = (?) AND
+			courses_id = (?) AND
+			class = (?) %s
+		LIMIT 1;`, sc)
+	err := conn.Get(&x, query, args...)
 	if err != nil {
 		return false
 	}
@@ -315,7 +310,7 @@ func IsExistSchedule(semester int8, year int16, courseID, class string, schedule
 func InsertSchedule(userID int64, startTime, endTime, year int16, semester, day, status int8, class, courseID, placeID string, tx ...*sqlx.Tx) (int64, error) {
 
 	var id int64
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			schedules (
 				status,
@@ -332,28 +327,25 @@ func InsertSchedule(userID int64, startTime, endTime, year int16, semester, day,
 				updated_at
 			)
 		VALUES (
-			(%d),
-			(%d),
-			(%d),
-			(%d),
-			('%s'),
-			(%d),
-			(%d),
-			('%s'),
-			('%s'),
-			(%d),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
 			NOW(),
 			NOW()
-		)`, status, startTime, endTime, day, class, semester, year, courseID, placeID, userID)
+		)`
 
-	var result sql.Result
-	var err error
-	switch len(tx) {
-	case 1:
-		result, err = tx[0].Exec(query)
-	default:
-		result, err = conn.DB.Exec(query)
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
 	}
+	result, err := conn.TxExec(t, query, status, startTime, endTime, day, class, semester, year, courseID, placeID, userID)
 	if err != nil {
 		return id, err
 	}
@@ -375,8 +367,7 @@ func SelectByPage(limit, offset int, isCount bool, scheduleID []int64) ([]Course
 		return course, count, nil
 	}
 
-	scid := strings.Join(helper.Int64ToStringSlice(scheduleID), ", ")
-	query := fmt.Sprintf(`
+	query, args, err := conn.In(`
 		SELECT
 			cs.id,
 			cs.name,
@@ -395,13 +386,17 @@ func SelectByPage(limit, offset int, isCount bool, scheduleID []int64) ([]Course
 		FROM
 			courses cs
 		RIGHT JOIN schedules sc ON cs.id = sc.courses_id
-		WHERE sc.id IN (%s)
-		LIMIT %d OFFSET %d;`, scid, limit, offset)
-	rows, err := conn.DB.Queryx(query)
-	defer rows.Close()
+		WHERE sc.id IN (?)
+		LIMIT ? OFFSET ?;`, scheduleID, limit, offset)
+	if err != nil {
+		return course, count, err
+	}
+
+	rows, err := conn.DB.Queryx(query, args...)
 	if err != nil {
 		return course, count, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var id, name, class, placeID string
@@ -442,13 +437,13 @@ func SelectByPage(limit, offset int, isCount bool, scheduleID []int64) ([]Course
 		return course, count, nil
 	}
 
-	query = fmt.Sprintf(`
+	query = `
 		SELECT
 			COUNT(*)
 		FROM
 			schedules
-		WHERE id IN (%s)`, scid)
-	err = conn.DB.Get(&count, query)
+		WHERE id IN (?)`
+	err = conn.Get(&count, query, scheduleID)
 	if err != nil {
 		return course, count, err
 	}
@@ -459,7 +454,7 @@ func SelectByPage(limit, offset int, isCount bool, scheduleID []int64) ([]Course
 func GetByScheduleID(scheduleID int64) (CourseSchedule, error) {
 
 	var course CourseSchedule
-	query := fmt.Sprintf(`
+	query := conn.DB.Rebind(`
 		SELECT
 			cs.id,
 			cs.name,
@@ -482,10 +477,10 @@ func GetByScheduleID(scheduleID int64) (CourseSchedule, error) {
 		ON
 			cs.id = sc.courses_id
 		WHERE
-			sc.id = (%d)
-		LIMIT 1;`, scheduleID)
+			sc.id = (?)
+		LIMIT 1;`)
 
-	rows := conn.DB.QueryRowx(query)
+	rows := conn.DB.QueryRowx(query, scheduleID)
 
 	// scan data to variable
 	var id, name, class, placeID string
@@ -524,15 +519,15 @@ func GetByScheduleID(scheduleID int64) (CourseSchedule, error) {
 
 func IsExistScheduleID(scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			schedules
 		WHERE
-			id = (%d)
-		LIMIT 1;`, scheduleID)
-	err := conn.DB.Get(&x, query)
+			id = (?)
+		LIMIT 1;`
+	err := conn.Get(&x, query, scheduleID)
 	if err != nil {
 		return false
 	}
@@ -541,31 +536,28 @@ func IsExistScheduleID(scheduleID int64) bool {
 
 func UpdateSchedule(scheduleID int64, startTime, endTime, year int16, semester, day, status int8, class, courseID, placeID string, tx ...*sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			schedules
 		SET
-			status = (%d),
-			start_time = (%d),
-			end_time = (%d),
-			day = (%d),
-			class = ('%s'),
-			semester = (%d),
-			year = (%d),
-			courses_id = ('%s'),
-			places_id = ('%s'),
+			status = (?),
+			start_time = (?),
+			end_time = (?),
+			day = (?),
+			class = (?),
+			semester = (?),
+			year = (?),
+			courses_id = (?),
+			places_id = (?),
 			updated_at = NOW()
 		WHERE
-			id = (%d);`, status, startTime, endTime, day, class, semester, year, courseID, placeID, scheduleID)
+			id = (?);`
 
-	var result sql.Result
-	var err error
-	switch len(tx) {
-	case 1:
-		result, err = tx[0].Exec(query)
-	default:
-		result, err = conn.DB.Exec(query)
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
 	}
+	result, err := conn.TxExec(t, query, status, startTime, endTime, day, class, semester, year, courseID, placeID, scheduleID)
 	if err != nil {
 		return err
 	}
@@ -585,10 +577,7 @@ func SelectByScheduleID(scheduleID []int64, status int8) ([]CourseSchedule, erro
 		return course, nil
 	}
 
-	d := helper.Int64ToStringSlice(scheduleID)
-	ids := strings.Join(d, ", ")
-
-	query := fmt.Sprintf(`
+	query, args, err := conn.In(`
 		SELECT
 			cs.id,
 			cs.name,
@@ -611,15 +600,18 @@ func SelectByScheduleID(scheduleID []int64, status int8) ([]CourseSchedule, erro
 		ON
 			cs.id = sc.courses_id
 		WHERE
-			sc.id IN (%s) AND
-			sc.status = (%d)
-		ORDER BY day ASC;`, ids, status)
+			sc.id IN (?) AND
+			sc.status = (?)
+		ORDER BY day ASC;`, scheduleID, status)
+	if err != nil {
+		return course, err
+	}
 
-	rows, err := conn.DB.Queryx(query)
-	defer rows.Close()
+	rows, err := conn.DB.Queryx(query, args...)
 	if err != nil {
 		return course, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var id, name, class, placeID string
@@ -662,7 +654,7 @@ func SelectByScheduleID(scheduleID []int64, status int8) ([]CourseSchedule, erro
 func SelectByStatus(status int8) ([]CourseSchedule, error) {
 
 	var course []CourseSchedule
-	query := fmt.Sprintf(`
+	query := conn.DB.Rebind(`
 		SELECT
 			cs.id,
 			cs.name,
@@ -685,13 +677,13 @@ func SelectByStatus(status int8) ([]CourseSchedule, error) {
 		ON
 			cs.id = sc.courses_id
 		WHERE
-			sc.status = (%d)`, status)
+			sc.status = (?)`)
 
-	rows, err := conn.DB.Queryx(query)
-	defer rows.Close()
+	rows, err := conn.DB.Queryx(query, status)
 	if err != nil {
 		return course, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var id, name, class, placeID string
@@ -733,20 +725,18 @@ func SelectByStatus(status int8) ([]CourseSchedule, error) {
 
 func DeleteSchedule(scheduleID int64, tx ...*sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			schedules
 		WHERE
-			id = (%d);
-		`, scheduleID)
+			id = (?);
+		`
 
-	var result sql.Result
-	var err error
+	var t *sqlx.Tx
 	if len(tx) == 1 {
-		result, err = tx[0].Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
+		t = tx[0]
 	}
+	result, err := conn.TxExec(t, query, scheduleID)
 	if err != nil {
 		return err
 	}
@@ -761,7 +751,7 @@ func DeleteSchedule(scheduleID int64, tx ...*sqlx.Tx) error {
 
 func SelectByName(name string) ([]Course, error) {
 	var courses []Course
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			name,
@@ -770,10 +760,10 @@ func SelectByName(name string) ([]Course, error) {
 		FROM
 			courses
 		WHERE
-			name LIKE ('%%%s%%')
+			name LIKE (?)
 		LIMIT 5;
-	`, name)
-	err := conn.DB.Select(&courses, query)
+	`
+	err := conn.Select(&courses, query, "%"+helper.EscapeLike(name)+"%")
 	if err != nil && err != sql.ErrNoRows {
 		return courses, err
 	}
@@ -783,7 +773,7 @@ func SelectByName(name string) ([]Course, error) {
 
 func InsertGradeParameter(typ string, percentage float32, scheduleID int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 		grade_parameters (
 			type,
@@ -793,21 +783,15 @@ func InsertGradeParameter(typ string, percentage float32, scheduleID int64, tx *
 			updated_at
 		)
 		VALUES (
-			('%s'),
-			(%f),
-			(%d),
+			(?),
+			(?),
+			(?),
 			NOW(),
 			NOW()
 		);
-		`, typ, percentage, scheduleID)
+		`
 
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, typ, percentage, scheduleID)
 	if err != nil {
 		return err
 	}
@@ -820,15 +804,14 @@ func InsertGradeParameter(typ string, percentage float32, scheduleID int64, tx *
 	return nil
 }
 
-func SelectGPBySchedule(scheduleID []int64) ([]GradeParameter, error) {
+func SelectGPBySchedule(scheduleID []int64, tx ...*sqlx.Tx) ([]GradeParameter, error) {
 	var gps []GradeParameter
 
 	if len(scheduleID) < 1 {
 		return gps, nil
 	}
 
-	querySchID := strings.Join(helper.Int64ToStringSlice(scheduleID), ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			type,
@@ -837,9 +820,14 @@ func SelectGPBySchedule(scheduleID []int64) ([]GradeParameter, error) {
 		FROM
 			grade_parameters
 		WHERE
-			schedules_id IN (%s);
-		`, querySchID)
-	err := conn.DB.Select(&gps, query)
+			schedules_id IN (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	err := conn.TxSelect(t, &gps, query, scheduleID)
 	if err != nil && err != sql.ErrNoRows {
 		return gps, err
 	}
@@ -849,12 +837,11 @@ func SelectGPBySchedule(scheduleID []int64) ([]GradeParameter, error) {
 // SelectGradeParameterByScheduleIDIN func
 func SelectGradeParameterByScheduleIDIN(scheduleID []int64) ([]int64, error) {
 	var gps []int64
-	var gradeQuery []string
-	for _, value := range scheduleID {
-		gradeQuery = append(gradeQuery, fmt.Sprintf("%d", value))
+	if len(scheduleID) < 1 {
+		return gps, nil
 	}
-	queryGradeList := strings.Join(gradeQuery, ",")
-	query := fmt.Sprintf(`
+
+	query := `
 		SELECT
 			id
 		FROM
@@ -862,9 +849,9 @@ func SelectGradeParameterByScheduleIDIN(scheduleID []int64) ([]int64, error) {
 		WHERE
 			schedules_id
 		IN
-			 (%s);
-		`, queryGradeList)
-	err := conn.DB.Select(&gps, query)
+			 (?);
+		`
+	err := conn.Select(&gps, query, scheduleID)
 	if err != nil && err != sql.ErrNoRows {
 		return gps, err
 	}
@@ -873,20 +860,14 @@ func SelectGradeParameterByScheduleIDIN(scheduleID []int64) ([]int64, error) {
 
 func DeleteGradeParameter(id int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 			DELETE FROM
 				grade_parameters
 			WHERE
-				id = (%d);
-			`, id)
+				id = (?);
+			`
 
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, id)
 	if err != nil {
 		return err
 	}
@@ -901,24 +882,18 @@ func DeleteGradeParameter(id int64, tx *sqlx.Tx) error {
 
 func UpdateGradeParameter(typ string, percentage float32, scheduleID int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			grade_parameters
 		SET
-			percentage = (%f),
+			percentage = (?),
 			updated_at = NOW()
 		WHERE
-			type = ('%s') AND
-			schedules_id = (%d);
-		`, percentage, typ, scheduleID)
+			type = (?) AND
+			schedules_id = (?);
+		`
 
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, percentage, typ, scheduleID)
 	if err != nil {
 		return err
 	}
@@ -934,15 +909,15 @@ func UpdateGradeParameter(typ string, percentage float32, scheduleID int64, tx *
 // GetScheduleIDByGP ...
 func GetScheduleIDByGP(gpID int64) (int64, error) {
 	var scheduleID int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT 
 			schedules_id
 		FROM
 			grade_parameters
 		WHERE
-			id = (%d)
-		`, gpID)
-	err := conn.DB.Get(&scheduleID, query)
+			id = (?)
+		`
+	err := conn.Get(&scheduleID, query, gpID)
 	if err != nil {
 		return scheduleID, err
 	}
@@ -952,16 +927,16 @@ func GetScheduleIDByGP(gpID int64) (int64, error) {
 
 // GetGradeParametersID func ...
 func GetGradeParametersID(AssignmentID int64) int64 {
-	query := fmt.Sprintf(`
+	query := conn.DB.Rebind(`
 		SELECT 
 			grade_parameters_id
 		FROM
 			assignments
 		WHERE
-			id = (%d)
-		`, AssignmentID)
+			id = (?)
+		`)
 	var assignmentID string
-	err := conn.DB.QueryRow(query).Scan(&assignmentID)
+	err := conn.DB.QueryRow(query, AssignmentID).Scan(&assignmentID)
 	if err != nil {
 		return 0
 	}
@@ -975,16 +950,16 @@ func GetGradeParametersID(AssignmentID int64) int64 {
 
 // GetGradeParametersIDByScheduleID func ...
 func GetGradeParametersIDByScheduleID(ScheduleID int64) ([]int64, error) {
-	query := fmt.Sprintf(`
+	query := conn.DB.Rebind(`
 		SELECT
 			id
 		FROM
 			grade_parameters
 		WHERE
-			schedules_id = (%d)
-		;`, ScheduleID)
+			schedules_id = (?)
+		;`)
 
-	rows, err := conn.DB.Query(query)
+	rows, err := conn.DB.Query(query, ScheduleID)
 	var gradeParamsID []int64
 	if err != nil {
 		return gradeParamsID, err
@@ -1003,15 +978,15 @@ func GetGradeParametersIDByScheduleID(ScheduleID int64) ([]int64, error) {
 // IsUserHasUploadedFile func ...
 func IsUserHasUploadedFile(assignmentID, userID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			p_users_assignments
 		WHERE
-			assignments_id = (%d) AND users_id =(%d)
-		LIMIT 1;`, assignmentID, userID)
-	err := conn.DB.Get(&x, query)
+			assignments_id = (?) AND users_id =(?)
+		LIMIT 1;`
+	err := conn.Get(&x, query, assignmentID, userID)
 	if err != nil {
 		return false
 	}
@@ -1021,20 +996,15 @@ func IsUserHasUploadedFile(assignmentID, userID int64) bool {
 // IsAllUsersEnrolled func ...
 func IsAllUsersEnrolled(scheduleID int64, usersID []int64) bool {
 	userIDs := []int64{}
-	var userList []string
-	for _, value := range usersID {
-		userList = append(userList, fmt.Sprintf("%d", value))
-	}
-	queryUserList := strings.Join(userList, ",")
-	query := fmt.Sprintf(`SELECT
+	query := `SELECT
 			users_id
 		FROM
 			p_users_schedules
 		WHERE 
-			status = (%d) AND
-			schedules_id = (%d) AND users_id IN(%s);`, PStatusStudent, scheduleID, queryUserList)
+			status = (?) AND
+			schedules_id = (?) AND users_id IN(?);`
 
-	err := conn.DB.Select(&userIDs, query)
+	err := conn.Select(&userIDs, query, PStatusStudent, scheduleID, usersID)
 	if err != nil && err != sql.ErrNoRows {
 		return false
 	}
@@ -1047,17 +1017,17 @@ func IsAllUsersEnrolled(scheduleID int64, usersID []int64) bool {
 
 // GetCourseID func ...
 func GetCourseID(scheduleID int64) (string, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			courses_id
 		FROM
 			schedules
 		WHERE
-			id=(%d)
+			id=(?)
 		LIMIT 1;
-		`, scheduleID)
+		`
 	var res string
-	err := conn.DB.Get(&res, query)
+	err := conn.Get(&res, query, scheduleID)
 	if err != nil {
 		return res, err
 	}
@@ -1066,17 +1036,17 @@ func GetCourseID(scheduleID int64) (string, error) {
 
 // GetName func ...
 func GetName(courseID string) (string, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			name
 		FROM
 			courses
 		WHERE
-			id=('%s')
+			id=(?)
 		LIMIT 1;
-		`, courseID)
+		`
 	var res string
-	err := conn.DB.Get(&res, query)
+	err := conn.Get(&res, query, courseID)
 	if err != nil {
 		return res, err
 	}
@@ -1084,9 +1054,7 @@ func GetName(courseID string) (string, error) {
 }
 func SelectJoinScheduleCourse(scheduleID []int64) ([]CourseConcise, error) {
 	var res []CourseConcise
-	id := helper.Int64ToStringSlice(scheduleID)
-	queryID := strings.Join(id, ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			sc.id,
 			cs.name
@@ -1097,10 +1065,10 @@ func SelectJoinScheduleCourse(scheduleID []int64) ([]CourseConcise, error) {
 		ON
 			sc.courses_id = cs.id
 		WHERE 
-			sc.id IN (%s)
+			sc.id IN (?)
 		;
-		`, queryID)
-	err := conn.DB.Select(&res, query)
+		`
+	err := conn.Select(&res, query, scheduleID)
 	if err != nil {
 		return res, err
 	}
@@ -1112,9 +1080,10 @@ func SelectJoinScheduleCourse(scheduleID []int64) ([]CourseConcise, error) {
 func InsertAssistant(usersID []int64, scheduleID int64, tx *sqlx.Tx) error {
 
 	var values []string
+	var args []interface{}
 	for _, val := range usersID {
-		value := fmt.Sprintf("(%d, %d, %d, NOW(), NOW())", val, scheduleID, PStatusAssistant)
-		values = append(values, value)
+		values = append(values, "(?, ?, ?, NOW(), NOW())")
+		args = append(args, val, scheduleID, PStatusAssistant)
 	}
 
 	queryValue := strings.Join(values, ", ")
@@ -1129,13 +1098,7 @@ func InsertAssistant(usersID []int64, scheduleID int64, tx *sqlx.Tx) error {
 			) VALUES %s;
 	`, queryValue)
 
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
-
+	_, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -1144,24 +1107,16 @@ func InsertAssistant(usersID []int64, scheduleID int64, tx *sqlx.Tx) error {
 
 func DeleteAssistant(usersID []int64, scheduleID int64, tx *sqlx.Tx) error {
 
-	usersIDString := helper.Int64ToStringSlice(usersID)
-	queryUsersID := strings.Join(usersIDString, ", ")
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			p_users_schedules
 		WHERE
-			status = (%d) AND
-			schedules_id = (%d) AND
-			users_id IN (%s);
-	`, PStatusAssistant, scheduleID, queryUsersID)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+			status = (?) AND
+			schedules_id = (?) AND
+			users_id IN (?);
+	`
 
+	_, err := conn.TxExec(tx, query, PStatusAssistant, scheduleID, usersID)
 	if err != nil {
 		return err
 	}
@@ -1174,8 +1129,7 @@ func SelectByDayScheduleID(day int8, schedulesID []int64) ([]CourseSchedule, err
 		return course, nil
 	}
 
-	querySchedulesID := strings.Join(helper.Int64ToStringSlice(schedulesID), ", ")
-	query := fmt.Sprintf(`
+	query, args, err := conn.In(`
 		SELECT
 			cs.id,
 			cs.name,
@@ -1198,14 +1152,18 @@ func SelectByDayScheduleID(day int8, schedulesID []int64) ([]CourseSchedule, err
 		ON
 			cs.id = sc.courses_id
 		WHERE
-			sc.day = (%d) AND
-			sc.id IN (%s)
-		;`, day, querySchedulesID)
-	rows, err := conn.DB.Queryx(query)
-	defer rows.Close()
+			sc.day = (?) AND
+			sc.id IN (?)
+		;`, day, schedulesID)
+	if err != nil {
+		return course, err
+	}
+
+	rows, err := conn.DB.Queryx(query, args...)
 	if err != nil {
 		return course, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var id, name, class, placeID string
@@ -1245,8 +1203,8 @@ func SelectByDayScheduleID(day int8, schedulesID []int64) ([]CourseSchedule, err
 	return course, nil
 }
 
-func InsertUnapproved(userID, scheduleID int64) error {
-	query := fmt.Sprintf(`
+func InsertUnapproved(userID, scheduleID int64, tx ...*sqlx.Tx) error {
+	query := `
 		INSERT INTO
 			p_users_schedules (
 				users_id,
@@ -1255,15 +1213,19 @@ func InsertUnapproved(userID, scheduleID int64) error {
 				created_at,
 				updated_at
 			) VALUES (
-				(%d),
-				(%d),
-				(%d),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-	`, userID, scheduleID, PStatusUnapproved)
+	`
 
-	_, err := conn.DB.Exec(query)
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	_, err := conn.TxExec(t, query, userID, scheduleID, PStatusUnapproved)
 	if err != nil {
 		return err
 	}
@@ -1271,17 +1233,23 @@ func InsertUnapproved(userID, scheduleID int64) error {
 	return nil
 }
 
-func DeleteUserRelation(userID, scheduleID int64) error {
+// DeleteUserRelation removes the relation of the user with the schedule, it is used to cancel,
+// reject or remove the user. The seat is given to the waitlist by the caller
+func DeleteUserRelation(userID, scheduleID int64, tx ...*sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			p_users_schedules
 		WHERE
-			users_id = (%d) AND
-			schedules_id = (%d);
-	`, userID, scheduleID)
+			users_id = (?) AND
+			schedules_id = (?);
+	`
 
-	result, err := conn.DB.Exec(query)
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, userID, scheduleID)
 	if err != nil {
 		return err
 	}
@@ -1296,7 +1264,7 @@ func DeleteUserRelation(userID, scheduleID int64) error {
 // InsertInvolved ...
 func InsertInvolved(userID, scheduleID int64, role int, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			p_users_schedules (
 				users_id,
@@ -1305,20 +1273,15 @@ func InsertInvolved(userID, scheduleID int64, role int, tx *sqlx.Tx) error {
 				created_at,
 				updated_at
 			) VALUES (
-				(%d),
-				(%d),
-				(%d),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-	`, userID, scheduleID, role)
+	`
 
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	_, err := conn.TxExec(tx, query, userID, scheduleID, role)
 
 	if err != nil {
 		return err
@@ -1329,23 +1292,18 @@ func InsertInvolved(userID, scheduleID int64, role int, tx *sqlx.Tx) error {
 // ActivateStudent ...
 func ActivateStudent(userID, scheduleID int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			p_users_schedules
 		SET
-			status = (%d),
+			status = (?),
 			updated_at = NOW()
 		WHERE
-			users_id = (%d) AND
-			schedules_id = (%d);
-	`, PStatusStudent, userID, scheduleID)
+			users_id = (?) AND
+			schedules_id = (?);
+	`
 
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	_, err := conn.TxExec(tx, query, PStatusStudent, userID, scheduleID)
 
 	if err != nil {
 		return err
@@ -1355,16 +1313,16 @@ func ActivateStudent(userID, scheduleID int64, tx *sqlx.Tx) error {
 
 func SelectUnapproved(scheduleID int64) ([]int64, error) {
 	var usersID []int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			users_id
 		FROM
 			p_users_schedules
 		WHERE
-			schedules_id = (%d) AND
-			status = (%d);
-		`, scheduleID, PStatusStudent)
-	err := conn.DB.Select(&usersID, query)
+			schedules_id = (?) AND
+			status = (?);
+		`
+	err := conn.Select(&usersID, query, scheduleID, PStatusStudent)
 	if err != nil {
 		return usersID, err
 	}
@@ -1373,15 +1331,15 @@ func SelectUnapproved(scheduleID int64) ([]int64, error) {
 
 func SelectInvolved(scheduleID int64) ([]int64, error) {
 	var usersID []int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			users_id
 		FROM
 			p_users_schedules
 		WHERE
-			schedules_id = (%d)
-		`, scheduleID)
-	err := conn.DB.Select(&usersID, query)
+			schedules_id = (?)
+		`
+	err := conn.Select(&usersID, query, scheduleID)
 	if err != nil {
 		return usersID, err
 	}
@@ -1391,17 +1349,64 @@ func SelectInvolved(scheduleID int64) ([]int64, error) {
 // SelectIDBySchedule ..
 func SelectIDBySchedule(scheduleID int64) ([]int64, error) {
 	var ids []int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			users_id
 		FROM
 			p_users_schedules
 		WHERE
-			schedules_id=(%d) AND status = 1
-		`, scheduleID)
-	err := conn.DB.Select(&ids, query)
+			schedules_id=(?) AND status = 1
+		`
+	err := conn.Select(&ids, query, scheduleID)
 	if err != nil {
 		return ids, err
 	}
 	return ids, nil
 }
+
+// SelectEnrollmentByUserID returns the schedules which are related to the user
+func SelectEnrollmentByUserID(userID int64) ([]Enrollment, error) {
+	var enrollments []Enrollment
+	query := `
+		SELECT
+			s.id,
+			s.courses_id,
+			c.name,
+			s.class,
+			s.semester,
+			s.year,
+			pus.status
+		FROM
+			p_users_schedules pus
+		INNER JOIN
+			schedules s
+		ON
+			pus.schedules_id = s.id
+		INNER JOIN
+			courses c
+		ON
+			s.courses_id = c.id
+		WHERE
+			pus.users_id = (?)
+		ORDER BY
+			s.year ASC,
+			s.semester ASC;
+		`
+	err := conn.Select(&enrollments, query, userID)
+	if err != nil {
+		return enrollments, err
+	}
+	return enrollments, nil
+}
+
+// DeleteEnrollmentByUserID removes the relations of the user with every schedule
+func DeleteEnrollmentByUserID(userID int64, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			p_users_schedules
+		WHERE
+			users_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, userID)
+	return err
+}
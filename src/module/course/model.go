@@ -59,3 +59,56 @@ type CourseConcise struct {
 	ID   int64  `db:"id"`
 	Name string `db:"name"`
 }
+
+// Enrollment is the relation of the user with the schedule, Status is the role of the user
+type Enrollment struct {
+	ScheduleID int64  `db:"id"`
+	CourseID   string `db:"courses_id"`
+	CourseName string `db:"name"`
+	Class      string `db:"class"`
+	Semester   int8   `db:"semester"`
+	Year       int16  `db:"year"`
+	Status     int8   `db:"status"`
+}
+
+// Involvement is the relation of the user with the schedule, Status is PStatusUnapproved, PStatusStudent or PStatusAssistant
+type Involvement struct {
+	UserID int64 `db:"users_id"`
+	Status int8  `db:"status"`
+}
+
+// Prerequisite is a course which has to be passed with MinGrade before enrolling the course
+type Prerequisite struct {
+	CourseID       string  `db:"courses_id"`
+	PrerequisiteID string  `db:"prerequisites_id"`
+	Name           string  `db:"name"`
+	MinGrade       float64 `db:"min_grade"`
+}
+
+// StudiedSchedule is a schedule of the course which has been studied by the user
+type StudiedSchedule struct {
+	ScheduleID int64  `db:"id"`
+	CourseID   string `db:"courses_id"`
+}
+
+// TimeSlot is the weekly time of a schedule in the semester, StartTime and EndTime are minutes of the day
+type TimeSlot struct {
+	Semester  int8
+	Year      int16
+	Day       int8
+	StartTime int16
+	EndTime   int16
+}
+
+// Conflict is a schedule which overlaps a time slot, UserID is the involved user who causes the conflict
+type Conflict struct {
+	ScheduleID int64         `db:"id"`
+	CourseID   string        `db:"courses_id"`
+	Name       string        `db:"name"`
+	Class      string        `db:"class"`
+	Day        int8          `db:"day"`
+	StartTime  uint16        `db:"start_time"`
+	EndTime    uint16        `db:"end_time"`
+	PlaceID    string        `db:"places_id"`
+	UserID     sql.NullInt64 `db:"users_id"`
+}
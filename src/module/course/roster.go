@@ -0,0 +1,45 @@
+package course
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// SelectInvolvement returns the relations of the users with the schedule, the users who are not involved are omitted
+/*
+	@params:
+		scheduleID	= int64
+		usersID		= []int64
+	@example:
+		scheduleID	= 100192
+		usersID		= [12, 13]
+	@return
+		[]Involvement
+*/
+func SelectInvolvement(scheduleID int64, usersID []int64, tx ...*sqlx.Tx) ([]Involvement, error) {
+	var involvements []Involvement
+	if len(usersID) < 1 {
+		return involvements, nil
+	}
+
+	query := `
+		SELECT
+			users_id,
+			status
+		FROM
+			p_users_schedules
+		WHERE
+			schedules_id = (?) AND
+			users_id IN (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	err := conn.TxSelect(t, &involvements, query, scheduleID, usersID)
+	if err != nil {
+		return involvements, err
+	}
+	return involvements, nil
+}
@@ -0,0 +1,35 @@
+package course
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSelectInvolvement(t *testing.T) {
+	query := `^\s*SELECT\s*users_id,\s*status\s*FROM\s*p_users_schedules\s*WHERE\s*schedules_id\s*=\s*\(\?\)\s*AND\s*users_id\s*IN\s*\(\?,\s*\?\);$`
+
+	got, err := SelectInvolvement(100192, nil)
+	if err != nil || len(got) != 0 {
+		t.Errorf("SelectInvolvement() without users = %v, %v", got, err)
+	}
+
+	db, _ := conn.InitDBMock()
+	db.ExpectQuery(query).
+		WithArgs(100192, 12, 13).
+		WillReturnRows(sqlmock.NewRows([]string{"users_id", "status"}).AddRow(12, PStatusUnapproved))
+
+	got, err = SelectInvolvement(100192, []int64{12, 13})
+	if err != nil {
+		t.Fatalf("SelectInvolvement() error = %v", err)
+	}
+	want := []Involvement{{UserID: 12, Status: PStatusUnapproved}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SelectInvolvement() = %v, want %v", got, want)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("SelectInvolvement() %s", err.Error())
+	}
+}
@@ -0,0 +1,408 @@
+package course
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// SelectPrerequisite returns the prerequisites of the course with their names
+/*
+	@params:
+		courseID	= string
+	@example:
+		courseID	= D10K-7D02
+	@return
+		[]Prerequisite
+*/
+func SelectPrerequisite(courseID string) ([]Prerequisite, error) {
+	var prerequisites []Prerequisite
+	query := `
+		SELECT
+			p.courses_id,
+			p.prerequisites_id,
+			c.name,
+			p.min_grade
+		FROM
+			p_courses_prerequisites p
+		INNER JOIN
+			courses c
+		ON
+			p.prerequisites_id = c.id
+		WHERE
+			p.courses_id = (?)
+		ORDER BY
+			p.prerequisites_id ASC;
+		`
+	err := conn.Select(&prerequisites, query, courseID)
+	if err != nil {
+		return prerequisites, err
+	}
+	return prerequisites, nil
+}
+
+// ReplacePrerequisite function to replace the prerequisites of the course, an empty prerequisites removes all of them
+/*
+	@params:
+		courseID		= string
+		prerequisites	= []Prerequisite, only PrerequisiteID and MinGrade are used
+	@example:
+		courseID		= D10K-7D02
+		prerequisites	= [{PrerequisiteID: D10K-7D01, MinGrade: 60}]
+	@return
+*/
+func ReplacePrerequisite(courseID string, prerequisites []Prerequisite, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			p_courses_prerequisites
+		WHERE
+			courses_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, courseID)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO
+			p_courses_prerequisites (
+				courses_id,
+				prerequisites_id,
+				min_grade,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+	for _, val := range prerequisites {
+		_, err = conn.TxExec(tx, query, courseID, val.PrerequisiteID, val.MinGrade)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectStudiedSchedule returns the schedules of the courses which the user has studied as a student.
+// The deleted schedules and the schedule which is being enrolled are excluded
+/*
+	@params:
+		userID		= int64
+		coursesID	= []string
+		scheduleID	= int64
+	@example:
+		userID		= 12
+		coursesID	= [D10K-7D01]
+		scheduleID	= 100192
+	@return
+		[]StudiedSchedule
+*/
+func SelectStudiedSchedule(userID int64, coursesID []string, scheduleID int64) ([]StudiedSchedule, error) {
+	var schedules []StudiedSchedule
+	if len(coursesID) < 1 {
+		return schedules, nil
+	}
+
+	query := `
+		SELECT
+			s.id,
+			s.courses_id
+		FROM
+			schedules s
+		INNER JOIN
+			p_users_schedules pus
+		ON
+			s.id = pus.schedules_id
+		WHERE
+			pus.users_id = (?) AND
+			pus.status = (?) AND
+			s.courses_id IN (?) AND
+			s.status != (?) AND
+			s.id != (?);
+		`
+	err := conn.Select(&schedules, query, userID, PStatusStudent, coursesID, StatusScheduleDeleted, scheduleID)
+	if err != nil {
+		return schedules, err
+	}
+	return schedules, nil
+}
+
+// GetCapacity returns the capacity of the schedule, it is invalid if the schedule is unlimited
+/*
+	@params:
+		scheduleID	= int64
+	@example:
+		scheduleID	= 100192
+	@return
+		capacity	= sql.NullInt64
+*/
+func GetCapacity(scheduleID int64, tx ...*sqlx.Tx) (sql.NullInt64, error) {
+	var capacity sql.NullInt64
+	query := `
+		SELECT
+			capacity
+		FROM
+			schedules
+		WHERE
+			id = (?)
+		LIMIT 1;
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	err := conn.TxGet(t, &capacity, query, scheduleID)
+	if err != nil {
+		return capacity, err
+	}
+	return capacity, nil
+}
+
+// LockCapacity returns the capacity of the schedule and locks its row until the transaction ends,
+// so the seats are counted and taken by one enrollment at a time
+/*
+	@params:
+		scheduleID	= int64
+	@example:
+		scheduleID	= 100192
+	@return
+		capacity	= sql.NullInt64
+*/
+func LockCapacity(scheduleID int64, tx *sqlx.Tx) (sql.NullInt64, error) {
+	var capacity sql.NullInt64
+	query := `
+		SELECT
+			capacity
+		FROM
+			schedules
+		WHERE
+			id = (?)
+		LIMIT 1
+		FOR UPDATE;
+		`
+	err := conn.TxGet(tx, &capacity, query, scheduleID)
+	if err != nil {
+		return capacity, err
+	}
+	return capacity, nil
+}
+
+// UpdateCapacity function to change the capacity of the schedule, an invalid capacity makes it unlimited
+/*
+	@params:
+		scheduleID	= int64
+		capacity	= sql.NullInt64
+	@example:
+		scheduleID	= 100192
+		capacity	= 40
+	@return
+*/
+func UpdateCapacity(scheduleID int64, capacity sql.NullInt64, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			schedules
+		SET
+			capacity = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, capacity, scheduleID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// CountSeat returns the number of seats taken in the schedule, the enrollment requests take a seat until they are rejected
+/*
+	@params:
+		scheduleID	= int64
+	@example:
+		scheduleID	= 100192
+	@return
+		count		= int
+*/
+func CountSeat(scheduleID int64, tx ...*sqlx.Tx) (int, error) {
+	var count int
+	query := `
+		SELECT
+			COUNT(*)
+		FROM
+			p_users_schedules
+		WHERE
+			schedules_id = (?) AND
+			status IN (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	err := conn.TxGet(t, &count, query, scheduleID, []int8{PStatusUnapproved, PStatusStudent})
+	if err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// InsertWaitlist function to put the user at the end of the waitlist of the schedule
+/*
+	@params:
+		userID		= int64
+		scheduleID	= int64
+	@example:
+		userID		= 12
+		scheduleID	= 100192
+	@return
+*/
+func InsertWaitlist(userID, scheduleID int64, tx ...*sqlx.Tx) error {
+	query := `
+		INSERT INTO
+			waitlists (
+				users_id,
+				schedules_id,
+				created_at
+			) VALUES (
+				(?),
+				(?),
+				NOW()
+			);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	_, err := conn.TxExec(t, query, userID, scheduleID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsWaitlisted check whether the user is in the waitlist of the schedule
+/*
+	@params:
+		userID		= int64
+		scheduleID	= int64
+	@example:
+		userID		= 12
+		scheduleID	= 100192
+	@return
+*/
+func IsWaitlisted(userID, scheduleID int64) bool {
+	var x string
+	query := `
+		SELECT
+			'x'
+		FROM
+			waitlists
+		WHERE
+			users_id = (?) AND
+			schedules_id = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&x, query, userID, scheduleID)
+	if err != nil {
+		return false
+	}
+	return true
+}
+
+// SelectWaitlist returns the waitlisted users of the schedule, the earliest is the first
+/*
+	@params:
+		scheduleID	= int64
+	@example:
+		scheduleID	= 100192
+	@return
+		usersID		= []int64
+*/
+func SelectWaitlist(scheduleID int64, tx ...*sqlx.Tx) ([]int64, error) {
+	var usersID []int64
+	query := `
+		SELECT
+			users_id
+		FROM
+			waitlists
+		WHERE
+			schedules_id = (?)
+		ORDER BY
+			created_at ASC,
+			users_id ASC;
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	err := conn.TxSelect(t, &usersID, query, scheduleID)
+	if err != nil {
+		return usersID, err
+	}
+	return usersID, nil
+}
+
+// DeleteWaitlist function to remove the user from the waitlist of the schedule
+/*
+	@params:
+		userID		= int64
+		scheduleID	= int64
+	@example:
+		userID		= 12
+		scheduleID	= 100192
+	@return
+*/
+func DeleteWaitlist(userID, scheduleID int64, tx ...*sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			waitlists
+		WHERE
+			users_id = (?) AND
+			schedules_id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, userID, scheduleID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// DeleteWaitlistByUserID removes the user from every waitlist
+func DeleteWaitlistByUserID(userID int64, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			waitlists
+		WHERE
+			users_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, userID)
+	return err
+}
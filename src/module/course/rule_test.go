@@ -0,0 +1,27 @@
+package course
+
+import (
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestLockCapacity(t *testing.T) {
+	query := `^\s*SELECT\s*capacity\s*FROM\s*schedules\s*WHERE\s*id\s*=\s*\(\?\)\s*LIMIT\s*1\s*FOR\s*UPDATE;$`
+
+	db, _ := conn.InitDBMock()
+	db.ExpectBegin()
+	db.ExpectQuery(query).
+		WithArgs(100192).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity"}).AddRow(40))
+
+	tx := conn.DB.MustBegin()
+	got, err := LockCapacity(100192, tx)
+	if err != nil || !got.Valid || got.Int64 != 40 {
+		t.Errorf("LockCapacity() = %v, %v, want 40", got, err)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("LockCapacity() %s", err.Error())
+	}
+}
@@ -0,0 +1,164 @@
+package course
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// AttachTerm function to attach the schedules of the semester and the year to the term
+/*
+	@params:
+		termID		= int64
+		semester	= int8
+		year		= int16
+	@example:
+		termID		= 1
+		semester	= 1
+		year		= 2018
+	@return
+*/
+func AttachTerm(termID int64, semester int8, year int16, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			schedules
+		SET
+			terms_id = (?)
+		WHERE
+			semester = (?) AND
+			year = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	_, err := conn.TxExec(t, query, termID, semester, year)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateScheduleTerm function to change the term of the schedule, an invalid termID detaches it
+/*
+	@params:
+		scheduleID	= int64
+		termID		= sql.NullInt64
+	@example:
+		scheduleID	= 100192
+		termID		= 1
+	@return
+*/
+func UpdateScheduleTerm(scheduleID int64, termID sql.NullInt64, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			schedules
+		SET
+			terms_id = (?)
+		WHERE
+			id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	_, err := conn.TxExec(t, query, termID, scheduleID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTermID returns the term of the schedule, it is invalid if the schedule doesn't belong to any term
+func GetTermID(scheduleID int64) (sql.NullInt64, error) {
+	var termID sql.NullInt64
+	query := `
+		SELECT
+			terms_id
+		FROM
+			schedules
+		WHERE
+			id = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&termID, query, scheduleID)
+	if err != nil {
+		return termID, err
+	}
+	return termID, nil
+}
+
+// SelectScheduleByTerm returns the schedules of the term which are not deleted
+/*
+	@params:
+		termID	= int64
+	@example:
+		termID	= 1
+	@return
+		[]Schedule
+*/
+func SelectScheduleByTerm(termID int64) ([]Schedule, error) {
+	var schedules []Schedule
+	query := `
+		SELECT
+			id,
+			status,
+			start_time,
+			end_time,
+			day,
+			class,
+			semester,
+			year,
+			courses_id,
+			places_id,
+			created_by
+		FROM
+			schedules
+		WHERE
+			terms_id = (?) AND
+			status != (?)
+		ORDER BY
+			id ASC;
+		`
+	err := conn.Select(&schedules, query, termID, StatusScheduleDeleted)
+	if err != nil {
+		return schedules, err
+	}
+	return schedules, nil
+}
+
+// UpdateStatusByTerm function to change the status of the schedules of the term, the deleted schedules are kept
+/*
+	@params:
+		termID	= int64
+		status	= int8
+	@example:
+		termID	= 1
+		status	= 0
+	@return
+*/
+func UpdateStatusByTerm(termID int64, status int8, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			schedules
+		SET
+			status = (?),
+			updated_at = NOW()
+		WHERE
+			terms_id = (?) AND
+			status != (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	_, err := conn.TxExec(t, query, status, termID, StatusScheduleDeleted)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package file
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// Clone function to add the metadata of a copied file which belongs to the tableID.
+// The content is copied on the disk by the caller
+/*
+	@params:
+		file	= File, ID is the id of the copy
+		userID	= int64
+		tableID	= string
+	@example:
+		file	= {ID: 1539812345678901234.123456, Name: modul-1, Mime: application/pdf, Extension: pdf, Type: TT-FILE}
+		userID	= 12
+		tableID	= 22
+	@return
+*/
+func Clone(file File, userID int64, tableID string, tx *sqlx.Tx) error {
+	query := `
+		INSERT INTO
+			files (
+				id,
+				name,
+				mime,
+				extension,
+				type,
+				users_id,
+				table_id,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+	result, err := conn.TxExec(tx, query, file.ID, file.Name, file.Mime, file.Extension, file.Type, userID, tableID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
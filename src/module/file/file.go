@@ -1,7 +1,6 @@
 package file
 
 import (
-	"database/sql"
 	"fmt"
 	"strings"
 
@@ -32,8 +31,8 @@ func GetByIDExt(id string, column ...string) (File, error) {
 	}
 
 	cols := strings.Join(c, ", ")
-	query := fmt.Sprintf(`SELECT %s FROM files WHERE id = ('%s') LIMIT 1;`, cols, id)
-	err := conn.DB.Get(&file, query)
+	query := fmt.Sprintf(`SELECT %s FROM files WHERE id = (?) LIMIT 1;`, cols)
+	err := conn.Get(&file, query, id)
 	if err != nil {
 		return file, err
 	}
@@ -61,8 +60,8 @@ func GetByTypeUserID(userID int64, typ string, column ...string) (File, error) {
 	}
 
 	cols := strings.Join(c, ", ")
-	query := fmt.Sprintf(`SELECT %s FROM files WHERE users_id = (%d) AND type = ('%s') AND status = (%d) LIMIT 1;`, cols, userID, typ, StatusExist)
-	err := conn.DB.Get(&file, query)
+	query := fmt.Sprintf(`SELECT %s FROM files WHERE users_id = (?) AND type = (?) AND status = (?) LIMIT 1;`, cols)
+	err := conn.Get(&file, query, userID, typ, StatusExist)
 	if err != nil {
 		return file, err
 	}
@@ -70,22 +69,18 @@ func GetByTypeUserID(userID int64, typ string, column ...string) (File, error) {
 }
 
 func DeleteProfileImage(userID int64, tx *sqlx.Tx) error {
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			files
 		SET
-			status = (%d),
+			status = (?),
 			updated_at = NOW()
 		WHERE
-			users_id = (%d) AND
-			type IN ('%s', '%s');`, StatusDeleted, userID, TypProfPict, TypProfPictThumb)
+			users_id = (?) AND
+			type IN (?);`
 
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	types := []string{TypProfPict, TypProfPictThumb}
+	_, err := conn.TxExec(tx, query, StatusDeleted, userID, types)
 	if err != nil {
 		return err
 	}
@@ -95,21 +90,16 @@ func DeleteProfileImage(userID int64, tx *sqlx.Tx) error {
 // DeleteByRelation ...
 func DeleteByRelation(typ, tableID string, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			files
 		SET
-			status = (%d),
+			status = (?),
 			updated_at = NOW()
 		WHERE
-			type = ('%s') AND
-			table_id = ('%s');`, StatusDeleted, typ, tableID)
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+			type = (?) AND
+			table_id = (?);`
+	_, err := conn.TxExec(tx, query, StatusDeleted, typ, tableID)
 	if err != nil {
 		return err
 	}
@@ -118,21 +108,15 @@ func DeleteByRelation(typ, tableID string, tx *sqlx.Tx) error {
 
 // Delete ...
 func Delete(id string, tx *sqlx.Tx) error {
-	var result sql.Result
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			files
 		SET
-			status = (%d),
+			status = (?),
 			updated_at = NOW()
 		WHERE
-			id = ('%s');`, StatusDeleted, id)
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+			id = (?);`
+	result, err := conn.TxExec(tx, query, StatusDeleted, id)
 	if err != nil {
 		return err
 	}
@@ -147,35 +131,25 @@ func Delete(id string, tx *sqlx.Tx) error {
 func GetByStatus(status int, tableID int64) ([]string, error) {
 
 	var files []string
-	query := fmt.Sprintf(`
+	query := `
 			SELECT 
 				id
 			FROM
 				files
 			WHERE
-				status = (%d) AND table_id = (%d) 
-			;`, status, tableID)
+				status = (?) AND table_id = (?) 
+			;`
 
-	rows, err := conn.DB.Query(query)
+	err := conn.Select(&files, query, status, tableID)
 	if err != nil {
 		return files, err
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return files, err
-		}
-		files = append(files, id)
-	}
 	return files, nil
 }
 
 func Insert(id, name, mime, extension string, userID int64, typ string, tx *sqlx.Tx) error {
 
-	var result sql.Result
-	var err error
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 		files (
 			id,
@@ -187,21 +161,17 @@ func Insert(id, name, mime, extension string, userID int64, typ string, tx *sqlx
 			created_at,
 			updated_at
 		) VALUES (
-			('%s'),
-			('%s'),
-			('%s'),
-			('%s'),
-			('%s'),
-			(%d),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
 			NOW(),
 			NOW()
-		);`, id, name, mime, extension, typ, userID)
+		);`
 
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, id, name, mime, extension, typ, userID)
 	if err != nil {
 		return err
 	}
@@ -214,9 +184,7 @@ func Insert(id, name, mime, extension string, userID int64, typ string, tx *sqlx
 
 func InsertImageProfile(id, name, mime, extension string, userID int64, typ string, tx *sqlx.Tx) error {
 
-	var result sql.Result
-	var err error
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 		files (
 			id,
@@ -229,22 +197,18 @@ func InsertImageProfile(id, name, mime, extension string, userID int64, typ stri
 			created_at,
 			updated_at
 		) VALUES (
-			('%s'),
-			('%s'),
-			('%s'),
-			('%s'),
-			('%s'),
-			(%d),
-			(%d),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
+			(?),
 			NOW(),
 			NOW()
-		);`, id, name, mime, extension, typ, userID, userID)
+		);`
 
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, id, name, mime, extension, typ, userID, userID)
 	if err != nil {
 		return err
 	}
@@ -258,24 +222,18 @@ func InsertImageProfile(id, name, mime, extension string, userID int64, typ stri
 // UpdateRelation ..
 func UpdateRelation(id, typ, tableID string, tx *sqlx.Tx) error {
 
-	var result sql.Result
-	var err error
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			files
 		SET
-			table_id = ('%s'),
+			table_id = (?),
 			updated_at = NOW()
 		WHERE
-			id = ('%s') AND
-			type = ('%s') AND
+			id = (?) AND
+			type = (?) AND
 			table_id IS NULL;
-		`, tableID, id, typ)
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+		`
+	result, err := conn.TxExec(tx, query, tableID, id, typ)
 	if err != nil {
 		return err
 	}
@@ -289,19 +247,19 @@ func UpdateRelation(id, typ, tableID string, tx *sqlx.Tx) error {
 
 func IsHasRelation(id string) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			files
 		WHERE
-			id = ('%s') AND
+			id = (?) AND
 			table_name IS NOT NULL AND
 			table_id IS NOT NULL
 		LIMIT 1;	
-	`, id)
+	`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, id)
 	if err != nil {
 		return false
 	}
@@ -311,22 +269,16 @@ func IsHasRelation(id string) bool {
 // UpdateStatusFiles func ...
 func UpdateStatusFiles(id string, status int, tx *sqlx.Tx) error {
 
-	var result sql.Result
-	var err error
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE 
 			files
 		SET
-			status = (%d)
+			status = (?)
 		WHERE
-			id = ('%s')
-		;`, status, id)
+			id = (?)
+		;`
 
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	result, err := conn.TxExec(tx, query, status, id)
 	if err != nil {
 		return err
 	}
@@ -341,18 +293,18 @@ func UpdateStatusFiles(id string, status int, tx *sqlx.Tx) error {
 func IsExistID(fileID string) bool {
 
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT 
 			'x'
 		FROM
 			files
 		WHERE
-			id = ('%s') AND
-			status = (%d)
+			id = (?) AND
+			status = (?)
 		LIMIT 1;
-		`, fileID, StatusExist)
+		`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, fileID, StatusExist)
 	if err != nil {
 		return false
 	}
@@ -368,11 +320,13 @@ func SelectByRelation(typ string, tablesID []string, userID *int64) ([]File, err
 	}
 
 	var queryUserID string
+	args := []interface{}{}
 	if userID != nil {
-		queryUserID = fmt.Sprintf("users_id = (%d) AND ", *userID)
+		queryUserID = "users_id = (?) AND "
+		args = append(args, *userID)
 	}
+	args = append(args, StatusExist, typ, tablesID)
 
-	queryTableID := strings.Join(tablesID, ", ")
 	query := fmt.Sprintf(`
 		SELECT 
 			id,
@@ -386,12 +340,12 @@ func SelectByRelation(typ string, tablesID []string, userID *int64) ([]File, err
 			files
 		WHERE
 			%s
-			status = (%d) AND
-			type = ('%s') AND
-			table_id IN (%s);
-		`, queryUserID, StatusExist, typ, queryTableID)
+			status = (?) AND
+			type = (?) AND
+			table_id IN (?);
+		`, queryUserID)
 
-	err := conn.DB.Select(&files, query)
+	err := conn.Select(&files, query, args...)
 	if err != nil {
 		return files, err
 	}
@@ -402,18 +356,18 @@ func SelectByRelation(typ string, tablesID []string, userID *int64) ([]File, err
 func SelectIDByRelation(typ string, tableID string, userID int64) ([]string, error) {
 
 	var filesID []string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT 
 			id
 		FROM
 			files
 		WHERE
-			users_id = (%d) AND
-			status = (%d) AND
-			type = ('%s') AND
-			table_id = ('%s');
-		`, userID, StatusExist, typ, tableID)
-	err := conn.DB.Select(&filesID, query)
+			users_id = (?) AND
+			status = (?) AND
+			type = (?) AND
+			table_id = (?);
+		`
+	err := conn.Select(&filesID, query, userID, StatusExist, typ, tableID)
 	if err != nil {
 		return filesID, err
 	}
@@ -424,16 +378,16 @@ func SelectIDByRelation(typ string, tableID string, userID int64) ([]string, err
 func SelectCountIDByRelation(typ string, tableID string, userID int64) (int, error) {
 
 	var count int
-	query := fmt.Sprintf(`
+	query := `
 		SELECT COUNT(*) FROM
 			files
 		WHERE
-			users_id = (%d) AND
-			status = (%d) AND
-			type = ('%s') AND
-			table_id = ('%s');
-		`, userID, StatusExist, typ, tableID)
-	err := conn.DB.Get(&count, query)
+			users_id = (?) AND
+			status = (?) AND
+			type = (?) AND
+			table_id = (?);
+		`
+	err := conn.Get(&count, query, userID, StatusExist, typ, tableID)
 	if err != nil {
 		return count, err
 	}
@@ -442,21 +396,15 @@ func SelectCountIDByRelation(typ string, tableID string, userID int64) (int, err
 
 // UpdateStatusFilesByNameID func ...
 func UpdateStatusFilesByNameID(TableName string, Status, TableID int64, tx *sqlx.Tx) error {
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			files
 		SET
-			status=(%d)
+			status=(?)
 		WHERE
-			table_name=('%s') AND table_id=(%d)
-		;`, Status, TableName, TableID)
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+			table_name=(?) AND table_id=(?)
+		;`
+	result, err := conn.TxExec(tx, query, Status, TableName, TableID)
 	if err != nil {
 		return err
 	}
@@ -471,20 +419,20 @@ func UpdateStatusFilesByNameID(TableName string, Status, TableID int64, tx *sqlx
 func GetByRelation(typ, tableID string) (File, error) {
 
 	var file File
-	query := fmt.Sprintf(`
+	query := `
 		SELECT 
 			id,
 			extension
 		FROM
 			files
 		WHERE
-			status = (%d) AND
-			type = ('%s') AND
-			table_id = ('%s')
+			status = (?) AND
+			type = (?) AND
+			table_id = (?)
 		LIMIT 1;
-		`, StatusExist, typ, tableID)
+		`
 
-	err := conn.DB.Get(&file, query)
+	err := conn.Get(&file, query, StatusExist, typ, tableID)
 	if err != nil {
 		return file, err
 	}
@@ -494,17 +442,17 @@ func GetByRelation(typ, tableID string) (File, error) {
 // GetByUserIDTableIDName func ...
 func GetByUserIDTableIDName(UserID, TableID int64, TableName string) ([]File, error) {
 	var files []File
-	query := fmt.Sprintf(`
+	query := `
 		SELECT 
 			id,
 			extension
 		FROM
 			files
 		WHERE
-			users_id = (%d) AND table_name=('%s') AND table_id=(%d)
-		`, UserID, TableName, TableID)
+			users_id = (?) AND table_name=(?) AND table_id=(?)
+		`
 
-	err := conn.DB.Select(&files, query)
+	err := conn.Select(&files, query, UserID, TableName, TableID)
 	if err != nil {
 		return files, err
 	}
@@ -513,10 +461,8 @@ func GetByUserIDTableIDName(UserID, TableID int64, TableName string) ([]File, er
 
 // SelectIDStatusByID ..
 func SelectIDStatusByID(filesID []string) ([]IDStatus, error) {
-	ids := strings.Join(filesID, ", ")
-
 	var result []IDStatus
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			status
@@ -525,9 +471,9 @@ func SelectIDStatusByID(filesID []string) ([]IDStatus, error) {
 		WHERE
 			id
 		IN
-			('%s')
-		`, ids)
-	err := conn.DB.Select(&result, query)
+			(?)
+		`
+	err := conn.Select(&result, query, filesID)
 	if err != nil {
 		return result, err
 	}
@@ -537,8 +483,10 @@ func SelectIDStatusByID(filesID []string) ([]IDStatus, error) {
 // InsertType ..
 func InsertType(typ []string, assignmentID int64, tx *sqlx.Tx) error {
 	var value []string
+	var args []interface{}
 	for _, val := range typ {
-		value = append(value, fmt.Sprintf(`('%s',%d)`, val, assignmentID))
+		value = append(value, "(?, ?)")
+		args = append(args, val, assignmentID)
 	}
 	valueQuery := strings.Join(value, ", ")
 	query := fmt.Sprintf(`
@@ -551,7 +499,7 @@ func InsertType(typ []string, assignmentID int64, tx *sqlx.Tx) error {
 		VALUES
 			%s
 		;`, valueQuery)
-	result, err := tx.Exec(query)
+	result, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -564,16 +512,16 @@ func InsertType(typ []string, assignmentID int64, tx *sqlx.Tx) error {
 
 //SelectTypeByID ..
 func SelectTypeByID(assignmentID int64) ([]string, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			name
 		FROM
 			types
 		WHERE
-		assignments_id = (%d);
-		`, assignmentID)
+		assignments_id = (?);
+		`
 	var typs []string
-	err := conn.DB.Select(&typs, query)
+	err := conn.Select(&typs, query, assignmentID)
 	if err != nil {
 		return typs, err
 	}
@@ -582,19 +530,13 @@ func SelectTypeByID(assignmentID int64) ([]string, error) {
 
 // DeleteTypeByID ..
 func DeleteTypeByID(typ []string, assignmentID int64, tx *sqlx.Tx) error {
-	var listTyp []string
-
-	for _, val := range typ {
-		listTyp = append(listTyp, fmt.Sprintf(`'%s'`, val))
-	}
-	queryTyp := strings.Join(listTyp, ", ")
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM 
 			types
 		WHERE
-			assignments_id = (%d) AND name IN (%s);
-		`, assignmentID, queryTyp)
-	result, err := tx.Exec(query)
+			assignments_id = (?) AND name IN (?);
+		`
+	result, err := conn.TxExec(tx, query, assignmentID, typ)
 	if err != nil {
 		return err
 	}
@@ -607,13 +549,13 @@ func DeleteTypeByID(typ []string, assignmentID int64, tx *sqlx.Tx) error {
 
 // DeleteAllTypeByID ..
 func DeleteAllTypeByID(assignmentID int64, tx *sqlx.Tx) error {
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM 
 			types
 		WHERE
-			assignments_id = (%d);
-		`, assignmentID)
-	result, err := tx.Exec(query)
+			assignments_id = (?);
+		`
+	result, err := conn.TxExec(tx, query, assignmentID)
 	if err != nil {
 		return err
 	}
@@ -626,16 +568,62 @@ func DeleteAllTypeByID(assignmentID int64, tx *sqlx.Tx) error {
 
 //SelectCountTypeByID ..
 func SelectCountTypeByID(assignmentID int64) (int, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT COUNT(*) FROM
 			types
 		WHERE
-			assignments_id =(%d);
-		`, assignmentID)
+			assignments_id =(?);
+		`
 	var count int
-	err := conn.DB.Get(&count, query)
+	err := conn.Get(&count, query, assignmentID)
 	if err != nil {
 		return count, err
 	}
 	return count, nil
 }
+
+// SelectByUserID returns the files of the given types which are uploaded by the user, the deleted files are included
+// unless the status is given
+func SelectByUserID(userID int64, typ []string, status ...int) ([]File, error) {
+	var st string
+	args := []interface{}{userID, typ}
+	if len(status) == 1 {
+		st = "AND status = (?)"
+		args = append(args, status[0])
+	}
+
+	var files []File
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			name,
+			mime,
+			extension,
+			users_id,
+			type,
+			table_name,
+			table_id
+		FROM
+			files
+		WHERE
+			users_id = (?) AND
+			type IN (?) %s;`, st)
+	err := conn.Select(&files, query, args...)
+	if err != nil {
+		return files, err
+	}
+	return files, nil
+}
+
+// DeleteByUserID removes the records of the files of the given types which are uploaded by the user,
+// the caller removes the files from the storage after the transaction is committed
+func DeleteByUserID(userID int64, typ []string, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			files
+		WHERE
+			users_id = (?) AND
+			type IN (?);`
+	_, err := conn.TxExec(tx, query, userID, typ)
+	return err
+}
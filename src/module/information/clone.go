@@ -0,0 +1,81 @@
+package information
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// SelectAllByScheduleID returns every information of the schedule, the general information is excluded and the earliest is the first
+/*
+	@params:
+		scheduleID	= int64
+	@example:
+		scheduleID	= 100192
+	@return
+		[]Information
+*/
+func SelectAllByScheduleID(scheduleID int64) ([]Information, error) {
+	var informations []Information
+	query := `
+		SELECT
+			id,
+			title,
+			description,
+			schedules_id,
+			created_at,
+			updated_at
+		FROM
+			informations
+		WHERE
+			schedules_id = (?)
+		ORDER BY
+			created_at ASC,
+			id ASC;
+		`
+	err := conn.Select(&informations, query, scheduleID)
+	if err != nil {
+		return informations, err
+	}
+	return informations, nil
+}
+
+// Clone function to copy the information into the schedule
+/*
+	@params:
+		information	= Information, only Title and Description are used
+		scheduleID	= int64
+	@example:
+		information	= {Title: Kuliah Pengganti, Description: Kuliah diganti hari Sabtu}
+		scheduleID	= 100193
+	@return
+		id			= the id of the copy
+*/
+func Clone(information Information, scheduleID int64, tx *sqlx.Tx) (int64, error) {
+	query := `
+		INSERT INTO
+			informations (
+				title,
+				description,
+				schedules_id,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+	result, err := conn.TxExec(tx, query, information.Title, information.Description, scheduleID)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("No rows affected")
+	}
+	return id, nil
+}
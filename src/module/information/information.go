@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/melodiez14/meiko/src/util/conn"
-	"github.com/melodiez14/meiko/src/util/helper"
 )
 
 //SelectByScheduleID func ...
@@ -15,7 +14,6 @@ func SelectByScheduleID(scheduleID []int64, column ...string) ([]Information, er
 
 	var info []Information
 	var c []string
-	d := helper.Int64ToStringSlice(scheduleID)
 
 	if len(scheduleID) < 1 {
 		return info, nil
@@ -35,7 +33,6 @@ func SelectByScheduleID(scheduleID []int64, column ...string) ([]Information, er
 			c = append(c, val)
 		}
 	}
-	ids := strings.Join(d, ", ")
 	cols := strings.Join(c, ", ")
 	query := fmt.Sprintf(`
 		SELECT
@@ -45,10 +42,10 @@ func SelectByScheduleID(scheduleID []int64, column ...string) ([]Information, er
 		WHERE
 			schedules_id IS NULL
 		OR
-			schedules_id IN (%s)
+			schedules_id IN (?)
 		ORDER BY created_at DESC
-		LIMIT 100`, cols, ids)
-	err := conn.DB.Select(&info, query)
+		LIMIT 100`, cols)
+	err := conn.Select(&info, query, scheduleID)
 	if err != nil {
 		return info, err
 	}
@@ -81,10 +78,10 @@ func GetByID(informationID int64, column ...string) (Information, error) {
 		FROM
 			informations
 		WHERE
-			id = (%d)
+			id = (?)
 		LIMIT 1
-		;`, cols, informationID)
-	err := conn.DB.Get(&info, query)
+		;`, cols)
+	err := conn.Get(&info, query, informationID)
 	if err != nil {
 		return info, err
 	}
@@ -94,16 +91,16 @@ func GetByID(informationID int64, column ...string) (Information, error) {
 // GetScheduleIDByID func ...
 func GetScheduleIDByID(informationID int64) int64 {
 	var id int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			schedules_id
 		FROM
 			informations
 		WHERE
-			id = (%d)
+			id = (?)
 		LIMIT 1
-		;`, informationID)
-	err := conn.DB.Get(&id, query)
+		;`
+	err := conn.Get(&id, query, informationID)
 	if err != nil {
 		return 0
 	}
@@ -114,8 +111,7 @@ func GetScheduleIDByID(informationID int64) int64 {
 func Insert(title, description string, scheduleID int64) error {
 	var c []string
 	var data string
-	var result sql.Result
-	var err error
+	var args []interface{}
 
 	if scheduleID == 0 {
 		c = []string{
@@ -124,7 +120,8 @@ func Insert(title, description string, scheduleID int64) error {
 			CreatedAt,
 			UpdatedAt,
 		}
-		data = fmt.Sprintf(`'%s','%s', NOW(), NOW()`, title, description)
+		data = `?, ?, NOW(), NOW()`
+		args = []interface{}{title, description}
 	} else {
 		c = []string{
 			ColTitle,
@@ -133,7 +130,8 @@ func Insert(title, description string, scheduleID int64) error {
 			CreatedAt,
 			UpdatedAt,
 		}
-		data = fmt.Sprintf(`'%s','%s',(%d), NOW(), NOW()`, title, description, scheduleID)
+		data = `?, ?, (?), NOW(), NOW()`
+		args = []interface{}{title, description, scheduleID}
 	}
 	cols := strings.Join(c, ", ")
 	query := fmt.Sprintf(`
@@ -148,8 +146,7 @@ func Insert(title, description string, scheduleID int64) error {
 			)
 		;`, cols, data)
 
-	result, err = conn.DB.Exec(query)
-
+	result, err := conn.Exec(query, args...)
 	if err != nil {
 		return err
 	}
@@ -162,33 +159,20 @@ func Insert(title, description string, scheduleID int64) error {
 
 // Update func ...
 func Update(title, description string, scheduleID, informationID int64) error {
-	var data string
-	if scheduleID == 0 {
-		data = fmt.Sprintf(`
-			title = '%s',
-			description = '%s',
-			schedules_id = NULL,
-			updated_at = NOW()`, title, description)
-	} else {
-		data = fmt.Sprintf(`
-			title = '%s',
-			description = '%s',
-			schedules_id = %d,
-			updated_at = NOW()`, title, description, scheduleID)
-	}
-	query := fmt.Sprintf(`
+	schID := sql.NullInt64{Int64: scheduleID, Valid: scheduleID != 0}
+	query := `
 		UPDATE 
 			informations
 		SET
-			%s
+			title = (?),
+			description = (?),
+			schedules_id = (?),
+			updated_at = NOW()
 		WHERE
-			id = (%d)
-		;`, data, informationID)
-	var result sql.Result
-	var err error
-
-	result, err = conn.DB.Exec(query)
+			id = (?)
+		;`
 
+	result, err := conn.Exec(query, title, description, schID, informationID)
 	if err != nil {
 		return err
 	}
@@ -202,16 +186,16 @@ func Update(title, description string, scheduleID, informationID int64) error {
 // IsInformationIDExist func ...
 func IsInformationIDExist(informationID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			informations
 		WHERE
-			id = (%d)
+			id = (?)
 		LIMIT 1
-		;`, informationID)
-	err := conn.DB.Get(&x, query)
+		;`
+	err := conn.Get(&x, query, informationID)
 	if err != nil {
 		return false
 	}
@@ -220,13 +204,13 @@ func IsInformationIDExist(informationID int64) bool {
 
 // Delete func ...
 func Delete(informationID int64) error {
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			informations
 		WHERE
-			id = (%d)
-		;`, informationID)
-	result, err := conn.DB.Exec(query)
+			id = (?)
+		;`
+	result, err := conn.Exec(query, informationID)
 	if err != nil {
 		return err
 	}
@@ -242,7 +226,6 @@ func SelectByPage(scheduleID []int64, total, offset int64, column ...string) ([]
 
 	var info []Information
 	var c []string
-	d := helper.Int64ToStringSlice(scheduleID)
 	if len(column) < 1 {
 		c = []string{
 			ColID,
@@ -257,7 +240,6 @@ func SelectByPage(scheduleID []int64, total, offset int64, column ...string) ([]
 			c = append(c, val)
 		}
 	}
-	ids := strings.Join(d, ", ")
 	cols := strings.Join(c, ", ")
 	query := fmt.Sprintf(`
 		SELECT
@@ -267,10 +249,10 @@ func SelectByPage(scheduleID []int64, total, offset int64, column ...string) ([]
 			WHERE
 			schedules_id IS NULL
 		OR
-			schedules_id IN (%s)
+			schedules_id IN (?)
 		ORDER BY created_at DESC
-		LIMIT %d OFFSET %d`, cols, ids, total, offset)
-	err := conn.DB.Select(&info, query)
+		LIMIT ? OFFSET ?`, cols)
+	err := conn.Select(&info, query, scheduleID, total, offset)
 	if err != nil {
 		return info, err
 	}
@@ -280,17 +262,19 @@ func SelectByScheduleIDAndTime(scheduleID []int64, t []time.Time, column ...stri
 
 	var info []Information
 	var c []string
-	d := helper.Int64ToStringSlice(scheduleID)
 
 	if len(scheduleID) < 1 {
 		return info, nil
 	}
 
 	var queryTime string
+	args := []interface{}{scheduleID}
 	if len(t) == 1 {
-		queryTime = fmt.Sprintf("AND date(created_at) = ('%s')", t[0].Format("2006-01-02"))
+		queryTime = "AND date(created_at) = (?)"
+		args = append(args, t[0].Format("2006-01-02"))
 	} else if len(t) == 2 {
-		queryTime = fmt.Sprintf("AND date(created_at) BETWEEN ('%s') AND ('%s')", t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
+		queryTime = "AND date(created_at) BETWEEN (?) AND (?)"
+		args = append(args, t[0].Format("2006-01-02"), t[1].Format("2006-01-02"))
 	} else if len(t) > 2 {
 		return info, fmt.Errorf("date more than two")
 	}
@@ -308,7 +292,6 @@ func SelectByScheduleIDAndTime(scheduleID []int64, t []time.Time, column ...stri
 			c = append(c, val)
 		}
 	}
-	ids := strings.Join(d, ", ")
 	cols := strings.Join(c, ", ")
 	query := fmt.Sprintf(`
 			SELECT
@@ -318,11 +301,11 @@ func SelectByScheduleIDAndTime(scheduleID []int64, t []time.Time, column ...stri
 			WHERE (
 					schedules_id IS NULL
 				OR
-					schedules_id IN (%s)
+					schedules_id IN (?)
 			) %s
 			ORDER BY created_at DESC
-			LIMIT 5`, cols, ids, queryTime)
-	err := conn.DB.Select(&info, query)
+			LIMIT 5`, cols, queryTime)
+	err := conn.Select(&info, query, args...)
 	if err != nil {
 		return info, err
 	}
@@ -332,17 +315,15 @@ func SelectByScheduleIDAndTime(scheduleID []int64, t []time.Time, column ...stri
 // CountInformation is
 func CountInformation(scheduleID []int64, total, offset int64, column ...string) (int64, error) {
 	var count int64
-	d := helper.Int64ToStringSlice(scheduleID)
-	ids := strings.Join(d, ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT COUNT(*)
 		FROM
 			informations
 			WHERE
 			schedules_id IS NULL
 		OR
-			schedules_id IN (%s);`, ids)
-	err := conn.DB.Get(&count, query)
+			schedules_id IN (?);`
+	err := conn.Get(&count, query, scheduleID)
 	if err != nil {
 		return count, err
 	}
@@ -0,0 +1,104 @@
+package lockout
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// Insert records a lock or unlock event, actorID is the admin who unlocked
+/*
+	@params:
+		email		= string
+		ip			= string
+		scope		= string
+		event		= int8
+		failures	= int64
+		lockedUntil	= time.Time
+		actorID		= sql.NullInt64
+	@example:
+		email		= risal@live.com
+		ip			= 10.10.1.20
+		scope		= signin
+		event		= 1
+		failures	= 10
+		lockedUntil	= 2017-12-21 08:10:00
+		actorID		= NULL
+	@return
+*/
+func Insert(email, ip, scope string, event int8, failures int64, lockedUntil time.Time, actorID sql.NullInt64) error {
+	query := `
+		INSERT INTO
+			lockout_logs (
+				email,
+				ip,
+				scope,
+				event,
+				failures,
+				locked_until,
+				actor_id,
+				created_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				NOW()
+			);
+		`
+	_, err := conn.Exec(query, email, ip, scope, event, failures, lockedUntil, actorID)
+	return err
+}
+
+// SelectByEmail returns the latest lock and unlock events of the email
+func SelectByEmail(email string, limit int) ([]Log, error) {
+	var logs []Log
+	query := `
+		SELECT
+			id,
+			email,
+			ip,
+			scope,
+			event,
+			failures,
+			locked_until,
+			actor_id,
+			created_at
+		FROM
+			lockout_logs
+		WHERE
+			email = (?)
+		ORDER BY
+			id DESC
+		LIMIT ?;
+		`
+	err := conn.Select(&logs, query, email, limit)
+	if err != nil {
+		return logs, err
+	}
+	return logs, nil
+}
+
+// DeleteByEmail removes the lock and unlock events of the email
+/*
+	@params:
+		email	= string
+	@example:
+		email	= risal@live.com
+	@return
+*/
+func DeleteByEmail(email string, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			lockout_logs
+		WHERE
+			email = (?);
+		`
+	_, err := conn.TxExec(tx, query, email)
+	return err
+}
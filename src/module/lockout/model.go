@@ -0,0 +1,24 @@
+package lockout
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	EventLock   = 1
+	EventUnlock = 2
+)
+
+// Log is a lock or unlock event of an email or an IP address
+type Log struct {
+	ID          int64         `db:"id"`
+	Email       string        `db:"email"`
+	IP          string        `db:"ip"`
+	Scope       string        `db:"scope"`
+	Event       int8          `db:"event"`
+	Failures    int64         `db:"failures"`
+	LockedUntil time.Time     `db:"locked_until"`
+	ActorID     sql.NullInt64 `db:"actor_id"`
+	CreatedAt   time.Time     `db:"created_at"`
+}
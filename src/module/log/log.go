@@ -1,7 +1,6 @@
 package log
 
 import (
-	"database/sql"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
@@ -11,10 +10,7 @@ import (
 // Insert used for logging the data and inserting the log into bot_logs table
 func Insert(text string, userID int64, status uint8, tx *sqlx.Tx) (int64, error) {
 
-	var result sql.Result
-	var err error
-
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			bot_logs(
 				message,
@@ -22,18 +18,13 @@ func Insert(text string, userID int64, status uint8, tx *sqlx.Tx) (int64, error)
 				status,
 				created_at
 			) VALUES (
-				('%s'),
-				(%d),
-				(%d),
+				(?),
+				(?),
+				(?),
 				NOW()
-			);`, text, userID, status)
-
-	if tx != nil {
-		result, err = conn.DB.Exec(query)
-	} else {
-		result, err = tx.Exec(query)
-	}
+			);`
 
+	result, err := conn.TxExec(tx, query, text, userID, status)
 	if err != nil {
 		return 0, err
 	}
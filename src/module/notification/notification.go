@@ -2,8 +2,8 @@ package notification
 
 import (
 	"database/sql"
-	"fmt"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/melodiez14/meiko/src/util/conn"
 )
 
@@ -12,8 +12,7 @@ func Get(userID int64, page uint16, limit uint8) ([]Notification, error) {
 
 	startRow := uint32(page-1) * uint32(limit)
 
-	query := fmt.Sprintf(queryGet, userID, startRow, limit)
-	err := conn.DB.Select(&notifications, query)
+	err := conn.Select(&notifications, queryGet, userID, startRow, limit)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, err
 	}
@@ -24,3 +23,19 @@ func Get(userID int64, page uint16, limit uint8) ([]Notification, error) {
 func (n Notification) GetURL() string {
 	return "http://URL.com"
 }
+
+// SelectByUserID returns all notifications of the user
+func SelectByUserID(userID int64) ([]Notification, error) {
+	var notifications []Notification
+	err := conn.Select(&notifications, querySelectByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// DeleteByUserID removes all notifications of the user
+func DeleteByUserID(userID int64, tx *sqlx.Tx) error {
+	_, err := conn.TxExec(tx, queryDeleteByUserID, userID)
+	return err
+}
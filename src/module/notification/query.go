@@ -12,8 +12,32 @@ const queryGet = `
 	FROM
 		notifications
 	WHERE
-		users_id = (%d)
+		users_id = (?)
 	ORDER BY
 		created_at DESC
-	LIMIT %d, %d
+	LIMIT ?, ?
+`
+
+const querySelectByUserID = `
+	SELECT
+		id,
+		name,
+		descriptions,
+		read_at,
+		table_id,
+		table_name,
+		created_at
+	FROM
+		notifications
+	WHERE
+		users_id = (?)
+	ORDER BY
+		created_at ASC
+`
+
+const queryDeleteByUserID = `
+	DELETE FROM
+		notifications
+	WHERE
+		users_id = (?)
 `
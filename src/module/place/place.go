@@ -6,12 +6,13 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
 )
 
 func Search(id string) ([]string, error) {
 	places := []string{}
-	query := fmt.Sprintf("SELECT id FROM places WHERE id LIKE ('%%%s%%')", id)
-	err := conn.DB.Select(&places, query)
+	query := "SELECT id FROM places WHERE id LIKE (?)"
+	err := conn.Select(&places, query, "%"+helper.EscapeLike(id)+"%")
 	if err != nil {
 		return places, err
 	}
@@ -20,8 +21,8 @@ func Search(id string) ([]string, error) {
 
 func IsExistID(id string) bool {
 	var place string
-	query := fmt.Sprintf("SELECT id FROM places WHERE id = ('%s') LIMIT 1", id)
-	err := conn.DB.Get(&place, query)
+	query := "SELECT id FROM places WHERE id = (?) LIMIT 1"
+	err := conn.Get(&place, query, id)
 	if err != nil {
 		return false
 	}
@@ -30,31 +31,24 @@ func IsExistID(id string) bool {
 
 func Insert(id string, description sql.NullString, tx ...*sqlx.Tx) error {
 
-	queryDescription := "(NULL)"
-	if description.Valid {
-		queryDescription = fmt.Sprintf("('%s')", description.String)
-	}
-
-	query := fmt.Sprintf(`INSERT INTO
+	query := `INSERT INTO
 		places (
 			id,
 			description,
 			created_at,
 			updated_at
 		) VALUES (
-			('%s'),
-			%s,
+			(?),
+			(?),
 			NOW(),
 			NOW()
-		)`, id, queryDescription)
+		)`
 
-	var result sql.Result
-	var err error
+	var t *sqlx.Tx
 	if len(tx) == 1 {
-		result, err = tx[0].Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
+		t = tx[0]
 	}
+	result, err := conn.TxExec(t, query, id, description)
 	if err != nil {
 		return err
 	}
@@ -13,6 +13,7 @@ const (
 	ModuleAssignment  = "assignments"
 	ModuleInformation = "informations"
 	ModuleTutorial    = "tutorials"
+	ModuleAudit       = "audits"
 
 	RoleCreate  = "CREATE"
 	RoleRead    = "READ"
@@ -25,9 +26,10 @@ const (
 )
 
 type RoleGroup struct {
-	ID        int64     `db:"id"`
-	Name      string    `db:"name"`
-	CreatedAt time.Time `db:"updated_at"`
+	ID                  int64     `db:"id"`
+	Name                string    `db:"name"`
+	IsTwoFactorRequired bool      `db:"is_2fa_required"`
+	CreatedAt           time.Time `db:"updated_at"`
 }
 
 type Privilege struct {
@@ -1,7 +1,6 @@
 package rolegroup
 
 import (
-	"database/sql"
 	"fmt"
 	"log"
 	"strings"
@@ -9,22 +8,24 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
 )
 
 // GetByID ...
 func GetByID(id int64) (RoleGroup, error) {
 	var rolegroup RoleGroup
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
-			name
+			name,
+			is_2fa_required
 		FROM
 			rolegroups
 		WHERE
-			id = (%d)
+			id = (?)
 		LIMIT 1;
-	`, id)
-	err := conn.DB.Get(&rolegroup, query)
+	`
+	err := conn.Get(&rolegroup, query, id)
 	if err != nil {
 		return rolegroup, err
 	}
@@ -36,17 +37,17 @@ func GetByID(id int64) (RoleGroup, error) {
 func SelectByPage(limit, offset int, isCount bool) ([]RoleGroup, int, error) {
 	rolegroups := []RoleGroup{}
 	var count int
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			name,
 			updated_at
 		FROM
 			rolegroups
-		LIMIT %d
-		OFFSET %d;
-	`, limit, offset)
-	err := conn.DB.Select(&rolegroups, query)
+		LIMIT ?
+		OFFSET ?;
+	`
+	err := conn.Select(&rolegroups, query, limit, offset)
 	if err != nil {
 		return rolegroups, count, err
 	}
@@ -61,7 +62,7 @@ func SelectByPage(limit, offset int, isCount bool) ([]RoleGroup, int, error) {
 		FROM
 			rolegroups;
 	`
-	err = conn.DB.Get(&count, query)
+	err = conn.Get(&count, query)
 	if err != nil {
 		return rolegroups, count, err
 	}
@@ -70,24 +71,20 @@ func SelectByPage(limit, offset int, isCount bool) ([]RoleGroup, int, error) {
 }
 
 // Update ...
-func Update(id int64, name string, tx *sqlx.Tx) error {
+func Update(id int64, name string, isTwoFactorRequired bool, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			rolegroups
 		SET
-			name = ('%s'),
+			name = (?),
+			is_2fa_required = (?),
 			updated_at = NOW()
 		WHERE
-			id = (%d)	
-	`, name, id)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+			id = (?)	
+	`
+
+	_, err := conn.TxExec(tx, query, name, isTwoFactorRequired, id)
 	if err != nil {
 		return err
 	}
@@ -106,6 +103,7 @@ func GetModuleList() []string {
 		ModuleAssignment,
 		ModuleInformation,
 		ModuleTutorial,
+		ModuleAudit,
 	}
 }
 
@@ -130,16 +128,20 @@ func SelectModuleAccess(id int64) (map[string][]string, error) {
 	var ability string
 
 	privilege := make(map[string][]string)
-	query := fmt.Sprintf(`
+	query, args, err := conn.In(`
 		SELECT
 			module,
 			ability
 		FROM
 			rolegroups_modules
 		WHERE
-			rolegroups_id = (%d)
+			rolegroups_id = (?)
 	`, id)
-	rows, err := conn.DB.Query(query)
+	if err != nil {
+		return privilege, err
+	}
+
+	rows, err := conn.DB.Query(query, args...)
 	if err != nil {
 		return privilege, err
 	}
@@ -154,21 +156,44 @@ func SelectModuleAccess(id int64) (map[string][]string, error) {
 	return privilege, nil
 }
 
+// IsTwoFactorRequired returns whether users of the rolegroup have to sign in with two factor authentication
+func IsTwoFactorRequired(id int64) bool {
+
+	var x string
+	query := `
+		SELECT
+			'x'
+		FROM
+			rolegroups
+		WHERE
+			id = (?) AND
+			is_2fa_required = 1
+		LIMIT 1;
+	`
+
+	err := conn.Get(&x, query, id)
+	if err != nil {
+		return false
+	}
+
+	return true
+}
+
 // IsExistName ...
 func IsExistName(name string) bool {
 
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			rolegroups
 		WHERE
-			name = ('%s')
+			name = (?)
 		LIMIT 1;
-	`, name)
+	`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, name)
 	if err != nil {
 		return false
 	}
@@ -179,17 +204,17 @@ func IsExistName(name string) bool {
 // IsExist ...
 func IsExist(rolegroupID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			rolegroups
 		WHERE
-			id = (%d)
+			id = (?)
 		LIMIT 1;
-	`, rolegroupID)
+	`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, rolegroupID)
 	if err != nil {
 		return false
 	}
@@ -198,29 +223,25 @@ func IsExist(rolegroupID int64) bool {
 }
 
 // Insert ...
-func Insert(name string, tx *sqlx.Tx) (int64, error) {
+func Insert(name string, isTwoFactorRequired bool, tx *sqlx.Tx) (int64, error) {
 
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			rolegroups (
 				name,
+				is_2fa_required,
 				created_at,
 				updated_at
 			)
 			VALUES (
-				('%s'),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-	`, name)
-
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	`
+
+	result, err := conn.TxExec(tx, query, name, isTwoFactorRequired)
 	if err != nil {
 		return 0, err
 	}
@@ -237,9 +258,11 @@ func Insert(name string, tx *sqlx.Tx) (int64, error) {
 func InsertModuleAccess(rolegroupsID int64, privileges map[string][]string, tx *sqlx.Tx) error {
 
 	var value []string
+	var args []interface{}
 	for module, abilities := range privileges {
 		for _, ability := range abilities {
-			value = append(value, fmt.Sprintf("(%d, '%s', '%s', NOW(), NOW())", rolegroupsID, module, ability))
+			value = append(value, "(?, ?, ?, NOW(), NOW())")
+			args = append(args, rolegroupsID, module, ability)
 		}
 	}
 
@@ -256,12 +279,7 @@ func InsertModuleAccess(rolegroupsID int64, privileges map[string][]string, tx *
 			VALUES %s;
 	`, queryValue)
 
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+	_, err := conn.TxExec(tx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -271,18 +289,13 @@ func InsertModuleAccess(rolegroupsID int64, privileges map[string][]string, tx *
 
 func DeleteModuleAccess(rolegroupID int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			rolegroups_modules
-		WHERE rolegroups_id = (%d);
-	`, rolegroupID)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+		WHERE rolegroups_id = (?);
+	`
+
+	_, err := conn.TxExec(tx, query, rolegroupID)
 	if err != nil {
 		return err
 	}
@@ -292,18 +305,13 @@ func DeleteModuleAccess(rolegroupID int64, tx *sqlx.Tx) error {
 
 func Delete(rolegroupID int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			rolegroups
-		WHERE id = (%d);
-	`, rolegroupID)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+		WHERE id = (?);
+	`
+
+	_, err := conn.TxExec(tx, query, rolegroupID)
 	if err != nil {
 		return err
 	}
@@ -314,8 +322,8 @@ func Delete(rolegroupID int64, tx *sqlx.Tx) error {
 // Search ..
 func Search(name string) ([]RoleGroup, error) {
 	roles := []RoleGroup{}
-	query := fmt.Sprintf("SELECT id, name FROM rolegroups WHERE name LIKE ('%%%s%%')", name)
-	err := conn.DB.Select(&roles, query)
+	query := "SELECT id, name FROM rolegroups WHERE name LIKE (?)"
+	err := conn.Select(&roles, query, "%"+helper.EscapeLike(name)+"%")
 	if err != nil {
 		return roles, err
 	}
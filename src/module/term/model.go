@@ -0,0 +1,26 @@
+package term
+
+import (
+	"time"
+)
+
+const (
+	StatusUpcoming = 0
+	StatusActive   = 1
+	StatusClosed   = 2
+)
+
+// Term is an academic term, the schedules of the semester and year belong to it.
+// The dates are inclusive, registration opens the enrollment and grading opens the scoring
+type Term struct {
+	ID                    int64     `db:"id"`
+	Semester              int8      `db:"semester"`
+	Year                  int16     `db:"year"`
+	Status                int8      `db:"status"`
+	StartDate             time.Time `db:"start_date"`
+	EndDate               time.Time `db:"end_date"`
+	RegistrationStartDate time.Time `db:"registration_start_date"`
+	RegistrationEndDate   time.Time `db:"registration_end_date"`
+	GradingStartDate      time.Time `db:"grading_start_date"`
+	GradingEndDate        time.Time `db:"grading_end_date"`
+}
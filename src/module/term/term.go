@@ -0,0 +1,243 @@
+package term
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// IsRegistrationOpen reports whether the enrollment is allowed at the time
+func (t Term) IsRegistrationOpen(at time.Time) bool {
+	return isBetween(at, t.RegistrationStartDate, t.RegistrationEndDate)
+}
+
+// IsGradingOpen reports whether the scoring is allowed at the time
+func (t Term) IsGradingOpen(at time.Time) bool {
+	return isBetween(at, t.GradingStartDate, t.GradingEndDate)
+}
+
+// isBetween reports whether the time is in the dates, the whole end date is included
+func isBetween(at, start, end time.Time) bool {
+	return !at.Before(start) && at.Before(end.AddDate(0, 0, 1))
+}
+
+// Insert saves a new term
+/*
+	@params:
+		t	= Term, the ID and the Status are ignored, a new term is upcoming
+	@example:
+		t	= {Semester: 1, Year: 2018, StartDate: 2018-08-27, EndDate: 2018-12-21, ...}
+	@return
+		id	= 1
+*/
+func Insert(t Term, tx ...*sqlx.Tx) (int64, error) {
+	query := `
+		INSERT INTO
+			terms (
+				semester,
+				year,
+				status,
+				start_date,
+				end_date,
+				registration_start_date,
+				registration_end_date,
+				grading_start_date,
+				grading_end_date,
+				created_at,
+				updated_at
+			) VALUES (
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				(?),
+				NOW(),
+				NOW()
+			);
+		`
+
+	var tt *sqlx.Tx
+	if len(tx) == 1 {
+		tt = tx[0]
+	}
+	result, err := conn.TxExec(tt, query, t.Semester, t.Year, StatusUpcoming, t.StartDate, t.EndDate,
+		t.RegistrationStartDate, t.RegistrationEndDate, t.GradingStartDate, t.GradingEndDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// Update changes the dates of the term, the semester, the year and the status are kept
+/*
+	@params:
+		term	= Term
+	@example:
+		term	= {ID: 1, StartDate: 2018-08-27, EndDate: 2018-12-21, ...}
+	@return
+*/
+func Update(term Term, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			terms
+		SET
+			start_date = (?),
+			end_date = (?),
+			registration_start_date = (?),
+			registration_end_date = (?),
+			grading_start_date = (?),
+			grading_end_date = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, term.StartDate, term.EndDate, term.RegistrationStartDate,
+		term.RegistrationEndDate, term.GradingStartDate, term.GradingEndDate, term.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// UpdateStatus changes the lifecycle status of the term
+/*
+	@params:
+		id		= int64
+		status	= int8
+	@example:
+		id		= 1
+		status	= 2
+	@return
+*/
+func UpdateStatus(id int64, status int8, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			terms
+		SET
+			status = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, status, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+const queryGet = `
+	SELECT
+		id,
+		semester,
+		year,
+		status,
+		start_date,
+		end_date,
+		registration_start_date,
+		registration_end_date,
+		grading_start_date,
+		grading_end_date
+	FROM
+		terms
+	`
+
+// Get returns the term by its ID
+func Get(id int64) (Term, error) {
+	var t Term
+	query := queryGet + `
+		WHERE
+			id = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&t, query, id)
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// GetBySemester returns the term of the semester and the year
+/*
+	@params:
+		semester	= int8
+		year		= int16
+	@example:
+		semester	= 1
+		year		= 2018
+	@return
+		Term
+*/
+func GetBySemester(semester int8, year int16) (Term, error) {
+	var t Term
+	query := queryGet + `
+		WHERE
+			semester = (?) AND
+			year = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&t, query, semester, year)
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// SelectAll returns all terms, the latest is the first
+func SelectAll() ([]Term, error) {
+	var terms []Term
+	query := queryGet + `
+		ORDER BY
+			year DESC,
+			semester DESC;
+		`
+	err := conn.Select(&terms, query)
+	if err != nil {
+		return terms, err
+	}
+	return terms, nil
+}
+
+// IsExist check whether the term of the semester and the year exists
+func IsExist(semester int8, year int16) bool {
+	var x string
+	query := `
+		SELECT
+			'x'
+		FROM
+			terms
+		WHERE
+			semester = (?) AND
+			year = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&x, query, semester, year)
+	if err != nil {
+		return false
+	}
+	return true
+}
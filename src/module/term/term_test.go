@@ -0,0 +1,94 @@
+package term
+
+import (
+	"testing"
+	"time"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func date(value string) time.Time {
+	t, _ := time.ParseInLocation("2006-01-02 15:04", value, time.Local)
+	return t
+}
+
+func TestTerm_IsRegistrationOpen(t *testing.T) {
+	term := Term{
+		RegistrationStartDate: date("2018-08-13 00:00"),
+		RegistrationEndDate:   date("2018-09-07 00:00"),
+		GradingStartDate:      date("2018-12-03 00:00"),
+		GradingEndDate:        date("2019-01-11 00:00"),
+	}
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{name: "Before registration", at: date("2018-08-12 23:59"), want: false},
+		{name: "First day", at: date("2018-08-13 00:00"), want: true},
+		{name: "Last day", at: date("2018-09-07 23:59"), want: true},
+		{name: "After registration", at: date("2018-09-08 00:00"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := term.IsRegistrationOpen(tt.at); got != tt.want {
+				t.Errorf("Term.IsRegistrationOpen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if term.IsGradingOpen(date("2018-09-07 10:00")) {
+		t.Errorf("Term.IsGradingOpen() = true, want false")
+	}
+	if !term.IsGradingOpen(date("2019-01-11 10:00")) {
+		t.Errorf("Term.IsGradingOpen() = false, want true")
+	}
+}
+
+func TestUpdateStatus(t *testing.T) {
+	query := `^\s*UPDATE\s*terms\s*SET\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\);$`
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{
+			name:         "Test Case 1",
+			rowsAffected: 1,
+		},
+		{
+			name:         "Test Case 2",
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		db.ExpectExec(query).WithArgs(StatusClosed, 1).WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdateStatus(1, StatusClosed); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInsert(t *testing.T) {
+	query := `^\s*INSERT\s*INTO\s*terms\s*\(.*\)\s*VALUES\s*\(.*\);$`
+
+	db, _ := conn.InitDBMock()
+	db.ExpectBegin()
+	db.ExpectExec(query).
+		WithArgs(1, 2018, StatusUpcoming, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	tx := conn.DB.MustBegin()
+	id, err := Insert(Term{Semester: 1, Year: 2018}, tx)
+	if err != nil || id != 7 {
+		t.Errorf("Insert() = %v, %v, want 7", id, err)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("Insert() %s", err.Error())
+	}
+}
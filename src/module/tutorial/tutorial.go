@@ -13,7 +13,7 @@ import (
 func SelectByPage(scheduleID int64, limit, offset int, isCount bool) ([]Tutorial, int, error) {
 	var tutorials []Tutorial
 	var count int
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			name,
@@ -22,11 +22,11 @@ func SelectByPage(scheduleID int64, limit, offset int, isCount bool) ([]Tutorial
 		FROM
 			tutorials
 		WHERE
-			schedules_id = (%d)
-		LIMIT %d
-		OFFSET %d;
-		`, scheduleID, limit, offset)
-	err := conn.DB.Select(&tutorials, query)
+			schedules_id = (?)
+		LIMIT ?
+		OFFSET ?;
+		`
+	err := conn.Select(&tutorials, query, scheduleID, limit, offset)
 	if err != nil {
 		return tutorials, count, err
 	}
@@ -35,15 +35,15 @@ func SelectByPage(scheduleID int64, limit, offset int, isCount bool) ([]Tutorial
 		return tutorials, count, err
 	}
 
-	query = fmt.Sprintf(`
+	query = `
 		SELECT
 			COUNT(*)
 		FROM
 			tutorials
 		WHERE
-			schedules_id = (%d);
-		`, scheduleID)
-	err = conn.DB.Get(&count, query)
+			schedules_id = (?);
+		`
+	err = conn.Get(&count, query, scheduleID)
 	if err != nil {
 		return tutorials, count, err
 	}
@@ -53,7 +53,7 @@ func SelectByPage(scheduleID int64, limit, offset int, isCount bool) ([]Tutorial
 // GetByID ...
 func GetByID(id int64) (Tutorial, error) {
 	var tutorial Tutorial
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			name,
@@ -63,11 +63,11 @@ func GetByID(id int64) (Tutorial, error) {
 		FROM
 			tutorials
 		WHERE
-			id = (%d)
+			id = (?)
 		LIMIT 1;	
-	`, id)
+	`
 
-	err := conn.DB.Get(&tutorial, query)
+	err := conn.Get(&tutorial, query, id)
 	if err != nil {
 		return tutorial, err
 	}
@@ -77,9 +77,12 @@ func GetByID(id int64) (Tutorial, error) {
 // IsExistName ...
 func IsExistName(name string, scheduleID int64, currentID ...int64) bool {
 	var queryID string
+	args := []interface{}{}
 	if len(currentID) == 1 {
-		queryID = fmt.Sprintf("id != (%d) AND ", currentID[0])
+		queryID = "id != (?) AND "
+		args = append(args, currentID[0])
 	}
+	args = append(args, name, scheduleID)
 
 	var x string
 	query := fmt.Sprintf(`
@@ -89,12 +92,12 @@ func IsExistName(name string, scheduleID int64, currentID ...int64) bool {
 			tutorials
 		WHERE
 			%s
-			name = ('%s') AND
-			schedules_id = (%d)
+			name = (?) AND
+			schedules_id = (?)
 		LIMIT 1;	
-	`, queryID, name, scheduleID)
+	`, queryID)
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, args...)
 	if err != nil {
 		return false
 	}
@@ -104,12 +107,7 @@ func IsExistName(name string, scheduleID int64, currentID ...int64) bool {
 // Insert ...
 func Insert(name string, description sql.NullString, scheduleID int64, tx *sqlx.Tx) (int64, error) {
 
-	desc := "(NULL)"
-	if description.Valid {
-		desc = fmt.Sprintf("('%s')", description.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		INSERT INTO
 			tutorials (
 				name,
@@ -118,21 +116,15 @@ func Insert(name string, description sql.NullString, scheduleID int64, tx *sqlx.
 				created_at,
 				updated_at
 			) VALUES (
-				('%s'),
-				%s,
-				(%d),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
-	`, name, desc, scheduleID)
-
-	var result sql.Result
-	var err error
-	if tx != nil {
-		result, err = tx.Exec(query)
-	} else {
-		result, err = conn.DB.Exec(query)
-	}
+	`
+
+	result, err := conn.TxExec(tx, query, name, description, scheduleID)
 	if err != nil {
 		return 0, err
 	}
@@ -148,17 +140,17 @@ func Insert(name string, description sql.NullString, scheduleID int64, tx *sqlx.
 // IsExistID ...
 func IsExistID(id int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			tutorials
 		WHERE
-			id = ('%d')
+			id = (?)
 		LIMIT 1;	
-	`, id)
+	`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, id)
 	if err != nil {
 		return false
 	}
@@ -167,20 +159,14 @@ func IsExistID(id int64) bool {
 
 func Delete(id int64, tx *sqlx.Tx) error {
 
-	query := fmt.Sprintf(`
+	query := `
 		DELETE FROM
 			tutorials
 		WHERE
-			id = (%d);
-	`, id)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+			id = (?);
+	`
 
+	_, err := conn.TxExec(tx, query, id)
 	if err != nil {
 		return err
 	}
@@ -190,31 +176,53 @@ func Delete(id int64, tx *sqlx.Tx) error {
 
 func Update(id int64, name string, description sql.NullString, tx *sqlx.Tx) error {
 
-	desc := "(NULL)"
-	if description.Valid {
-		desc = fmt.Sprintf("('%s')", description.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			tutorials
 		SET 
-			name = ('%s'),
-			description = %s,
+			name = (?),
+			description = (?),
 			updated_at = NOW()
 		WHERE
-			id = (%d)
-	`, name, desc, id)
-
-	var err error
-	if tx != nil {
-		_, err = tx.Exec(query)
-	} else {
-		_, err = conn.DB.Exec(query)
-	}
+			id = (?)
+	`
+
+	_, err := conn.TxExec(tx, query, name, description, id)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// SelectByScheduleID returns all tutorials of the schedule, the earliest is the first
+/*
+	@params:
+		scheduleID	= int64
+	@example:
+		scheduleID	= 100192
+	@return
+		[]Tutorial
+*/
+func SelectByScheduleID(scheduleID int64) ([]Tutorial, error) {
+	var tutorials []Tutorial
+	query := `
+		SELECT
+			id,
+			name,
+			description,
+			schedules_id,
+			created_at
+		FROM
+			tutorials
+		WHERE
+			schedules_id = (?)
+		ORDER BY
+			id ASC;
+		`
+	err := conn.Select(&tutorials, query, scheduleID)
+	if err != nil {
+		return tutorials, err
+	}
+	return tutorials, nil
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// UpdateCalendarToken function to replace the hash of the calendar feed token, an invalid hash revokes the feed
+/*
+	@params:
+		id		= int64
+		hash	= sql.NullString
+	@example:
+		id		= 12
+		hash	= 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	@return
+*/
+func UpdateCalendarToken(id int64, hash sql.NullString) error {
+	query := `
+		UPDATE
+			users
+		SET
+			calendar_token = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+	result, err := conn.Exec(query, hash, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// GetIDByCalendarToken returns the active user who owns the calendar feed token
+/*
+	@params:
+		hash	= string
+	@example:
+		hash	= 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	@return
+		id		= 12
+*/
+func GetIDByCalendarToken(hash string) (int64, error) {
+	var id int64
+	query := `
+		SELECT
+			id
+		FROM
+			users
+		WHERE
+			calendar_token = (?) AND
+			status = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&id, query, hash, StatusActivated)
+	if err != nil {
+		return id, err
+	}
+	return id, nil
+}
@@ -0,0 +1,165 @@
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+// EmailChangeAttemptMax is the number of wrong codes before the email change is refused
+const EmailChangeAttemptMax = 3
+
+// GenerateEmailChange function to save the new email of user until it is confirmed by the code sent to it.
+// The previous unconfirmed email change is replaced
+/*
+	@params:
+		id		= int64
+		email	= string
+	@example:
+		id		= 140810140060
+		email	= risal.falah@gmail.com
+	@return
+		Verification
+*/
+func GenerateEmailChange(id int64, email string) (Verification, error) {
+
+	code, err := helper.GenerateVerificationCode()
+	if err != nil {
+		return Verification{}, err
+	}
+	v := Verification{
+		Code:           code,
+		ExpireDuration: "30 Minutes",
+		ExpireDate:     time.Now().Add(30 * time.Minute),
+		Attempt:        0,
+	}
+
+	query := `
+		UPDATE
+			users
+		SET
+			email_change = (?),
+			email_change_code = (?),
+			email_change_expire_date = (DATE_ADD(NOW(), INTERVAL 30 MINUTE)),
+			email_change_attempt = 0,
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+	result, err := conn.Exec(query, email, v.Code, id)
+	if err != nil {
+		return v, err
+	}
+	count, _ := result.RowsAffected()
+	if count < 1 {
+		return v, fmt.Errorf("Error executing query")
+	}
+
+	return v, nil
+}
+
+// GetEmailChange function to get the unexpired email change of user
+/*
+	@params:
+		id	= int64
+	@example:
+		id	= 140810140060
+	@return
+		EmailChange
+*/
+func GetEmailChange(id int64) (EmailChange, error) {
+	var c EmailChange
+	query := `
+		SELECT
+			id,
+			email_change,
+			email_change_code,
+			email_change_attempt
+		FROM
+			users
+		WHERE
+			id = (?) AND
+			email_change IS NOT NULL AND
+			NOW() < email_change_expire_date
+		LIMIT 1;
+		`
+	err := conn.Get(&c, query, id)
+	if err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// IsValidEmailChangeCode function to check the code of the email change, a wrong code is counted as an attempt
+/*
+	@params:
+		c		= EmailChange
+		code	= uint16
+	@example:
+		code	= 1408
+	@return
+*/
+func IsValidEmailChangeCode(c EmailChange, code uint16) bool {
+	if !c.Attempt.Valid || c.Attempt.Int64 >= EmailChangeAttemptMax {
+		return false
+	}
+
+	if !c.Code.Valid || c.Code.Int64 != int64(code) {
+		query := `
+			UPDATE
+				users
+			SET
+				email_change_attempt = email_change_attempt + 1,
+				updated_at = NOW()
+			WHERE
+				id = (?);
+			`
+		_, _ = conn.Exec(query, c.ID)
+		return false
+	}
+
+	return true
+}
+
+// UpdateEmail function to change the email of user into the confirmed email change
+/*
+	@params:
+		id		= int64
+		email	= string
+	@example:
+		id		= 140810140060
+		email	= risal.falah@gmail.com
+	@return
+*/
+func UpdateEmail(id int64, email string, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			email = (?),
+			email_change = NULL,
+			email_change_code = NULL,
+			email_change_expire_date = NULL,
+			email_change_attempt = NULL,
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, email, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestIsValidEmailChangeCode(t *testing.T) {
+	query := `^\s*UPDATE\s*users\s*SET\s*email_change_attempt\s*=\s*email_change_attempt\s*\+\s*1,\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\);$`
+	tests := []struct {
+		name        string
+		change      EmailChange
+		code        uint16
+		isIncrement bool
+		want        bool
+	}{
+		{
+			name: "Valid Code",
+			change: EmailChange{
+				ID:      1,
+				Email:   sql.NullString{String: "risal.falah@gmail.com", Valid: true},
+				Code:    sql.NullInt64{Int64: 1408, Valid: true},
+				Attempt: sql.NullInt64{Int64: 2, Valid: true},
+			},
+			code: 1408,
+			want: true,
+		},
+		{
+			name: "Wrong Code",
+			change: EmailChange{
+				ID:      1,
+				Email:   sql.NullString{String: "risal.falah@gmail.com", Valid: true},
+				Code:    sql.NullInt64{Int64: 1408, Valid: true},
+				Attempt: sql.NullInt64{Int64: 0, Valid: true},
+			},
+			code:        1409,
+			isIncrement: true,
+			want:        false,
+		},
+		{
+			name: "Too Many Attempts",
+			change: EmailChange{
+				ID:      1,
+				Email:   sql.NullString{String: "risal.falah@gmail.com", Valid: true},
+				Code:    sql.NullInt64{Int64: 1408, Valid: true},
+				Attempt: sql.NullInt64{Int64: EmailChangeAttemptMax, Valid: true},
+			},
+			code: 1408,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		if tt.isIncrement {
+			db.ExpectExec(query).WithArgs(tt.change.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidEmailChangeCode(tt.change, tt.code); got != tt.want {
+				t.Errorf("IsValidEmailChangeCode() = %v, want %v", got, tt.want)
+			}
+			if err := db.ExpectationsWereMet(); err != nil {
+				t.Errorf("IsValidEmailChangeCode() %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestUpdateEmail(t *testing.T) {
+	query := `^\s*UPDATE\s*users\s*SET\s*email\s*=\s*\(\?\),\s*email_change\s*=\s*NULL,\s*email_change_code\s*=\s*NULL,\s*email_change_expire_date\s*=\s*NULL,\s*email_change_attempt\s*=\s*NULL,\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\);$`
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		err          error
+		wantErr      bool
+	}{
+		{
+			name:         "Test Case 1",
+			rowsAffected: 1,
+		},
+		{
+			name:         "Test Case 2",
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:    "Test Case 3",
+			err:     fmt.Errorf("Error connection"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(query).WithArgs("risal.falah@gmail.com", 1)
+		if tt.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+		} else {
+			q.WillReturnError(tt.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdateEmail(1, "risal.falah@gmail.com"); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateEmail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
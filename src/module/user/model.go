@@ -3,6 +3,8 @@ package user
 import (
 	"database/sql"
 	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 // const const for specify variable in user
@@ -32,17 +34,21 @@ import (
 	@return
 */
 const (
-	ColID           = "id"
-	ColName         = "name"
-	ColEmail        = "email"
-	ColGender       = "gender"
-	ColNote         = "note"
-	ColStatus       = "status"
-	ColIdentityCode = "identity_code"
-	ColLineID       = "line_id"
-	ColPhone        = "phone"
-	ColRoleGroupsID = "rolegroups_id"
-	ColPassword     = "password"
+	ColID            = "id"
+	ColName          = "name"
+	ColEmail         = "email"
+	ColGender        = "gender"
+	ColNote          = "note"
+	ColStatus        = "status"
+	ColIdentityCode  = "identity_code"
+	ColLineID        = "line_id"
+	ColPhone         = "phone"
+	ColRoleGroupsID  = "rolegroups_id"
+	ColPassword      = "password"
+	ColProgram       = "program"
+	ColCohort        = "cohort"
+	ColAdvisorID     = "advisor_id"
+	ColPhoneVerified = "phone_verified"
 
 	StatusUnverified = 0
 	StatusVerified   = 1
@@ -52,6 +58,9 @@ const (
 	GenderMale      = 1
 	GenderFemale    = 2
 
+	// AnonymousName replaces the name of the erased user
+	AnonymousName = "Anonymous"
+
 	OperatorEquals  = "="
 	OperatorUnquals = "!="
 	OperatorIn      = "IN"
@@ -83,6 +92,10 @@ type (
 		LineID			= sql.string
 		Phone			= sql.string
 		RoleGroupsID	= sql.int64
+		Program			= sql.string
+		Cohort			= sql.int64
+		AdvisorID		= sql.int64
+		PhoneVerified	= bool
 	@example:
 		ID				= 140810140060
 		Name			= kharil azmi ashari
@@ -94,19 +107,74 @@ type (
 		LineID			= khaazas
 		Phone			= 082214467300
 		RoleGroupsID	= 0
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		AdvisorID		= 2000000001
+		PhoneVerified	= true
 	@return
 */
 type User struct {
-	ID           int64          `db:"id"`
-	Name         string         `db:"name"`
-	Email        string         `db:"email"`
-	Gender       int8           `db:"gender"`
-	Note         string         `db:"note"`
-	Status       int8           `db:"status"`
-	IdentityCode int64          `db:"identity_code"`
-	LineID       sql.NullString `db:"line_id"`
-	Phone        sql.NullString `db:"phone"`
-	RoleGroupsID sql.NullInt64  `db:"rolegroups_id"`
+	ID            int64          `db:"id"`
+	Name          string         `db:"name"`
+	Email         string         `db:"email"`
+	Gender        int8           `db:"gender"`
+	Note          string         `db:"note"`
+	Status        int8           `db:"status"`
+	IdentityCode  int64          `db:"identity_code"`
+	LineID        sql.NullString `db:"line_id"`
+	Phone         sql.NullString `db:"phone"`
+	RoleGroupsID  sql.NullInt64  `db:"rolegroups_id"`
+	Program       sql.NullString `db:"program"`
+	Cohort        sql.NullInt64  `db:"cohort"`
+	AdvisorID     sql.NullInt64  `db:"advisor_id"`
+	PhoneVerified bool           `db:"phone_verified"`
+}
+
+// DashboardFilter narrows down the users of the dashboard, zero value fields are ignored
+type DashboardFilter struct {
+	Program   string
+	Cohort    uint16
+	AdvisorID int64
+}
+
+// SearchFilter narrows down the users of the search, zero value fields are ignored
+/*
+	@params:
+		Text		= string
+		Status		= sql.int64
+		Gender		= sql.int64
+		RolegroupID	= int64
+		ScheduleID	= int64
+	@example:
+		Text		= risal
+		Status		= 2
+		Gender		= 1
+		RolegroupID	= 2
+		ScheduleID	= 12
+	@return
+*/
+type SearchFilter struct {
+	Text        string
+	Status      sql.NullInt64
+	Gender      sql.NullInt64
+	RolegroupID int64
+	ScheduleID  int64
+}
+
+// SearchCursor is the position of the last user of the previous page,
+// zero ID means the first page
+/*
+	@params:
+		Value	= string
+		ID		= int64
+	@example:
+		Value	= risal falah
+		ID		= 2000000001
+	@return
+*/
+type SearchCursor struct {
+	Value string
+	ID    int64
 }
 
 // Verification struct for verify account confirmation through email
@@ -148,9 +216,67 @@ type Confirmation struct {
 	Attempt sql.NullInt64 `db:"email_verification_attempt"`
 }
 
+// EmailChange struct for the new email which is waiting for the confirmation of its owner
+/*
+	@params:
+		ID		= int64
+		Email	= sql.string
+		Code	= sql.int64
+		Attempt	= sql.int64
+	@example:
+		ID		= 140810140060
+		Email	= risal.falah@gmail.com
+		Code	= 1408
+		Attempt	= 0
+	@return
+*/
+type EmailChange struct {
+	ID      int64          `db:"id"`
+	Email   sql.NullString `db:"email_change"`
+	Code    sql.NullInt64  `db:"email_change_code"`
+	Attempt sql.NullInt64  `db:"email_change_attempt"`
+}
+
+// PhoneVerification struct for the code sent to the phone of user by SMS
+/*
+	@params:
+		ID		= int64
+		Phone	= sql.string
+		Code	= sql.int64
+		Attempt	= sql.int64
+	@example:
+		ID		= 140810140060
+		Phone	= 085860141146
+		Code	= 1408
+		Attempt	= 0
+	@return
+*/
+type PhoneVerification struct {
+	ID      int64          `db:"id"`
+	Phone   sql.NullString `db:"phone"`
+	Code    sql.NullInt64  `db:"phone_verification_code"`
+	Attempt sql.NullInt64  `db:"phone_verification_attempt"`
+}
+
 // ConciseUsers ..
 type ConciseUsers struct {
 	ID           int64  `db:"id"`
 	Name         string `db:"name"`
 	IdentityCode int64  `db:"identity_code"`
 }
+
+// TwoFactor struct for save TOTP two factor authentication of user.
+// Secret without EnabledAt means the enrolment has not been confirmed yet
+/*
+	@params:
+		Secret		= sql.string
+		EnabledAt	= mysql.NullTime
+	@example:
+		Secret		= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+		EnabledAt	= 2017-12-21 08:10:00
+	@return
+*/
+type TwoFactor struct {
+	Secret    sql.NullString `db:"totp_secret"`
+	EnabledAt mysql.NullTime `db:"totp_enabled_at"`
+}
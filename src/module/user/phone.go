@@ -0,0 +1,182 @@
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+// PhoneVerificationAttemptMax is the number of wrong codes before the phone verification is refused
+const PhoneVerificationAttemptMax = 3
+
+// GeneratePhoneVerification function to save the code which is sent to the unverified phone of user.
+// A new code can only be generated a minute after the previous one, so the SMS can't be flooded. The wrong
+// attempts are kept while the previous code is still valid, so resending the code doesn't give more guesses
+/*
+	@params:
+		id	= int64
+	@example:
+		id	= 140810140060
+	@return
+		Verification
+*/
+func GeneratePhoneVerification(id int64) (Verification, error) {
+
+	code, err := helper.GenerateVerificationCode()
+	if err != nil {
+		return Verification{}, err
+	}
+	v := Verification{
+		Code:           code,
+		ExpireDuration: "30 Minutes",
+		ExpireDate:     time.Now().Add(30 * time.Minute),
+		Attempt:        0,
+	}
+
+	query := `
+		UPDATE
+			users
+		SET
+			phone_verification_attempt = (
+				IF(
+					phone_verification_expire_date IS NULL OR phone_verification_expire_date < NOW(),
+					0,
+					phone_verification_attempt
+				)
+			),
+			phone_verification_code = (?),
+			phone_verification_expire_date = (DATE_ADD(NOW(), INTERVAL 30 MINUTE)),
+			updated_at = NOW()
+		WHERE
+			id = (?) AND
+			phone IS NOT NULL AND
+			phone_verified = 0 AND
+			(
+				phone_verification_expire_date IS NULL OR
+				phone_verification_expire_date < DATE_ADD(NOW(), INTERVAL 29 MINUTE)
+			);
+		`
+	result, err := conn.Exec(query, v.Code, id)
+	if err != nil {
+		return v, err
+	}
+	count, _ := result.RowsAffected()
+	if count < 1 {
+		return v, fmt.Errorf("Error executing query")
+	}
+
+	return v, nil
+}
+
+// GetPhoneVerification function to get the unexpired phone verification of user
+/*
+	@params:
+		id	= int64
+	@example:
+		id	= 140810140060
+	@return
+		PhoneVerification
+*/
+func GetPhoneVerification(id int64) (PhoneVerification, error) {
+	var v PhoneVerification
+	query := `
+		SELECT
+			id,
+			phone,
+			phone_verification_code,
+			phone_verification_attempt
+		FROM
+			users
+		WHERE
+			id = (?) AND
+			phone_verification_code IS NOT NULL AND
+			NOW() < phone_verification_expire_date
+		LIMIT 1;
+		`
+	err := conn.Get(&v, query, id)
+	if err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// IsValidPhoneVerificationCode function to check the code sent to the phone. Every attempt is counted before the code
+// is compared, and the counter is only raised below the maximum, so the concurrent requests can't guess more codes
+/*
+	@params:
+		v		= PhoneVerification
+		code	= uint16
+	@example:
+		code	= 1408
+	@return
+*/
+func IsValidPhoneVerificationCode(v PhoneVerification, code uint16) bool {
+	query := `
+		UPDATE
+			users
+		SET
+			phone_verification_attempt = phone_verification_attempt + 1,
+			updated_at = NOW()
+		WHERE
+			id = (?) AND
+			phone_verification_attempt < (?);
+		`
+	result, err := conn.Exec(query, v.ID, PhoneVerificationAttemptMax)
+	if err != nil {
+		return false
+	}
+	rows, err := result.RowsAffected()
+	if err != nil || rows < 1 {
+		return false
+	}
+
+	if !v.Code.Valid || v.Code.Int64 != int64(code) {
+		return false
+	}
+
+	return true
+}
+
+// UpdatePhoneVerified function to mark the phone of user as verified. The phone is compared
+// so a phone which has been changed after the code was sent isn't verified
+/*
+	@params:
+		id		= int64
+		phone	= string
+	@example:
+		id		= 140810140060
+		phone	= 085860141146
+	@return
+*/
+func UpdatePhoneVerified(id int64, phone string, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			phone_verified = 1,
+			phone_verification_code = NULL,
+			phone_verification_expire_date = NULL,
+			phone_verification_attempt = NULL,
+			updated_at = NOW()
+		WHERE
+			id = (?) AND
+			phone = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, id, phone)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestIsValidPhoneVerificationCode(t *testing.T) {
+	query := `^\s*UPDATE\s*users\s*SET\s*phone_verification_attempt\s*=\s*phone_verification_attempt\s*\+\s*1,\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\)\s*AND\s*phone_verification_attempt\s*<\s*\(\?\);$`
+	tests := []struct {
+		name         string
+		verification PhoneVerification
+		code         uint16
+		rowsAffected int64
+		want         bool
+	}{
+		{
+			name: "Valid Code",
+			verification: PhoneVerification{
+				ID:      1,
+				Phone:   sql.NullString{String: "085860141146", Valid: true},
+				Code:    sql.NullInt64{Int64: 1408, Valid: true},
+				Attempt: sql.NullInt64{Int64: 2, Valid: true},
+			},
+			code:         1408,
+			rowsAffected: 1,
+			want:         true,
+		},
+		{
+			name: "Wrong Code",
+			verification: PhoneVerification{
+				ID:      1,
+				Phone:   sql.NullString{String: "085860141146", Valid: true},
+				Code:    sql.NullInt64{Int64: 1408, Valid: true},
+				Attempt: sql.NullInt64{Int64: 0, Valid: true},
+			},
+			code:         1409,
+			rowsAffected: 1,
+			want:         false,
+		},
+		{
+			name: "Too Many Attempts",
+			verification: PhoneVerification{
+				ID:      1,
+				Phone:   sql.NullString{String: "085860141146", Valid: true},
+				Code:    sql.NullInt64{Int64: 1408, Valid: true},
+				Attempt: sql.NullInt64{Int64: PhoneVerificationAttemptMax, Valid: true},
+			},
+			code:         1408,
+			rowsAffected: 0,
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		db.ExpectExec(query).
+			WithArgs(tt.verification.ID, PhoneVerificationAttemptMax).
+			WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidPhoneVerificationCode(tt.verification, tt.code); got != tt.want {
+				t.Errorf("IsValidPhoneVerificationCode() = %v, want %v", got, tt.want)
+			}
+			if err := db.ExpectationsWereMet(); err != nil {
+				t.Errorf("IsValidPhoneVerificationCode() %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestGeneratePhoneVerification(t *testing.T) {
+	query := `^\s*UPDATE\s*users\s*SET\s*phone_verification_attempt\s*=\s*\(\s*IF\(.*phone_verification_attempt\s*\)\s*\),\s*phone_verification_code\s*=\s*\(\?\),.*WHERE\s*id\s*=\s*\(\?\)\s*AND\s*phone\s*IS\s*NOT\s*NULL\s*AND\s*phone_verified\s*=\s*0\s*AND.*;$`
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{
+			name:         "Test Case 1",
+			rowsAffected: 1,
+		},
+		{
+			name:         "Test Case 2",
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		db.ExpectExec(query).WithArgs(sqlmock.AnyArg(), 1).WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := GeneratePhoneVerification(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GeneratePhoneVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if v.Code < 1000 || v.Code > 9999 {
+				t.Errorf("GeneratePhoneVerification() code = %v, want 4 digits", v.Code)
+			}
+		})
+	}
+}
+
+func TestUpdatePhoneVerified(t *testing.T) {
+	query := `^\s*UPDATE\s*users\s*SET\s*phone_verified\s*=\s*1,\s*phone_verification_code\s*=\s*NULL,\s*phone_verification_expire_date\s*=\s*NULL,\s*phone_verification_attempt\s*=\s*NULL,\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\)\s*AND\s*phone\s*=\s*\(\?\);$`
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		err          error
+		wantErr      bool
+	}{
+		{
+			name:         "Test Case 1",
+			rowsAffected: 1,
+		},
+		{
+			name:         "Test Case 2",
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:    "Test Case 3",
+			err:     fmt.Errorf("Error connection"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(query).WithArgs(1, "085860141146")
+		if tt.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+		} else {
+			q.WillReturnError(tt.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdatePhoneVerified(1, "085860141146"); (err != nil) != tt.wantErr {
+				t.Errorf("UpdatePhoneVerified() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
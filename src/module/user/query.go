@@ -12,7 +12,7 @@ const (
 		FROM
 			users
 		WHERE
-			email = ('%s')
+			email = (?)
 		LIMIT 1;
 	`
 	queryGetByIdentityCode = `
@@ -21,7 +21,7 @@ const (
 		FROM
 			users
 		WHERE
-			identity_code = (%d)
+			identity_code = (?)
 		LIMIT 1;
 	`
 	querySignIn = `
@@ -35,14 +35,27 @@ const (
 			identity_code,
 			line_id,
 			phone,
-			rolegroups_id
+			rolegroups_id,
+			program,
+			cohort,
+			advisor_id,
+			phone_verified,
+			password
 		FROM
 			users
 		WHERE
-			email = ('%s') AND
-			password = ('%s')
+			email = (?)
 		LIMIT 1;
 	`
+	queryUpdatePassword = `
+		UPDATE
+			users
+		SET
+			password = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?);
+	`
 	querySignUp = `
 		INSERT INTO
 			users (
@@ -53,10 +66,10 @@ const (
 				created_at,
 				updated_at
 			) VALUES (
-				('%s'),
-				('%s'),
-				('%s'),
-				(%d),
+				(?),
+				(?),
+				(?),
+				(?),
 				NOW(),
 				NOW()
 			);
@@ -65,22 +78,22 @@ const (
 		UPDATE
 			users
 		SET
-			status = (%d),
+			status = (?),
 			email_verification_code = NULL,
 			email_verification_expire_date = NULL,
 			email_verification_attempt = NULL,
 			updated_at = NOW()
 		WHERE
-			identity_code = (%d);
+			identity_code = (?);
 	`
 	queryUpdateStatus = `
 		UPDATE
 			users
 		SET
-			status = (%d),
+			status = (?),
 			updated_at = NOW()
 		WHERE
-			identity_code = (%d);
+			identity_code = (?);
 	`
 	querySelectDashboard = `
 		SELECT
@@ -91,22 +104,22 @@ const (
 		FROM
 			users
 		WHERE
-			(status = (%d) OR status = (%d)) AND
-			id != (%d)
-		LIMIT %d
-		OFFSET %d;
+			(status = (?) OR status = (?)) AND
+			id != (?)
+		LIMIT ?
+		OFFSET ?;
 	`
 
 	generateVerificationQuery = `
 		UPDATE
 			users
 		SET
-			email_verification_code = (%d),
+			email_verification_code = (?),
 			email_verification_expire_date = (DATE_ADD(NOW(), INTERVAL 30 MINUTE)),
 			email_verification_attempt = 0,
 			updated_at = NOW()
 		WHERE
-			identity_code = (%d);
+			identity_code = (?);
 	`
 
 	getConfirmationQuery = `
@@ -117,7 +130,7 @@ const (
 		FROM
 			users
 		WHERE
-			email = ('%s') AND
+			email = (?) AND
 			NOW() < email_verification_expire_date
 		LIMIT 1;
 	`
@@ -129,19 +142,19 @@ const (
 			email_verification_attempt = email_verification_attempt + 1,
 			updated_at = NOW()
 		WHERE
-			id = (%d)
+			id = (?)
 	`
 
 	queryForgotNewPassword = `
 		UPDATE
 			users
 		SET
-			password = ('%s'),
+			password = (?),
 			email_verification_code = NULL,
 			email_verification_expire_date = NULL,
 			email_verification_attempt = NULL,
 			updated_at = NOW()
 		WHERE
-			email = ('%s');
+			email = (?);
 	`
 )
@@ -0,0 +1,162 @@
+package user
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+// SortColumns are the columns which the search can be sorted by
+var SortColumns = []string{
+	ColID,
+	ColName,
+	ColEmail,
+	ColIdentityCode,
+	ColGender,
+	ColStatus,
+}
+
+// IsSortColumn checks whether the search can be sorted by the column
+func IsSortColumn(column string) bool {
+	for _, val := range SortColumns {
+		if val == column {
+			return true
+		}
+	}
+	return false
+}
+
+// SelectBySearch function to search the users matched by the filter a page at a time.
+// The page starts right after the cursor, so the next page stays stable while users are
+// being created or deleted. The users are sorted by the column, then by id to break ties
+/*
+	@params:
+		filter	= SearchFilter
+		sort	= string
+		isDesc	= bool
+		cursor	= SearchCursor
+		limit	= int
+	@example:
+		filter	= {Text: risal, Status: 2}
+		sort	= name
+		isDesc	= false
+		cursor	= {Value: risal falah, ID: 2000000001}
+		limit	= 10
+	@return
+		[]User
+*/
+func SelectBySearch(filter SearchFilter, sort string, isDesc bool, cursor SearchCursor, limit int) ([]User, error) {
+	users := []User{}
+	if !IsSortColumn(sort) {
+		return users, fmt.Errorf("Invalid sort column")
+	}
+
+	conds, args := filter.where()
+
+	order := "ASC"
+	operator := OperatorMore
+	if isDesc {
+		order = "DESC"
+		operator = OperatorLess
+	}
+
+	if cursor.ID > 0 {
+		if sort == ColID {
+			conds = append(conds, fmt.Sprintf("id %s (?)", operator))
+			args = append(args, cursor.ID)
+		} else {
+			conds = append(conds, fmt.Sprintf("(%s %s (?) OR (%s = (?) AND id %s (?)))", sort, operator, sort, operator))
+			args = append(args, cursor.Value, cursor.Value, cursor.ID)
+		}
+	}
+
+	var where string
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+
+	orderBy := fmt.Sprintf("id %s", order)
+	if sort != ColID {
+		orderBy = fmt.Sprintf("%s %s, id %s", sort, order, order)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			name,
+			email,
+			gender,
+			status,
+			identity_code,
+			rolegroups_id
+		FROM
+			users
+		%s
+		ORDER BY
+			%s
+		LIMIT ?;
+		`, where, orderBy)
+	err := conn.Select(&users, query, append(args, limit)...)
+	if err != nil {
+		return users, err
+	}
+	return users, nil
+}
+
+// SortValue returns the value of the sort column of user to be used as the search cursor
+/*
+	@params:
+		column	= string
+	@example:
+		column	= name
+	@return
+		value	= risal falah
+*/
+func (u User) SortValue(column string) string {
+	switch column {
+	case ColName:
+		return u.Name
+	case ColEmail:
+		return u.Email
+	case ColIdentityCode:
+		return strconv.FormatInt(u.IdentityCode, 10)
+	case ColGender:
+		return strconv.FormatInt(int64(u.Gender), 10)
+	case ColStatus:
+		return strconv.FormatInt(int64(u.Status), 10)
+	}
+	return strconv.FormatInt(u.ID, 10)
+}
+
+// where returns the conditions of the filter and its arguments
+func (f SearchFilter) where() ([]string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	if len(f.Text) > 0 {
+		pattern := helper.EscapeLike(f.Text)
+		conds = append(conds, "(name LIKE (?) OR email LIKE (?) OR identity_code LIKE (?))")
+		args = append(args, "%"+pattern+"%", "%"+pattern+"%", pattern+"%")
+	}
+	if f.Status.Valid {
+		conds = append(conds, "status = (?)")
+		args = append(args, f.Status.Int64)
+	}
+	if f.Gender.Valid {
+		conds = append(conds, "gender = (?)")
+		args = append(args, f.Gender.Int64)
+	}
+	if f.RolegroupID > 0 {
+		conds = append(conds, "rolegroups_id = (?)")
+		args = append(args, f.RolegroupID)
+	}
+	if f.ScheduleID > 0 {
+		conds = append(conds, "id IN (SELECT users_id FROM p_users_schedules WHERE schedules_id = (?))")
+		args = append(args, f.ScheduleID)
+	}
+
+	return conds, args
+}
@@ -0,0 +1,157 @@
+package user
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSelectBySearch(t *testing.T) {
+	type args struct {
+		filter SearchFilter
+		sort   string
+		isDesc bool
+		cursor SearchCursor
+		limit  int
+	}
+	columns := []string{"id", "name", "email", "gender", "status", "identity_code", "rolegroups_id"}
+	tests := []struct {
+		name     string
+		args     args
+		query    string
+		withArgs []driver.Value
+		rows     [][]driver.Value
+		err      error
+		want     []User
+		wantErr  bool
+	}{
+		{
+			name: "Invalid Sort",
+			args: args{
+				sort:  "password",
+				limit: 10,
+			},
+			want:    []User{},
+			wantErr: true,
+		},
+		{
+			name: "First Page",
+			args: args{
+				sort:  ColID,
+				limit: 10,
+			},
+			query:    `^\s*SELECT\s*id,\s*name,\s*email,\s*gender,\s*status,\s*identity_code,\s*rolegroups_id\s*FROM\s*users\s*ORDER\s*BY\s*id\s*ASC\s*LIMIT\s*\?;$`,
+			withArgs: []driver.Value{10},
+			rows: [][]driver.Value{
+				[]driver.Value{"1", "Risal Falah", "risal@live.com", "1", "2", "140810140016", "1"},
+			},
+			want: []User{
+				User{
+					ID:           1,
+					Name:         "Risal Falah",
+					Email:        "risal@live.com",
+					Gender:       1,
+					Status:       2,
+					IdentityCode: 140810140016,
+					RoleGroupsID: sql.NullInt64{Int64: 1, Valid: true},
+				},
+			},
+		},
+		{
+			name: "Filtered Next Page",
+			args: args{
+				filter: SearchFilter{
+					Text:        "ris_l",
+					Status:      sql.NullInt64{Int64: StatusUnverified, Valid: true},
+					Gender:      sql.NullInt64{Int64: GenderMale, Valid: true},
+					RolegroupID: 2,
+					ScheduleID:  12,
+				},
+				sort:   ColName,
+				isDesc: true,
+				cursor: SearchCursor{Value: "Risal Falah", ID: 1},
+				limit:  5,
+			},
+			query: `^\s*SELECT\s*id,\s*name,\s*email,\s*gender,\s*status,\s*identity_code,\s*rolegroups_id\s*FROM\s*users\s*` +
+				`WHERE\s*\(name\s*LIKE\s*\(\?\)\s*OR\s*email\s*LIKE\s*\(\?\)\s*OR\s*identity_code\s*LIKE\s*\(\?\)\)\s*AND\s*` +
+				`status\s*=\s*\(\?\)\s*AND\s*gender\s*=\s*\(\?\)\s*AND\s*rolegroups_id\s*=\s*\(\?\)\s*AND\s*` +
+				`id\s*IN\s*\(SELECT\s*users_id\s*FROM\s*p_users_schedules\s*WHERE\s*schedules_id\s*=\s*\(\?\)\)\s*AND\s*` +
+				`\(name\s*<\s*\(\?\)\s*OR\s*\(name\s*=\s*\(\?\)\s*AND\s*id\s*<\s*\(\?\)\)\)\s*` +
+				`ORDER\s*BY\s*name\s*DESC,\s*id\s*DESC\s*LIMIT\s*\?;$`,
+			withArgs: []driver.Value{`%ris\_l%`, `%ris\_l%`, `ris\_l%`, 0, 1, 2, 12, "Risal Falah", "Risal Falah", 1, 5},
+			rows:     [][]driver.Value{},
+			want:     []User{},
+		},
+		{
+			name: "Error Connection",
+			args: args{
+				sort:   ColID,
+				cursor: SearchCursor{Value: "1", ID: 1},
+				limit:  10,
+			},
+			query:    `^\s*SELECT.*FROM\s*users\s*WHERE\s*id\s*>\s*\(\?\)\s*ORDER\s*BY\s*id\s*ASC\s*LIMIT\s*\?;$`,
+			withArgs: []driver.Value{1, 10},
+			err:      fmt.Errorf("Error connection"),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		if len(tt.query) > 0 {
+			q := db.ExpectQuery(tt.query).WithArgs(tt.withArgs...)
+			if tt.err == nil {
+				rows := sqlmock.NewRows(columns)
+				for _, val := range tt.rows {
+					rows.AddRow(val...)
+				}
+				q.WillReturnRows(rows)
+			} else {
+				q.WillReturnError(tt.err)
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectBySearch(tt.args.filter, tt.args.sort, tt.args.isDesc, tt.args.cursor, tt.args.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SelectBySearch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectBySearch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_SortValue(t *testing.T) {
+	u := User{
+		ID:           2000000001,
+		Name:         "Risal Falah",
+		Email:        "risal@live.com",
+		Gender:       GenderMale,
+		Status:       StatusActivated,
+		IdentityCode: 140810140016,
+	}
+	tests := []struct {
+		column string
+		want   string
+	}{
+		{ColID, "2000000001"},
+		{ColName, "Risal Falah"},
+		{ColEmail, "risal@live.com"},
+		{ColIdentityCode, "140810140016"},
+		{ColGender, "1"},
+		{ColStatus, "2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			if got := u.SortValue(tt.column); got != tt.want {
+				t.Errorf("User.SortValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// GetBySSOSubject function to get the user linked to the subject of the single sign on provider
+/*
+	@params:
+		subject	= string
+	@example:
+		subject	= 248289761001
+	@return
+		User
+*/
+func GetBySSOSubject(subject string, column ...string) (User, error) {
+	var user User
+	c := column
+	if len(c) < 1 {
+		c = []string{
+			ColID,
+			ColName,
+			ColEmail,
+			ColGender,
+			ColNote,
+			ColStatus,
+			ColIdentityCode,
+			ColLineID,
+			ColPhone,
+			ColRoleGroupsID,
+			ColProgram,
+			ColCohort,
+			ColAdvisorID,
+			ColPhoneVerified,
+		}
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			sso_subject = (?)
+		LIMIT 1;
+		`, strings.Join(c, ", "))
+	err := conn.Get(&user, query, subject)
+	if err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+// UpdateSSOSubject function to link the user to the subject of the single sign on provider, a linked user is not relinked
+/*
+	@params:
+		id		= int64
+		subject	= string
+	@example:
+		id		= 140810140060
+		subject	= 248289761001
+	@return
+*/
+func UpdateSSOSubject(id int64, subject string, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			sso_subject = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?) AND
+			sso_subject IS NULL;
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, subject, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// Provision function to create the user who signs in by the single sign on for the first time.
+// The user has no password and waits for the admin approval like a confirmed sign up
+/*
+	@params:
+		identityCode	= int64
+		name			= string
+		email			= string
+		subject			= string
+	@example:
+		identityCode	= 140810140060
+		name			= kharil azmi ashari
+		email			= khairil_azmi_ashari@yahoo.com
+		subject			= 248289761001
+	@return
+		id				= 12
+*/
+func Provision(identityCode int64, name, email, subject string, tx ...*sqlx.Tx) (int64, error) {
+	query := `
+		INSERT INTO
+		users (
+			name,
+			email,
+			password,
+			identity_code,
+			status,
+			sso_subject,
+			created_at,
+			updated_at
+		) VALUES (
+			(?),
+			(?),
+			('x'),
+			(?),
+			(?),
+			(?),
+			NOW(),
+			NOW()
+		);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, name, email, identityCode, StatusVerified, subject)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return 0, fmt.Errorf("No rows affected")
+	}
+	return result.LastInsertId()
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestUpdateSSOSubject(t *testing.T) {
+	type mock struct {
+		rowsAffected int64
+		err          error
+	}
+	tests := []struct {
+		name    string
+		mock    mock
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			mock:    mock{rowsAffected: 1},
+			wantErr: false,
+		},
+		{
+			name:    "Test Case 2",
+			mock:    mock{rowsAffected: 0},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			mock:    mock{err: fmt.Errorf("Error connection")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(`^\s*UPDATE\s*users\s*SET\s*sso_subject\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\)\s*AND\s*sso_subject\s*IS\s*NULL;$`).
+			WithArgs("248289761001", 1)
+		if tt.mock.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.mock.rowsAffected))
+		} else {
+			q.WillReturnError(tt.mock.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdateSSOSubject(1, "248289761001"); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateSSOSubject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProvision(t *testing.T) {
+	db, _ := conn.InitDBMock()
+	db.ExpectExec(`^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*sso_subject,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\(\?\),\s*\(\?\),\s*\('x'\),\s*\(\?\),\s*\(\?\),\s*\(\?\),\s*NOW\(\),\s*NOW\(\)\s*\);$`).
+		WithArgs("Risal Falah", "risal@live.com", 140810140016, StatusVerified, "248289761001").
+		WillReturnResult(sqlmock.NewResult(12, 1))
+
+	id, err := Provision(140810140016, "Risal Falah", "risal@live.com", "248289761001")
+	if err != nil || id != 12 {
+		t.Errorf("Provision() = %v, error = %v", id, err)
+	}
+}
@@ -0,0 +1,243 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+// GetTwoFactor function to get the TOTP secret and its activation time of the user
+/*
+	@params:
+		id			= int64
+	@example:
+		id			= 140810140060
+	@return
+		Secret		= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+		EnabledAt	= 2017-12-21 08:10:00
+*/
+func GetTwoFactor(id int64) (TwoFactor, error) {
+	var twoFactor TwoFactor
+	query := `
+		SELECT
+			totp_secret,
+			totp_enabled_at
+		FROM
+			users
+		WHERE
+			id = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&twoFactor, query, id)
+	if err != nil {
+		return twoFactor, err
+	}
+	return twoFactor, nil
+}
+
+// UpdateTOTPSecret function to start the enrolment with a new secret, it doesn't replace an activated one
+/*
+	@params:
+		id		= int64
+		secret	= string
+	@example:
+		id		= 140810140060
+		secret	= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	@return
+*/
+func UpdateTOTPSecret(id int64, secret string) error {
+	query := `
+		UPDATE
+			users
+		SET
+			totp_secret = (?),
+			updated_at = NOW()
+		WHERE
+			id = (?) AND
+			totp_enabled_at IS NULL;
+		`
+	result, err := conn.Exec(query, secret, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// EnableTOTP function to activate the enrolled secret
+/*
+	@params:
+		id	= int64
+		tx	= *sqlx.Tx
+	@example:
+		id	= 140810140060
+	@return
+*/
+func EnableTOTP(id int64, tx *sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			totp_enabled_at = NOW(),
+			updated_at = NOW()
+		WHERE
+			id = (?) AND
+			totp_secret IS NOT NULL AND
+			totp_enabled_at IS NULL;
+		`
+	result, err := conn.TxExec(tx, query, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// DisableTOTP function to remove the secret and the recovery codes of the user
+/*
+	@params:
+		id	= int64
+		tx	= *sqlx.Tx
+	@example:
+		id	= 140810140060
+	@return
+*/
+func DisableTOTP(id int64, tx *sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			totp_secret = NULL,
+			totp_enabled_at = NULL,
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+	_, err := conn.TxExec(tx, query, id)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		DELETE FROM
+			recovery_codes
+		WHERE
+			users_id = (?);
+		`
+	_, err = conn.TxExec(tx, query, id)
+	return err
+}
+
+// ReplaceRecoveryCodes function to replace all recovery codes of the user, only the hashes are stored
+/*
+	@params:
+		id		= int64
+		hashes	= []string
+		tx		= *sqlx.Tx
+	@example:
+		id		= 140810140060
+		hashes	= [9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08]
+	@return
+*/
+func ReplaceRecoveryCodes(id int64, hashes []string, tx *sqlx.Tx) error {
+	query := `
+		DELETE FROM
+			recovery_codes
+		WHERE
+			users_id = (?);
+		`
+	_, err := conn.TxExec(tx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if len(hashes) < 1 {
+		return nil
+	}
+
+	var value []string
+	var args []interface{}
+	for _, hash := range hashes {
+		value = append(value, "(?, ?, NOW())")
+		args = append(args, id, hash)
+	}
+
+	query = fmt.Sprintf(`
+		INSERT INTO
+			recovery_codes (
+				users_id,
+				code,
+				created_at
+			) VALUES %s;
+		`, strings.Join(value, ", "))
+	_, err = conn.TxExec(tx, query, args...)
+	return err
+}
+
+// UseRecoveryCode function to mark the recovery code as used, a code can only be used once
+/*
+	@params:
+		id		= int64
+		hash	= string
+	@example:
+		id		= 140810140060
+		hash	= 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	@return
+		used	= true
+*/
+func UseRecoveryCode(id int64, hash string) bool {
+	query := `
+		UPDATE
+			recovery_codes
+		SET
+			used_at = NOW()
+		WHERE
+			users_id = (?) AND
+			code = (?) AND
+			used_at IS NULL;
+		`
+	result, err := conn.Exec(query, id, hash)
+	if err != nil {
+		return false
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false
+	}
+	return rows == 1
+}
+
+// SelectCountRecoveryCodes function to count the unused recovery codes of the user
+/*
+	@params:
+		id		= int64
+	@example:
+		id		= 140810140060
+	@return
+		count	= 8
+*/
+func SelectCountRecoveryCodes(id int64) (int, error) {
+	var count int
+	query := `
+		SELECT
+			COUNT(*)
+		FROM
+			recovery_codes
+		WHERE
+			users_id = (?) AND
+			used_at IS NULL;
+		`
+	err := conn.Get(&count, query, id)
+	if err != nil {
+		return count, err
+	}
+	return count, nil
+}
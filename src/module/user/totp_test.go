@@ -0,0 +1,71 @@
+package user
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestUseRecoveryCode(t *testing.T) {
+	type mock struct {
+		rowsAffected int64
+		err          error
+	}
+	tests := []struct {
+		name string
+		mock mock
+		want bool
+	}{
+		{
+			name: "Test Case 1",
+			mock: mock{rowsAffected: 1},
+			want: true,
+		},
+		{
+			name: "Test Case 2",
+			mock: mock{rowsAffected: 0},
+			want: false,
+		},
+		{
+			name: "Test Case 3",
+			mock: mock{err: fmt.Errorf("Error connection")},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(`^\s*UPDATE\s*recovery_codes\s*SET\s*used_at\s*=\s*NOW\(\)\s*WHERE\s*users_id\s*=\s*\(\?\)\s*AND\s*code\s*=\s*\(\?\)\s*AND\s*used_at\s*IS\s*NULL;$`).
+			WithArgs(1, "hash")
+		if tt.mock.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.mock.rowsAffected))
+		} else {
+			q.WillReturnError(tt.mock.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UseRecoveryCode(1, "hash"); got != tt.want {
+				t.Errorf("UseRecoveryCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceRecoveryCodes(t *testing.T) {
+	db, _ := conn.InitDBMock()
+	db.ExpectBegin()
+	db.ExpectExec(`^\s*DELETE\s*FROM\s*recovery_codes\s*WHERE\s*users_id\s*=\s*\(\?\);$`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	db.ExpectExec(`^\s*INSERT\s*INTO\s*recovery_codes\s*\(\s*users_id,\s*code,\s*created_at\s*\)\s*VALUES\s*\(\?, \?, NOW\(\)\), \(\?, \?, NOW\(\)\);$`).
+		WithArgs(1, "a", 1, "b").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	tx := conn.DB.MustBegin()
+	if err := ReplaceRecoveryCodes(1, []string{"a", "b"}, tx); err != nil {
+		t.Errorf("ReplaceRecoveryCodes() error = %v", err)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("ReplaceRecoveryCodes() %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/melodiez14/meiko/src/util/helper"
 
 	"github.com/melodiez14/meiko/src/util/conn"
@@ -35,8 +36,6 @@ func SelectByID(id []int64, isSort bool, column ...string) ([]User, error) {
 	var c []string
 	var sortQuery string
 
-	d := helper.Int64ToStringSlice(id)
-
 	if isSort {
 		sortQuery = "ORDER BY identity_code ASC"
 	}
@@ -53,13 +52,16 @@ func SelectByID(id []int64, isSort bool, column ...string) ([]User, error) {
 			ColLineID,
 			ColPhone,
 			ColRoleGroupsID,
+			ColProgram,
+			ColCohort,
+			ColAdvisorID,
+			ColPhoneVerified,
 		}
 	} else {
 		for _, val := range column {
 			c = append(c, val)
 		}
 	}
-	ids := strings.Join(d, ", ")
 	cols := strings.Join(c, ", ")
 	query := fmt.Sprintf(`
 		SELECT
@@ -67,8 +69,8 @@ func SelectByID(id []int64, isSort bool, column ...string) ([]User, error) {
 		FROM
 			users
 		WHERE
-			id IN (%s) %s;`, cols, ids, sortQuery)
-	err := conn.DB.Select(&user, query)
+			id IN (?) %s;`, cols, sortQuery)
+	err := conn.Select(&user, query, id)
 	if err != nil {
 		return user, err
 	}
@@ -108,6 +110,10 @@ func GetByEmail(email string, column ...string) (User, error) {
 			ColLineID,
 			ColPhone,
 			ColRoleGroupsID,
+			ColProgram,
+			ColCohort,
+			ColAdvisorID,
+			ColPhoneVerified,
 		}
 	} else {
 		for _, val := range column {
@@ -115,8 +121,8 @@ func GetByEmail(email string, column ...string) (User, error) {
 		}
 	}
 	cols := strings.Join(c, ", ")
-	query := fmt.Sprintf(queryGetByEmail, cols, email)
-	err := conn.DB.Get(&user, query)
+	query := fmt.Sprintf(queryGetByEmail, cols)
+	err := conn.Get(&user, query, email)
 	if err != nil {
 		return user, err
 	}
@@ -154,6 +160,10 @@ func GetByIdentityCode(identityCode int64, column ...string) (User, error) {
 			ColLineID,
 			ColPhone,
 			ColRoleGroupsID,
+			ColProgram,
+			ColCohort,
+			ColAdvisorID,
+			ColPhoneVerified,
 		}
 	} else {
 		for _, val := range column {
@@ -161,8 +171,8 @@ func GetByIdentityCode(identityCode int64, column ...string) (User, error) {
 		}
 	}
 	cols := strings.Join(c, ", ")
-	query := fmt.Sprintf(queryGetByIdentityCode, cols, identityCode)
-	err := conn.DB.Get(&user, query)
+	query := fmt.Sprintf(queryGetByIdentityCode, cols)
+	err := conn.Get(&user, query, identityCode)
 	if err != nil {
 		return user, err
 	}
@@ -190,13 +200,29 @@ func GetByIdentityCode(identityCode int64, column ...string) (User, error) {
 		RoleGroupsID	= 0
 */
 func SignIn(email, password string) (User, error) {
-	var user User
-	query := fmt.Sprintf(querySignIn, email, password)
-	err := conn.DB.Get(&user, query)
+	var user struct {
+		User
+		Password string `db:"password"`
+	}
+	err := conn.Get(&user, querySignIn, email)
 	if err != nil {
-		return user, err
+		return User{}, err
 	}
-	return user, nil
+
+	match, rehash := helper.ComparePassword(user.Password, password)
+	if !match {
+		return User{}, sql.ErrNoRows
+	}
+
+	// upgrade legacy or weak hash while the plain password is known
+	if rehash {
+		hash, err := helper.HashPassword(password)
+		if err == nil {
+			conn.Exec(queryUpdatePassword, hash, user.ID)
+		}
+	}
+
+	return user.User, nil
 }
 
 // SignUp function to sign up an account by fill with identity code, name, email, and password.
@@ -215,8 +241,12 @@ func SignIn(email, password string) (User, error) {
 	@return
 */
 func SignUp(identityCode int64, name, email, password string) error {
-	query := fmt.Sprintf(querySignUp, name, email, password, identityCode)
-	result, err := conn.DB.Exec(query)
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(querySignUp, name, email, hash, identityCode)
 	if err != nil {
 		return err
 	}
@@ -240,17 +270,17 @@ func SignUp(identityCode int64, name, email, password string) error {
 */
 func IsPhoneExist(identityCode int64, phone string) bool {
 	var user User
-	query := fmt.Sprintf(`
+	query := `
 			SELECT
 				phone
 			FROM
 				 users
 			WHERE
-				phone = ('%s') AND
-				identity_code != (%d)
+				phone = (?) AND
+				identity_code != (?)
 			LIMIT 1
-		`, phone, identityCode)
-	err := conn.DB.Get(&user, query)
+		`
+	err := conn.Get(&user, query, phone, identityCode)
 	if err == sql.ErrNoRows {
 		return false
 	}
@@ -270,17 +300,17 @@ func IsPhoneExist(identityCode int64, phone string) bool {
 */
 func IsLineIDExist(identityCode int64, lineID string) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 			SELECT
 				'x'
 			FROM
 				 users
 			WHERE
-				line_id = ('%s') AND
-				identity_code != (%d)
+				line_id = (?) AND
+				identity_code != (?)
 			LIMIT 1;
-		`, lineID, identityCode)
-	err := conn.DB.Get(&x, query)
+		`
+	err := conn.Get(&x, query, lineID, identityCode)
 	if err == sql.ErrNoRows {
 		return false
 	}
@@ -305,36 +335,33 @@ func IsLineIDExist(identityCode int64, lineID string) bool {
 		gender			= 1
 	@return
 */
-func UpdateProfile(identityCode int64, name, note string, phone, lineID sql.NullString, gender int8) error {
+func UpdateProfile(identityCode int64, name, note string, phone, lineID sql.NullString, gender int8, tx ...*sqlx.Tx) error {
 
 	if gender != GenderMale && gender != GenderFemale {
 		gender = GenderUndefined
 	}
 
-	queryLineID := fmt.Sprintf("NULL")
-	if lineID.Valid {
-		queryLineID = fmt.Sprintf("('%s')", lineID.String)
-	}
-
-	queryPhone := fmt.Sprintf("NULL")
-	if phone.Valid {
-		queryPhone = fmt.Sprintf("('%s')", phone.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE
 			users
 		SET
-			name = ('%s'),
-			phone = %s,
-			line_id = %s,
-			note = ('%s'),
-			gender = (%d),
+			name = (?),
+			phone_verified = IF(phone <=> (?), phone_verified, 0),
+			phone_verification_code = IF(phone <=> (?), phone_verification_code, NULL),
+			phone = (?),
+			line_id = (?),
+			note = (?),
+			gender = (?),
 			updated_at = NOW()
 		WHERE
-			identity_code = (%d);
-		`, name, queryPhone, queryLineID, note, gender, identityCode)
-	result, err := conn.DB.Exec(query)
+			identity_code = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, name, phone, phone, phone, lineID, note, gender, identityCode)
 	if err != nil {
 		return err
 	}
@@ -363,8 +390,10 @@ func GenerateVerification(identity int64) (Verification, error) {
 		Attempt:        0,
 	}
 
-	query := fmt.Sprintf(generateVerificationQuery, v.Code, identity)
-	result := conn.DB.MustExec(query)
+	result, err := conn.Exec(generateVerificationQuery, v.Code, identity)
+	if err != nil {
+		return v, err
+	}
 	count, _ := result.RowsAffected()
 	if count < 1 {
 		return v, fmt.Errorf("Error executing query")
@@ -386,8 +415,7 @@ func GenerateVerification(identity int64) (Verification, error) {
 */
 func IsValidConfirmationCode(email string, code uint16) bool {
 	var c Confirmation
-	query := fmt.Sprintf(getConfirmationQuery, email)
-	err := conn.DB.Get(&c, query)
+	err := conn.Get(&c, getConfirmationQuery, email)
 	if err != nil {
 		return false
 	}
@@ -397,8 +425,7 @@ func IsValidConfirmationCode(email string, code uint16) bool {
 	}
 
 	if !c.Code.Valid || c.Code.Int64 != int64(code) {
-		query = fmt.Sprintf(attemptIncrementQuery, c.ID)
-		_ = conn.DB.MustExec(query)
+		_, _ = conn.Exec(attemptIncrementQuery, c.ID)
 		return false
 	}
 
@@ -414,8 +441,7 @@ func IsValidConfirmationCode(email string, code uint16) bool {
 	@return
 */
 func UpdateToVerified(identityCode int64) error {
-	query := fmt.Sprintf(queryUpdateToVerified, StatusVerified, identityCode)
-	result, err := conn.DB.Exec(query)
+	result, err := conn.Exec(queryUpdateToVerified, StatusVerified, identityCode)
 	if err != nil {
 		return err
 	}
@@ -436,9 +462,12 @@ func UpdateToVerified(identityCode int64) error {
 		status			= i'm single, thanks you
 	@return
 */
-func UpdateStatus(identityCode int64, status int8) error {
-	query := fmt.Sprintf(queryUpdateStatus, status, identityCode)
-	result, err := conn.DB.Exec(query)
+func UpdateStatus(identityCode int64, status int8, tx ...*sqlx.Tx) error {
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, queryUpdateStatus, status, identityCode)
 	if err != nil {
 		return err
 	}
@@ -453,10 +482,12 @@ func UpdateStatus(identityCode int64, status int8) error {
 /*
 	@params:
 		id		= string
+		filter	= DashboardFilter
 		limit	= uint16
 		offset	= uint16
 	@example:
 		id		= 140810140060
+		filter	= {Program: Teknik Informatika, Cohort: 2014}
 		limit	= 1
 		offset	= 1
 	@return:
@@ -471,24 +502,29 @@ func UpdateStatus(identityCode int64, status int8) error {
 		Phone			= 082214467300
 		RoleGroupsID	= 0
 */
-func SelectDashboard(id int64, limit, offset int, isCount bool) ([]User, int, error) {
+func SelectDashboard(id int64, filter DashboardFilter, limit, offset int, isCount bool) ([]User, int, error) {
 	var user []User
 	var count int
+
+	where, args := filter.where()
+	args = append([]interface{}{StatusVerified, StatusActivated, id}, args...)
 	query := fmt.Sprintf(`
 		SELECT
 			identity_code,
 			name,
 			email,
-			status
+			status,
+			program,
+			cohort
 		FROM
 			users
 		WHERE
-			(status = (%d) OR status = (%d)) AND
-			id != (%d)
-		LIMIT %d
-		OFFSET %d;	
-	`, StatusVerified, StatusActivated, id, limit, offset)
-	err := conn.DB.Select(&user, query)
+			(status = (?) OR status = (?)) AND
+			id != (?)%s
+		LIMIT ?
+		OFFSET ?;
+	`, where)
+	err := conn.Select(&user, query, append(args, limit, offset)...)
 	if err != nil {
 		return user, count, err
 	}
@@ -503,10 +539,10 @@ func SelectDashboard(id int64, limit, offset int, isCount bool) ([]User, int, er
 		FROM
 		users
 		WHERE
-		(status = (%d) OR status = (%d)) AND
-		id != (%d);	
-		`, StatusVerified, StatusActivated, id)
-	err = conn.DB.Get(&count, query)
+		(status = (?) OR status = (?)) AND
+		id != (?)%s;
+		`, where)
+	err = conn.Get(&count, query, args...)
 	if err != nil {
 		return user, count, err
 	}
@@ -514,6 +550,30 @@ func SelectDashboard(id int64, limit, offset int, isCount bool) ([]User, int, er
 	return user, count, nil
 }
 
+// where returns the additional conditions of the filter and its arguments
+func (f DashboardFilter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	if len(f.Program) > 0 {
+		conds = append(conds, "program = (?)")
+		args = append(args, f.Program)
+	}
+	if f.Cohort > 0 {
+		conds = append(conds, "cohort = (?)")
+		args = append(args, f.Cohort)
+	}
+	if f.AdvisorID > 0 {
+		conds = append(conds, "advisor_id = (?)")
+		args = append(args, f.AdvisorID)
+	}
+
+	if len(conds) < 1 {
+		return "", args
+	}
+	return " AND " + strings.Join(conds, " AND "), args
+}
+
 // ChangePassword function to change user password account
 /*
 	@params:
@@ -527,16 +587,41 @@ func SelectDashboard(id int64, limit, offset int, isCount bool) ([]User, int, er
 	@return
 */
 func ChangePassword(identityCode int64, password, oldPassword string) error {
-	query := fmt.Sprintf(`
+	var current string
+	query := `
+		SELECT
+			password
+		FROM
+			users
+		WHERE
+			identity_code = (?)
+		LIMIT 1;
+		`
+	err := conn.Get(&current, query, identityCode)
+	if err != nil {
+		return err
+	}
+
+	if match, _ := helper.ComparePassword(current, oldPassword); !match {
+		return fmt.Errorf("Invalid old password")
+	}
+
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// compare with the old hash again so a concurrent change is not overwritten
+	query = `
 		UPDATE
 			users
 		SET
-			password = ('%s')
+			password = (?)
 		WHERE
-			identity_code = (%d) AND
-			password = ('%s');
-		`, password, identityCode, oldPassword)
-	result, err := conn.DB.Exec(query)
+			identity_code = (?) AND
+			password = (?);
+		`
+	result, err := conn.Exec(query, hash, identityCode, current)
 	if err != nil {
 		return err
 	}
@@ -559,8 +644,12 @@ func ChangePassword(identityCode int64, password, oldPassword string) error {
 	@return
 */
 func ForgotNewPassword(email, password string) error {
-	query := fmt.Sprintf(queryForgotNewPassword, password, email)
-	result, err := conn.DB.Exec(query)
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	result, err := conn.Exec(queryForgotNewPassword, hash, email)
 	if err != nil {
 		return err
 	}
@@ -589,37 +678,70 @@ func ForgotNewPassword(email, password string) error {
 		status			= 1
 	@return
 */
-func Update(identityCode int64, name, note string, phone, lineID sql.NullString, gender, status int8) error {
+func Update(identityCode int64, name, note string, phone, lineID sql.NullString, gender, status int8, tx ...*sqlx.Tx) error {
 
 	if gender != GenderMale && gender != GenderFemale {
 		gender = GenderUndefined
 	}
 
-	queryLineID := fmt.Sprintf("NULL")
-	if lineID.Valid {
-		queryLineID = fmt.Sprintf("('%s')", lineID.String)
-	}
-
-	queryPhone := fmt.Sprintf("NULL")
-	if phone.Valid {
-		queryPhone = fmt.Sprintf("('%s')", phone.String)
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 			UPDATE
 				users
 			SET
-				name = ('%s'),
-				phone = %s,
-				line_id = %s,
-				note = ('%s'),
-				gender = (%d),
-				status = (%d),
+				name = (?),
+				phone_verified = IF(phone <=> (?), phone_verified, 0),
+				phone_verification_code = IF(phone <=> (?), phone_verification_code, NULL),
+				phone = (?),
+				line_id = (?),
+				note = (?),
+				gender = (?),
+				status = (?),
 				updated_at = NOW()
 			WHERE
-				identity_code = (%d);
-			`, name, queryPhone, queryLineID, note, gender, status, identityCode)
-	result, err := conn.DB.Exec(query)
+				identity_code = (?);
+			`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, name, phone, phone, phone, lineID, note, gender, status, identityCode)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// UpdateRolegroup function to change the rolegroup of user, invalid rolegroupID removes the rolegroup
+/*
+	@params:
+		identityCode	= int64
+		rolegroupID		= sql.NullInt64
+	@example:
+		identityCode	= 140810140060
+		rolegroupID		= 2
+	@return
+*/
+func UpdateRolegroup(identityCode int64, rolegroupID sql.NullInt64, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			rolegroups_id = (?),
+			updated_at = NOW()
+		WHERE
+			identity_code = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, rolegroupID, identityCode)
 	if err != nil {
 		return err
 	}
@@ -630,6 +752,114 @@ func Update(identityCode int64, name, note string, phone, lineID sql.NullString,
 	return nil
 }
 
+// UpdateStudy function to change the study program and the cohort year of user, invalid value removes it
+/*
+	@params:
+		identityCode	= int64
+		program			= sql.NullString
+		cohort			= sql.NullInt64
+	@example:
+		identityCode	= 140810140060
+		program			= Teknik Informatika
+		cohort			= 2014
+	@return
+*/
+func UpdateStudy(identityCode int64, program sql.NullString, cohort sql.NullInt64, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			program = (?),
+			cohort = (?),
+			updated_at = NOW()
+		WHERE
+			identity_code = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, program, cohort, identityCode)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// UpdateAdvisor function to change the academic advisor of user, invalid advisorID removes the advisor
+/*
+	@params:
+		identityCode	= int64
+		advisorID		= sql.NullInt64
+	@example:
+		identityCode	= 140810140060
+		advisorID		= 2000000001
+	@return
+*/
+func UpdateAdvisor(identityCode int64, advisorID sql.NullInt64, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			advisor_id = (?),
+			updated_at = NOW()
+		WHERE
+			identity_code = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, advisorID, identityCode)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// SelectByAdvisorID function to get the advisees of the academic advisor sorted by identity code
+/*
+	@params:
+		advisorID	= int64
+	@example:
+		advisorID	= 2000000001
+	@return
+		[]User
+*/
+func SelectByAdvisorID(advisorID int64) ([]User, error) {
+	var user []User
+	query := `
+		SELECT
+			id,
+			name,
+			email,
+			identity_code,
+			program,
+			cohort
+		FROM
+			users
+		WHERE
+			advisor_id = (?)
+		ORDER BY
+			identity_code ASC;
+		`
+	err := conn.Select(&user, query, advisorID)
+	if err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
 // Delete function to delete user using identity code
 /*
 	@params:
@@ -638,15 +868,19 @@ func Update(identityCode int64, name, note string, phone, lineID sql.NullString,
 		identityCode	= 140810140060
 	@return
 */
-func Delete(identityCode int64) error {
-	query := fmt.Sprintf(`
+func Delete(identityCode int64, tx ...*sqlx.Tx) error {
+	query := `
 		DELETE FROM
 			users
 		WHERE
-			identity_code = (%d);
-		`, identityCode)
+			identity_code = (?);
+		`
 
-	result, err := conn.DB.Exec(query)
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, identityCode)
 	if err != nil {
 		return err
 	}
@@ -658,6 +892,76 @@ func Delete(identityCode int64) error {
 	return nil
 }
 
+// Anonymize function to replace the personal data of the user so the user can't be identified nor sign in anymore.
+// The row is kept because the courses and the logs still refer to it
+/*
+	@params:
+		id	= int64
+	@example:
+		id	= 12
+	@return
+*/
+func Anonymize(id int64, tx ...*sqlx.Tx) error {
+	query := `
+		UPDATE
+			users
+		SET
+			name = (?),
+			email = (?),
+			password = ('x'),
+			note = (''),
+			gender = (?),
+			phone = NULL,
+			phone_verified = 0,
+			phone_verification_code = NULL,
+			phone_verification_expire_date = NULL,
+			phone_verification_attempt = NULL,
+			line_id = NULL,
+			identity_code = (?),
+			status = (?),
+			rolegroups_id = NULL,
+			program = NULL,
+			cohort = NULL,
+			advisor_id = NULL,
+			email_verification_code = NULL,
+			email_verification_expire_date = NULL,
+			email_verification_attempt = NULL,
+			email_change = NULL,
+			email_change_code = NULL,
+			email_change_expire_date = NULL,
+			email_change_attempt = NULL,
+			totp_secret = NULL,
+			totp_enabled_at = NULL,
+			calendar_token = NULL,
+			sso_subject = NULL,
+			updated_at = NOW()
+		WHERE
+			id = (?);
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	// the identity code must stay unique and numeric, the prefix 9 and the 18 digits keep it apart from the real ones
+	result, err := conn.TxExec(t, query,
+		AnonymousName,
+		fmt.Sprintf("erased.%d@meiko.invalid", id),
+		GenderUndefined,
+		fmt.Sprintf("9%017d", id),
+		StatusUnverified,
+		id,
+	)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if rows == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
 // Create function to create user to database from valid singup process
 /*
 	@params:
@@ -669,9 +973,10 @@ func Delete(identityCode int64) error {
 		name			= kharil azmi ashari
 		email			= khairil_azmi_ashari@yahoo.com
 	@return
+		id				= 12
 */
-func Create(identityCode int64, name, email string) error {
-	query := fmt.Sprintf(`
+func Create(identityCode int64, name, email string, tx ...*sqlx.Tx) (int64, error) {
+	query := `
 		INSERT INTO
 		users (
 			name,
@@ -682,39 +987,44 @@ func Create(identityCode int64, name, email string) error {
 			created_at,
 			updated_at
 		) VALUES (
-			('%s'),
-			('%s'),
+			(?),
+			(?),
 			('x'),
-			(%d),
-			(%d),
+			(?),
+			(?),
 			NOW(),
 			NOW()
 		);
-		`, name, email, identityCode, StatusActivated)
-	result, err := conn.DB.Exec(query)
+		`
+
+	var t *sqlx.Tx
+	if len(tx) == 1 {
+		t = tx[0]
+	}
+	result, err := conn.TxExec(t, query, name, email, identityCode, StatusActivated)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	rows, err := result.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("No rows affected")
+		return 0, fmt.Errorf("No rows affected")
 	}
-	return nil
+	return result.LastInsertId()
 }
 
 // IsUserExist func ...
 func IsUserExist(identityCode int64) bool {
 
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 			SELECT
 				'x'
 			FROM
 				users
 			WHERE
-				identity_code = (%d)
-			LIMIT 1;`, identityCode)
-	err := conn.DB.Get(&x, query)
+				identity_code = (?)
+			LIMIT 1;`
+	err := conn.Get(&x, query, identityCode)
 	if err != nil {
 		return false
 	}
@@ -724,16 +1034,16 @@ func IsUserExist(identityCode int64) bool {
 // IsUserTakeSchedule func ...
 func IsUserTakeSchedule(id, scheduleID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			p_users_schedules
 		WHERE
-			users_id = (%d) AND schedules_id = (%d)
-		LIMIT 1;`, id, scheduleID)
+			users_id = (?) AND schedules_id = (?)
+		LIMIT 1;`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, id, scheduleID)
 	if err != nil {
 		return false
 	}
@@ -743,16 +1053,15 @@ func IsUserTakeSchedule(id, scheduleID int64) bool {
 // SelectIDByIdentityCode ...
 func SelectIDByIdentityCode(identityCode []int64) ([]int64, error) {
 	var ids []int64
-	queryIdentity := strings.Join(helper.Int64ToStringSlice(identityCode), ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id
 		FROM
 			users
 		WHERE
-			identity_code IN (%s)
-		;`, queryIdentity)
-	err := conn.DB.Select(&ids, query)
+			identity_code IN (?)
+		;`
+	err := conn.Select(&ids, query, identityCode)
 	if err != nil {
 		return ids, err
 	}
@@ -760,23 +1069,116 @@ func SelectIDByIdentityCode(identityCode []int64) ([]int64, error) {
 
 }
 
+// SelectByIdentityCode function to get the users by their identity codes
+/*
+	@params:
+		identityCode	= []int64
+		column			= []string
+	@example:
+		identityCode	= [140810140016, 140810140060]
+		column			= [ColID, ColIdentityCode]
+	@return
+		[]User
+*/
+func SelectByIdentityCode(identityCode []int64, column ...string) ([]User, error) {
+	var users []User
+	if len(identityCode) < 1 {
+		return users, nil
+	}
+
+	c := []string{ColID, ColIdentityCode, ColName}
+	if len(column) > 0 {
+		c = column
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			%s
+		FROM
+			users
+		WHERE
+			identity_code IN (?);`, strings.Join(c, ", "))
+	err := conn.Select(&users, query, identityCode)
+	if err != nil {
+		return users, err
+	}
+	return users, nil
+}
+
+// SelectRegisteredIdentityCode function to get the identity codes which have been registered
+/*
+	@params:
+		identityCode	= []int64
+	@example:
+		identityCode	= [140810140016, 140810140060]
+	@return
+		identityCode	= [140810140060]
+*/
+func SelectRegisteredIdentityCode(identityCode []int64) ([]int64, error) {
+	var registered []int64
+	if len(identityCode) < 1 {
+		return registered, nil
+	}
+	query := `
+		SELECT
+			identity_code
+		FROM
+			users
+		WHERE
+			identity_code IN (?)
+		;`
+	err := conn.Select(&registered, query, identityCode)
+	if err != nil {
+		return registered, err
+	}
+	return registered, nil
+}
+
+// SelectRegisteredEmail function to get the emails which have been registered
+/*
+	@params:
+		email	= []string
+	@example:
+		email	= [risal@live.com, khairil_azmi_ashari@yahoo.com]
+	@return
+		email	= [risal@live.com]
+*/
+func SelectRegisteredEmail(email []string) ([]string, error) {
+	var registered []string
+	if len(email) < 1 {
+		return registered, nil
+	}
+	query := `
+		SELECT
+			email
+		FROM
+			users
+		WHERE
+			email IN (?)
+		;`
+	err := conn.Select(&registered, query, email)
+	if err != nil {
+		return registered, err
+	}
+	return registered, nil
+}
+
 // SelectIDByScheduleID ..
 func SelectIDByScheduleID(scheduleID int64, limit, offset int) ([]int64, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			users_id
 		FROM
 			p_users_schedules
 		WHERE
-			schedules_id = (%d)
+			schedules_id = (?)
 		ORDER BY 
 			users_id 
 		ASC
-		LIMIT %d
-		OFFSET %d;
-		`, scheduleID, limit, offset)
+		LIMIT ?
+		OFFSET ?;
+		`
 	var result []int64
-	err := conn.DB.Select(&result, query)
+	err := conn.Select(&result, query, scheduleID, limit, offset)
 	if err != nil {
 		return result, err
 	}
@@ -785,14 +1187,14 @@ func SelectIDByScheduleID(scheduleID int64, limit, offset int) ([]int64, error)
 
 // SelectCountByScheduleID ..
 func SelectCountByScheduleID(scheduleID int64) (int, error) {
-	query := fmt.Sprintf(`
+	query := `
 		SELECT COUNT(*) FROM
 			p_users_schedules
 		WHERE
-			schedules_id = (%d)
-		`, scheduleID)
+			schedules_id = (?)
+		`
 	var count int
-	err := conn.DB.Get(&count, query)
+	err := conn.Get(&count, query, scheduleID)
 	if err != nil {
 		return count, err
 	}
@@ -802,17 +1204,17 @@ func SelectCountByScheduleID(scheduleID int64) (int, error) {
 // IsExistRolegroupID ...
 func IsExistRolegroupID(rolegroupID int64) bool {
 	var x string
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			'x'
 		FROM
 			users
 		WHERE
-			rolegroups_id = (%d)
+			rolegroups_id = (?)
 		LIMIT 1;
-	`, rolegroupID)
+	`
 
-	err := conn.DB.Get(&x, query)
+	err := conn.Get(&x, query, rolegroupID)
 	if err != nil {
 		return false
 	}
@@ -820,17 +1222,36 @@ func IsExistRolegroupID(rolegroupID int64) bool {
 	return true
 }
 
+// SelectIDByRolegroupID returns id of the users which have the rolegroup
+func SelectIDByRolegroupID(rolegroupID int64) ([]int64, error) {
+	var ids []int64
+	query := `
+		SELECT
+			id
+		FROM
+			users
+		WHERE
+			rolegroups_id = (?);
+	`
+	err := conn.Select(&ids, query, rolegroupID)
+	if err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
+
 // SelectDistinctRolegroupID ...
 func SelectDistinctRolegroupID() ([]int64, error) {
 	var rolegroupsID []int64
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			DISTINCT rolegroups_id
 		FROM
 			users
 		WHERE rolegroups_id IS NOT NULL;
-	`)
-	err := conn.DB.Select(&rolegroupsID, query)
+	`
+	err := conn.Select(&rolegroupsID, query)
 	if err != nil {
 		return rolegroupsID, err
 	}
@@ -840,17 +1261,18 @@ func SelectDistinctRolegroupID() ([]int64, error) {
 
 func Search(text string) ([]User, error) {
 	var users []User
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			identity_code,
 			name
 		FROM
 			users
 		WHERE
-			identity_code LIKE '%s%%' OR
-			name LIKE '%s%%'
-		LIMIT 5`, text, text)
-	err := conn.DB.Select(&users, query)
+			identity_code LIKE (?) OR
+			name LIKE (?)
+		LIMIT 5`
+	pattern := helper.EscapeLike(text) + "%"
+	err := conn.Select(&users, query, pattern, pattern)
 	if err != nil {
 		return users, err
 	}
@@ -859,21 +1281,20 @@ func Search(text string) ([]User, error) {
 
 func SearchUninvolved(text string, usersID []int64) ([]User, error) {
 	var users []User
-	usersStr := helper.Int64ToStringSlice(usersID)
-	queryUser := strings.Join(usersStr, ", ")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			identity_code,
 			name
 		FROM
 			users
 		WHERE
-			id NOT IN (%s) AND (
-				identity_code LIKE '%s%%' OR
-				name LIKE '%s%%'
+			id NOT IN (?) AND (
+				identity_code LIKE (?) OR
+				name LIKE (?)
 			)
-		LIMIT 5`, queryUser, text, text)
-	err := conn.DB.Select(&users, query)
+		LIMIT 5`
+	pattern := helper.EscapeLike(text) + "%"
+	err := conn.Select(&users, query, usersID, pattern, pattern)
 	if err != nil {
 		return users, err
 	}
@@ -882,12 +1303,7 @@ func SearchUninvolved(text string, usersID []int64) ([]User, error) {
 
 // SelectConciseUserByID ..
 func SelectConciseUserByID(ids []int64) ([]ConciseUsers, error) {
-	var idsSt []string
-	for _, val := range ids {
-		idsSt = append(idsSt, fmt.Sprintf(`%d`, val))
-	}
-	queryIDs := strings.Join(idsSt, ",")
-	query := fmt.Sprintf(`
+	query := `
 		SELECT
 			id,
 			identity_code,
@@ -897,11 +1313,11 @@ func SelectConciseUserByID(ids []int64) ([]ConciseUsers, error) {
 		WHERE
 			id
 		IN
-			(%s)
+			(?)
 		ORDER BY id
-		`, queryIDs)
+		`
 	var result []ConciseUsers
-	err := conn.DB.Select(&result, query)
+	err := conn.Select(&result, query, ids)
 	if err != nil {
 		return result, err
 	}
@@ -227,6 +227,7 @@ func TestSignIn(t *testing.T) {
 		column []string
 		result []driver.Value
 		err    error
+		rehash bool
 	}
 	tests := []struct {
 		name    string
@@ -242,10 +243,11 @@ func TestSignIn(t *testing.T) {
 				password: "2af9b1ba42dc5eb01743e6b3759b6e4b",
 			},
 			mock: mock{
-				query:  `^\s*SELECT(\s*)id,(\s*)name,(\s*)email,(\s*)gender,(\s*)note,(\s*)status,(\s*)identity_code,(\s*)line_id,(\s*)phone,(\s*)rolegroups_id(\s*)FROM(\s*)users(\s*)WHERE(\s*)email(\s*)=(\s*)(.+)AND(\s*)password(\s*)=(\s*)(.+)(\s*)LIMIT(\s*)1;$`,
-				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id"},
-				result: []driver.Value{"1", "Risal Falah", "risal@live.com", "1", "", "2", "140810140016", nil, nil, "1"},
+				query:  `^\s*SELECT(\s*)id,(\s*)name,(\s*)email,(\s*)gender,(\s*)note,(\s*)status,(\s*)identity_code,(\s*)line_id,(\s*)phone,(\s*)rolegroups_id,(\s*)program,(\s*)cohort,(\s*)advisor_id,(\s*)phone_verified,(\s*)password(\s*)FROM(\s*)users(\s*)WHERE(\s*)email(\s*)=(\s*)\(\?\)(\s*)LIMIT(\s*)1;$`,
+				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id", "password"},
+				result: []driver.Value{"1", "Risal Falah", "risal@live.com", "1", "", "2", "140810140016", nil, nil, "1", "2af9b1ba42dc5eb01743e6b3759b6e4b"},
 				err:    nil,
+				rehash: true,
 			},
 			want: User{
 				ID:           1,
@@ -268,14 +270,29 @@ func TestSignIn(t *testing.T) {
 				password: "2af9b1ba42dc5eb01743e6b3759b6e4b",
 			},
 			mock: mock{
-				query:  `^\s*SELECT(\s*)id,(\s*)name,(\s*)email,(\s*)gender,(\s*)note,(\s*)status,(\s*)identity_code,(\s*)line_id,(\s*)phone,(\s*)rolegroups_id(\s*)FROM(\s*)users(\s*)WHERE(\s*)email(\s*)=(\s*)(.+)AND(\s*)password(\s*)=(\s*)(.+)(\s*)LIMIT(\s*)1;$`,
-				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id"},
+				query:  `^\s*SELECT(\s*)id,(\s*)name,(\s*)email,(\s*)gender,(\s*)note,(\s*)status,(\s*)identity_code,(\s*)line_id,(\s*)phone,(\s*)rolegroups_id,(\s*)program,(\s*)cohort,(\s*)advisor_id,(\s*)phone_verified,(\s*)password(\s*)FROM(\s*)users(\s*)WHERE(\s*)email(\s*)=(\s*)\(\?\)(\s*)LIMIT(\s*)1;$`,
+				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id", "password"},
 				result: []driver.Value{},
 				err:    sql.ErrNoRows,
 			},
 			want:    User{},
 			wantErr: true,
 		},
+		{
+			name: "Wrong password",
+			args: args{
+				email:    "risal@live.com",
+				password: "f1cf8402f0fb0511a8054c697fc4bee1",
+			},
+			mock: mock{
+				query:  `^\s*SELECT(\s*)id,(\s*)name,(\s*)email,(\s*)gender,(\s*)note,(\s*)status,(\s*)identity_code,(\s*)line_id,(\s*)phone,(\s*)rolegroups_id,(\s*)program,(\s*)cohort,(\s*)advisor_id,(\s*)phone_verified,(\s*)password(\s*)FROM(\s*)users(\s*)WHERE(\s*)email(\s*)=(\s*)\(\?\)(\s*)LIMIT(\s*)1;$`,
+				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id", "password"},
+				result: []driver.Value{"1", "Risal Falah", "risal@live.com", "1", "", "2", "140810140016", nil, nil, "1", "2af9b1ba42dc5eb01743e6b3759b6e4b"},
+				err:    nil,
+			},
+			want:    User{},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -287,6 +304,11 @@ func TestSignIn(t *testing.T) {
 		} else {
 			q.WillReturnError(tt.mock.err)
 		}
+		if tt.mock.rehash {
+			db.ExpectExec(`^\s*UPDATE\s*users\s*SET\s*password\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\);$`).
+				WithArgs(sqlmock.AnyArg(), int64(1)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
 
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := SignIn(tt.args.email, tt.args.password)
@@ -297,6 +319,9 @@ func TestSignIn(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SignIn() = %v, want %v", got, tt.want)
 			}
+			if err := db.ExpectationsWereMet(); err != nil {
+				t.Errorf("SignIn() expectations = %v", err)
+			}
 		})
 	}
 }
@@ -580,7 +605,7 @@ func TestUpdateStatus(t *testing.T) {
 				status:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*status\s*=\s*\(\d\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -594,7 +619,7 @@ func TestUpdateStatus(t *testing.T) {
 				status:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*status\s*=\s*\(\d\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
@@ -608,7 +633,7 @@ func TestUpdateStatus(t *testing.T) {
 				status:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*status\s*=\s*\(\d\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -618,7 +643,7 @@ func TestUpdateStatus(t *testing.T) {
 	}
 	for _, tt := range tests {
 		db, _ := conn.InitDBMock()
-		q := db.ExpectExec(tt.mock.query)
+		q := db.ExpectExec(tt.mock.query).WithArgs(tt.args.status, tt.args.identityCode)
 		if tt.mock.err == nil {
 			q.WillReturnResult(sqlmock.NewResult(tt.mock.lastInsertID, tt.mock.rowsAffected))
 		} else {
@@ -713,6 +738,9 @@ func TestChangePassword(t *testing.T) {
 		oldPassword  string
 	}
 	type mock struct {
+		querySelect  string
+		current      string
+		errSelect    error
 		query        string
 		lastInsertID int64
 		rowsAffected int64
@@ -732,7 +760,9 @@ func TestChangePassword(t *testing.T) {
 				oldPassword:  "f6ec409a28c6d93c11c056f1409ed887",
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\('([\w]*)'\)\s*WHERE\s*identity_code\s=\s\((\d+)\)\sAND\s*password\s=\s\('(\w*)'\);$`,
+				querySelect:  `^\s*SELECT\s*password\s*FROM\s*users\s*WHERE\s*identity_code\s=\s\(\?\)\s*LIMIT 1;$`,
+				current:      "f6ec409a28c6d93c11c056f1409ed887",
+				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\(\?\)\s*WHERE\s*identity_code\s=\s\(\?\)\sAND\s*password\s=\s\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -747,7 +777,9 @@ func TestChangePassword(t *testing.T) {
 				oldPassword:  "f6ec409a28c6d93c11c056f1409ed887",
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\('([\w]*)'\)\s*WHERE\s*identity_code\s=\s\((\d+)\)\sAND\s*password\s=\s\('(\w*)'\);$`,
+				querySelect:  `^\s*SELECT\s*password\s*FROM\s*users\s*WHERE\s*identity_code\s=\s\(\?\)\s*LIMIT 1;$`,
+				current:      "f6ec409a28c6d93c11c056f1409ed887",
+				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\(\?\)\s*WHERE\s*identity_code\s=\s\(\?\)\sAND\s*password\s=\s\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -762,21 +794,57 @@ func TestChangePassword(t *testing.T) {
 				oldPassword:  "f6ec409a28c6d93c11c056f1409ed887",
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\('([\w]*)'\)\s*WHERE\s*identity_code\s=\s\((\d+)\)\sAND\s*password\s=\s\('(\w*)'\);$`,
+				querySelect:  `^\s*SELECT\s*password\s*FROM\s*users\s*WHERE\s*identity_code\s=\s\(\?\)\s*LIMIT 1;$`,
+				current:      "f6ec409a28c6d93c11c056f1409ed887",
+				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\(\?\)\s*WHERE\s*identity_code\s=\s\(\?\)\sAND\s*password\s=\s\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
 			},
 			wantErr: true,
 		},
+		{
+			name: "Wrong old password",
+			args: args{
+				identityCode: 140810140016,
+				password:     "f1cf8402f0fb0511a8054c697fc4bee1",
+				oldPassword:  "f6ec409a28c6d93c11c056f1409ed887",
+			},
+			mock: mock{
+				querySelect: `^\s*SELECT\s*password\s*FROM\s*users\s*WHERE\s*identity_code\s=\s\(\?\)\s*LIMIT 1;$`,
+				current:     "2af9b1ba42dc5eb01743e6b3759b6e4b",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error connection select",
+			args: args{
+				identityCode: 140810140016,
+				password:     "f1cf8402f0fb0511a8054c697fc4bee1",
+				oldPassword:  "f6ec409a28c6d93c11c056f1409ed887",
+			},
+			mock: mock{
+				querySelect: `^\s*SELECT\s*password\s*FROM\s*users\s*WHERE\s*identity_code\s=\s\(\?\)\s*LIMIT 1;$`,
+				errSelect:   fmt.Errorf("Error connection"),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		db, _ := conn.InitDBMock()
-		q := db.ExpectExec(tt.mock.query)
-		if tt.mock.err == nil {
-			q.WillReturnResult(sqlmock.NewResult(tt.mock.lastInsertID, tt.mock.rowsAffected))
+		s := db.ExpectQuery(tt.mock.querySelect).WithArgs(tt.args.identityCode)
+		if tt.mock.errSelect == nil {
+			s.WillReturnRows(sqlmock.NewRows([]string{"password"}).AddRow(tt.mock.current))
 		} else {
-			q.WillReturnError(tt.mock.err)
+			s.WillReturnError(tt.mock.errSelect)
+		}
+		if tt.mock.query != "" {
+			q := db.ExpectExec(tt.mock.query).WithArgs(sqlmock.AnyArg(), tt.args.identityCode, tt.mock.current)
+			if tt.mock.err == nil {
+				q.WillReturnResult(sqlmock.NewResult(tt.mock.lastInsertID, tt.mock.rowsAffected))
+			} else {
+				q.WillReturnError(tt.mock.err)
+			}
 		}
 
 		t.Run(tt.name, func(t *testing.T) {
@@ -811,7 +879,7 @@ func TestForgotNewPassword(t *testing.T) {
 				password: "f1cf8402f0fb0511a8054c697fc4bee1",
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\('([\w]*)'\),\s*email_verification_code\s=\sNULL,\s*email_verification_expire_date\s=\sNULL,\s*email_verification_attempt\s=\sNULL,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*email\s=\s\('([\w@.]*)'\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\(\?\),\s*email_verification_code\s=\sNULL,\s*email_verification_expire_date\s=\sNULL,\s*email_verification_attempt\s=\sNULL,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*email\s=\s\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -825,7 +893,7 @@ func TestForgotNewPassword(t *testing.T) {
 				password: "f1cf8402f0fb0511a8054c697fc4bee1",
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\('([\w]*)'\),\s*email_verification_code\s=\sNULL,\s*email_verification_expire_date\s=\sNULL,\s*email_verification_attempt\s=\sNULL,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*email\s=\s\('([\w@.]*)'\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\(\?\),\s*email_verification_code\s=\sNULL,\s*email_verification_expire_date\s=\sNULL,\s*email_verification_attempt\s=\sNULL,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*email\s=\s\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -839,7 +907,7 @@ func TestForgotNewPassword(t *testing.T) {
 				password: "f1cf8402f0fb0511a8054c697fc4bee1",
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\('([\w]*)'\),\s*email_verification_code\s=\sNULL,\s*email_verification_expire_date\s=\sNULL,\s*email_verification_attempt\s=\sNULL,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*email\s=\s\('([\w@.]*)'\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*password\s=\s\(\?\),\s*email_verification_code\s=\sNULL,\s*email_verification_expire_date\s=\sNULL,\s*email_verification_attempt\s=\sNULL,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*email\s=\s\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
@@ -887,7 +955,7 @@ func TestGenerateVerification(t *testing.T) {
 				identity: 140810140016,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*email_verification_code\s=\s\(\d+\),\s*email_verification_expire_date\s=\s\(DATE_ADD\(NOW\(\), INTERVAL 30 MINUTE\)\),\s*email_verification_attempt\s*=\s*0,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\d+\);`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*email_verification_code\s=\s\(\?\),\s*email_verification_expire_date\s=\s\(DATE_ADD\(NOW\(\), INTERVAL 30 MINUTE\)\),\s*email_verification_attempt\s*=\s*0,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\?\);`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -906,7 +974,7 @@ func TestGenerateVerification(t *testing.T) {
 				identity: 140810140016,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*email_verification_code\s=\s\(\d+\),\s*email_verification_expire_date\s=\s\(DATE_ADD\(NOW\(\), INTERVAL 30 MINUTE\)\),\s*email_verification_attempt\s*=\s*0,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\d+\);`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*email_verification_code\s=\s\(\?\),\s*email_verification_expire_date\s=\s\(DATE_ADD\(NOW\(\), INTERVAL 30 MINUTE\)\),\s*email_verification_attempt\s*=\s*0,\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\?\);`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -976,7 +1044,7 @@ func TestUpdateProfile(t *testing.T) {
 				gender:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\('([\w ]*)'\),\s*phone\s=\s\('\d+'\),\s*line_id\s=\s\('(.+)'\),\s*note\s=\s\('(.+),\s*gender\s=\s\(\d\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\d+\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\(\?\),\s*phone_verified\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verified,\s0\),\s*phone_verification_code\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verification_code,\sNULL\),\s*phone\s=\s\(\?\),\s*line_id\s=\s\(\?\),\s*note\s=\s\(\?\),\s*gender\s=\s\(\?\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -994,7 +1062,7 @@ func TestUpdateProfile(t *testing.T) {
 				gender:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\('([\w ]*)'\),\s*phone\s=\s\('\d+'\),\s*line_id\s=\s\('(.+)'\),\s*note\s=\s\('(.+),\s*gender\s=\s\(\d\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\d+\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\(\?\),\s*phone_verified\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verified,\s0\),\s*phone_verification_code\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verification_code,\sNULL\),\s*phone\s=\s\(\?\),\s*line_id\s=\s\(\?\),\s*note\s=\s\(\?\),\s*gender\s=\s\(\?\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -1012,7 +1080,7 @@ func TestUpdateProfile(t *testing.T) {
 				gender:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\('([\w ]*)'\),\s*phone\s=\s\('\d+'\),\s*line_id\s=\s\('(.+)'\),\s*note\s=\s\('(.+),\s*gender\s=\s\(\d\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\d+\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\(\?\),\s*phone_verified\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verified,\s0\),\s*phone_verification_code\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verification_code,\sNULL\),\s*phone\s=\s\(\?\),\s*line_id\s=\s\(\?\),\s*note\s=\s\(\?\),\s*gender\s=\s\(\?\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
@@ -1030,7 +1098,7 @@ func TestUpdateProfile(t *testing.T) {
 				gender:       3,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\('([\w ]*)'\),\s*phone\s=\s\('\d+'\),\s*line_id\s=\s\('(.+)'\),\s*note\s=\s\('(.+),\s*gender\s=\s\(\d\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\d+\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s=\s\(\?\),\s*phone_verified\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verified,\s0\),\s*phone_verification_code\s=\sIF\(phone\s<=>\s\(\?\),\sphone_verification_code,\sNULL\),\s*phone\s=\s\(\?\),\s*line_id\s=\s\(\?\),\s*note\s=\s\(\?\),\s*gender\s=\s\(\?\),\s*updated_at\s=\sNOW\(\)\s*WHERE\s*identity_code\s=\s\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -1083,7 +1151,7 @@ func TestSignUp(t *testing.T) {
 				password:     "2af9b1ba42dc5eb01743e6b3759b6e4b",
 			},
 			mock: mock{
-				query:        `^\s*INSERT\sINTO\s*users\s\(\s*name,\s*email,\s*password,\s*identity_code,\s*created_at,\s*updated_at\s*\)\sVALUES\s\(\s*\('([\w ]*)'\),\s*\('([\w@.]+)'\),\s*\('([\w]+)'\),\s*\(\d+\)\s*,\s*NOW\(\),\s*NOW\(\)\s*\);$`,
+				query:        `^\s*INSERT\sINTO\s*users\s\(\s*name,\s*email,\s*password,\s*identity_code,\s*created_at,\s*updated_at\s*\)\sVALUES\s\(\s*\(\?\),\s*\(\?\),\s*\(\?\),\s*\(\?\)\s*,\s*NOW\(\),\s*NOW\(\)\s*\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -1099,7 +1167,7 @@ func TestSignUp(t *testing.T) {
 				password:     "2af9b1ba42dc5eb01743e6b3759b6e4b",
 			},
 			mock: mock{
-				query:        `^\s*INSERT\sINTO\s*users\s\(\s*name,\s*email,\s*password,\s*identity_code,\s*created_at,\s*updated_at\s*\)\sVALUES\s\(\s*\('([\w ]*)'\),\s*\('([\w@.]+)'\),\s*\('([\w]+)'\),\s*\(\d+\)\s*,\s*NOW\(\),\s*NOW\(\)\s*\);$`,
+				query:        `^\s*INSERT\sINTO\s*users\s\(\s*name,\s*email,\s*password,\s*identity_code,\s*created_at,\s*updated_at\s*\)\sVALUES\s\(\s*\(\?\),\s*\(\?\),\s*\(\?\),\s*\(\?\)\s*,\s*NOW\(\),\s*NOW\(\)\s*\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -1115,7 +1183,7 @@ func TestSignUp(t *testing.T) {
 				password:     "2af9b1ba42dc5eb01743e6b3759b6e4b",
 			},
 			mock: mock{
-				query:        `^\s*INSERT\sINTO\s*users\s\(\s*name,\s*email,\s*password,\s*identity_code,\s*created_at,\s*updated_at\s*\)\sVALUES\s\(\s*\('([\w ]*)'\),\s*\('([\w@.]+)'\),\s*\('([\w]+)'\),\s*\(\d+\)\s*,\s*NOW\(\),\s*NOW\(\)\s*\);$`,
+				query:        `^\s*INSERT\sINTO\s*users\s\(\s*name,\s*email,\s*password,\s*identity_code,\s*created_at,\s*updated_at\s*\)\sVALUES\s\(\s*\(\?\),\s*\(\?\),\s*\(\?\),\s*\(\?\)\s*,\s*NOW\(\),\s*NOW\(\)\s*\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
@@ -1166,7 +1234,7 @@ func TestSelectByID(t *testing.T) {
 				column: []string{ColID, ColName, ColEmail, ColPhone},
 			},
 			mock: mock{
-				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([\d, ]*)\)\s*;$`,
+				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([?, ]*)\)\s*;$`,
 				column: []string{"id", "name", "email", "phone"},
 				result: [][]driver.Value{
 					[]driver.Value{
@@ -1211,7 +1279,7 @@ func TestSelectByID(t *testing.T) {
 				isSort: true,
 			},
 			mock: mock{
-				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([\d, ]*)\)\s*ORDER BY identity_code ASC;$`,
+				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([?, ]*)\)\s*ORDER BY identity_code ASC;$`,
 				column: []string{"id", "name", "email", "phone"},
 				result: [][]driver.Value{
 					[]driver.Value{
@@ -1255,7 +1323,7 @@ func TestSelectByID(t *testing.T) {
 				column: []string{},
 			},
 			mock: mock{
-				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([\d, ]*)\)\s*;$`,
+				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([?, ]*)\)\s*;$`,
 				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id"},
 				result: [][]driver.Value{
 					[]driver.Value{"1", "Risal Falah", "risal@live.com", "1", "", "2", "140810140016", nil, nil, nil},
@@ -1285,7 +1353,7 @@ func TestSelectByID(t *testing.T) {
 				column: []string{},
 			},
 			mock: mock{
-				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([\d, ]*)\)\s*;$`,
+				query:  `^\s*SELECT\s*(.+)\s*FROM\s*users\s*WHERE\s*id\s*IN\s*\(([?, ]*)\)\s*;$`,
 				column: []string{"id", "name", "email", "gender", "note", "status", "identity_code", "line_id", "phone", "rolegroups_id"},
 				result: nil,
 				err:    fmt.Errorf("Error connection"),
@@ -1354,7 +1422,7 @@ func TestUpdate(t *testing.T) {
 				status:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\('[\w ]*'\),\s*phone\s*=\s*\('\d*'\),\s*line_id\s*=\s*\('\w*'\),\s*note\s*=\s*(.+),\s*gender\s*=\s*\([012]\),\s*status\s*=\s*\([012]\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\(\?\),\s*phone_verified\s*=\s*IF\(phone\s*<=>\s*\(\?\),\s*phone_verified,\s*0\),\s*phone_verification_code\s*=\s*IF\(phone\s*<=>\s*\(\?\),\s*phone_verification_code,\s*NULL\),\s*phone\s*=\s*\(\?\),\s*line_id\s*=\s*\(\?\),\s*note\s*=\s*\(\?\),\s*gender\s*=\s*\(\?\),\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -1373,7 +1441,7 @@ func TestUpdate(t *testing.T) {
 				status:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\('[\w ]*'\),\s*phone\s*=\s*\('\d*'\),\s*line_id\s*=\s*\('\w*'\),\s*note\s*=\s*(.+),\s*gender\s*=\s*\([012]\),\s*status\s*=\s*\([012]\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\(\?\),\s*phone_verified\s*=\s*IF\(phone\s*<=>\s*\(\?\),\s*phone_verified,\s*0\),\s*phone_verification_code\s*=\s*IF\(phone\s*<=>\s*\(\?\),\s*phone_verification_code,\s*NULL\),\s*phone\s*=\s*\(\?\),\s*line_id\s*=\s*\(\?\),\s*note\s*=\s*\(\?\),\s*gender\s*=\s*\(\?\),\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -1392,7 +1460,7 @@ func TestUpdate(t *testing.T) {
 				status:       1,
 			},
 			mock: mock{
-				query:        `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\('[\w ]*'\),\s*phone\s*=\s*\('\d*'\),\s*line_id\s*=\s*\('\w*'\),\s*note\s*=\s*(.+),\s*gender\s*=\s*\([012]\),\s*status\s*=\s*\([012]\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\(\?\),\s*phone_verified\s*=\s*IF\(phone\s*<=>\s*\(\?\),\s*phone_verified,\s*0\),\s*phone_verification_code\s*=\s*IF\(phone\s*<=>\s*\(\?\),\s*phone_verification_code,\s*NULL\),\s*phone\s*=\s*\(\?\),\s*line_id\s*=\s*\(\?\),\s*note\s*=\s*\(\?\),\s*gender\s*=\s*\(\?\),\s*status\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
@@ -1438,7 +1506,7 @@ func TestDelete(t *testing.T) {
 				identityCode: 140810140016,
 			},
 			mock: mock{
-				query:        `^\s*DELETE\s*FROM\s*users\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*DELETE\s*FROM\s*users\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -1451,7 +1519,7 @@ func TestDelete(t *testing.T) {
 				identityCode: 140810140016,
 			},
 			mock: mock{
-				query:        `^\s*DELETE\s*FROM\s*users\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*DELETE\s*FROM\s*users\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -1464,7 +1532,7 @@ func TestDelete(t *testing.T) {
 				identityCode: 140810140016,
 			},
 			mock: mock{
-				query:        `^\s*DELETE\s*FROM\s*users\s*WHERE\s*identity_code\s*=\s*\(\d*\);$`,
+				query:        `^\s*DELETE\s*FROM\s*users\s*WHERE\s*identity_code\s*=\s*\(\?\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          fmt.Errorf("Error connection"),
@@ -1474,7 +1542,7 @@ func TestDelete(t *testing.T) {
 	}
 	for _, tt := range tests {
 		db, _ := conn.InitDBMock()
-		q := db.ExpectExec(tt.mock.query)
+		q := db.ExpectExec(tt.mock.query).WithArgs(tt.args.identityCode)
 		if tt.mock.err == nil {
 			q.WillReturnResult(sqlmock.NewResult(tt.mock.lastInsertID, tt.mock.rowsAffected))
 		} else {
@@ -1488,6 +1556,207 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestUpdateRolegroup(t *testing.T) {
+	type args struct {
+		identityCode int64
+		rolegroupID  sql.NullInt64
+	}
+	type mock struct {
+		query        string
+		rowsAffected int64
+		err          error
+	}
+	query := `^\s*UPDATE\s*users\s*SET\s*rolegroups_id\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`
+	tests := []struct {
+		name    string
+		args    args
+		mock    mock
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1",
+			args: args{
+				identityCode: 140810140016,
+				rolegroupID:  sql.NullInt64{Int64: 2, Valid: true},
+			},
+			mock: mock{
+				query:        query,
+				rowsAffected: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test Case 2",
+			args: args{
+				identityCode: 140810140016,
+			},
+			mock: mock{
+				query:        query,
+				rowsAffected: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 3",
+			args: args{
+				identityCode: 140810140016,
+				rolegroupID:  sql.NullInt64{Int64: 2, Valid: true},
+			},
+			mock: mock{
+				query: query,
+				err:   fmt.Errorf("Error connection"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(tt.mock.query).WithArgs(tt.args.rolegroupID, tt.args.identityCode)
+		if tt.mock.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.mock.rowsAffected))
+		} else {
+			q.WillReturnError(tt.mock.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdateRolegroup(tt.args.identityCode, tt.args.rolegroupID); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateRolegroup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateAdvisor(t *testing.T) {
+	type args struct {
+		identityCode int64
+		advisorID    sql.NullInt64
+	}
+	type mock struct {
+		query        string
+		rowsAffected int64
+		err          error
+	}
+	query := `^\s*UPDATE\s*users\s*SET\s*advisor_id\s*=\s*\(\?\),\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*identity_code\s*=\s*\(\?\);$`
+	tests := []struct {
+		name    string
+		args    args
+		mock    mock
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1",
+			args: args{
+				identityCode: 140810140016,
+				advisorID:    sql.NullInt64{Int64: 2000000001, Valid: true},
+			},
+			mock: mock{
+				query:        query,
+				rowsAffected: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test Case 2",
+			args: args{
+				identityCode: 140810140016,
+			},
+			mock: mock{
+				query:        query,
+				rowsAffected: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 3",
+			args: args{
+				identityCode: 140810140016,
+				advisorID:    sql.NullInt64{Int64: 2000000001, Valid: true},
+			},
+			mock: mock{
+				query: query,
+				err:   fmt.Errorf("Error connection"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(tt.mock.query).WithArgs(tt.args.advisorID, tt.args.identityCode)
+		if tt.mock.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.mock.rowsAffected))
+		} else {
+			q.WillReturnError(tt.mock.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if err := UpdateAdvisor(tt.args.identityCode, tt.args.advisorID); (err != nil) != tt.wantErr {
+				t.Errorf("UpdateAdvisor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectByAdvisorID(t *testing.T) {
+	query := `^\s*SELECT\s*id,\s*name,\s*email,\s*identity_code,\s*program,\s*cohort\s*FROM\s*users\s*WHERE\s*advisor_id\s*=\s*\(\?\)\s*ORDER\s*BY\s*identity_code\s*ASC;$`
+	tests := []struct {
+		name    string
+		rows    [][]driver.Value
+		err     error
+		want    []User
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1",
+			rows: [][]driver.Value{
+				[]driver.Value{"1", "Risal Falah", "risal@live.com", "140810140016", "Teknik Informatika", "2014"},
+				[]driver.Value{"2", "Rifki Muhammad", "rifki@live.com", "140810140020", nil, nil},
+			},
+			want: []User{
+				User{
+					ID:           1,
+					Name:         "Risal Falah",
+					Email:        "risal@live.com",
+					IdentityCode: 140810140016,
+					Program:      sql.NullString{String: "Teknik Informatika", Valid: true},
+					Cohort:       sql.NullInt64{Int64: 2014, Valid: true},
+				},
+				User{
+					ID:           2,
+					Name:         "Rifki Muhammad",
+					Email:        "rifki@live.com",
+					IdentityCode: 140810140020,
+				},
+			},
+		},
+		{
+			name:    "Test Case 2",
+			err:     fmt.Errorf("Error connection"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectQuery(query).WithArgs(2000000001)
+		if tt.err == nil {
+			rows := sqlmock.NewRows([]string{"id", "name", "email", "identity_code", "program", "cohort"})
+			for _, v := range tt.rows {
+				rows.AddRow(v...)
+			}
+			q.WillReturnRows(rows)
+		} else {
+			q.WillReturnError(tt.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectByAdvisorID(2000000001)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SelectByAdvisorID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectByAdvisorID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	type args struct {
 		identityCode int64
@@ -1514,7 +1783,7 @@ func TestCreate(t *testing.T) {
 				email:        "risal@live.com",
 			},
 			mock: mock{
-				query:        `^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\('[\w ]*'\),\s*\('[\w@.]*'\),\s*\('[\S]'\),\s*\(\d*\),\s*\([012]\),\s*NOW\(\),\s*NOW\(\)\s*\);$`,
+				query:        `^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\(\?\),\s*\(\?\),\s*\('[\S]'\),\s*\(\?\),\s*\(\?\),\s*NOW\(\),\s*NOW\(\)\s*\);$`,
 				lastInsertID: 1,
 				rowsAffected: 1,
 				err:          nil,
@@ -1529,7 +1798,7 @@ func TestCreate(t *testing.T) {
 				email:        "risal@live.com",
 			},
 			mock: mock{
-				query:        `^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\('[\w ]*'\),\s*\('[\w@.]*'\),\s*\('[\S]'\),\s*\(\d*\),\s*\([012]\),\s*NOW\(\),\s*NOW\(\)\s*\);$`,
+				query:        `^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\(\?\),\s*\(\?\),\s*\('[\S]'\),\s*\(\?\),\s*\(\?\),\s*NOW\(\),\s*NOW\(\)\s*\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          nil,
@@ -1544,7 +1813,7 @@ func TestCreate(t *testing.T) {
 				email:        "risal@live.com",
 			},
 			mock: mock{
-				query:        `^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\('[\w ]*'\),\s*\('[\w@.]*'\),\s*\('[\S]'\),\s*\(\d*\),\s*\([012]\),\s*NOW\(\),\s*NOW\(\)\s*\);$`,
+				query:        `^\s*INSERT\s*INTO\s*users\s*\(\s*name,\s*email,\s*password,\s*identity_code,\s*status,\s*created_at,\s*updated_at\s*\)\s*VALUES\s*\(\s*\(\?\),\s*\(\?\),\s*\('[\S]'\),\s*\(\?\),\s*\(\?\),\s*NOW\(\),\s*NOW\(\)\s*\);$`,
 				lastInsertID: 0,
 				rowsAffected: 0,
 				err:          fmt.Errorf("Error connection"),
@@ -1561,7 +1830,7 @@ func TestCreate(t *testing.T) {
 			q.WillReturnError(tt.mock.err)
 		}
 		t.Run(tt.name, func(t *testing.T) {
-			if err := Create(tt.args.identityCode, tt.args.name, tt.args.email); (err != nil) != tt.wantErr {
+			if _, err := Create(tt.args.identityCode, tt.args.name, tt.args.email); (err != nil) != tt.wantErr {
 				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -1571,6 +1840,7 @@ func TestCreate(t *testing.T) {
 func TestSelectDashboard(t *testing.T) {
 	type args struct {
 		id      int64
+		filter  DashboardFilter
 		limit   int
 		offset  int
 		isCount bool
@@ -1599,13 +1869,13 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			mocks: []mock{
 				mock{
-					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status,(\s)*program,(\s)*cohort(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 					column: []string{"identity_code", "name", "email", "status"},
 					result: [][]driver.Value{},
 					err:    nil,
 				},
 				mock{
-					query:  `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\d\)\s*OR\s*status\s*=\s*\(\d\)\)\s*AND\s*id\s*!=\s*\(\d\);$`,
+					query:  `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\?\)\s*OR\s*status\s*=\s*\(\?\)\)\s*AND\s*id\s*!=\s*\(\?\);$`,
 					column: []string{"count(*)"},
 					result: [][]driver.Value{
 						[]driver.Value{
@@ -1626,7 +1896,7 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			mocks: []mock{
 				mock{
-					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status,(\s)*program,(\s)*cohort(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 					column: []string{"identity_code", "name", "email", "status"},
 					result: [][]driver.Value{},
 					err:    nil,
@@ -1643,7 +1913,7 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			mocks: []mock{
 				mock{
-					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status,(\s)*program,(\s)*cohort(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 					column: []string{"identity_code", "name", "email", "status"},
 					result: [][]driver.Value{
 						[]driver.Value{
@@ -1672,7 +1942,7 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			mocks: []mock{
 				mock{
-					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status,(\s)*program,(\s)*cohort(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 					column: []string{"identity_code", "name", "email", "status"},
 					result: [][]driver.Value{
 						[]driver.Value{
@@ -1685,7 +1955,7 @@ func TestSelectDashboard(t *testing.T) {
 					err: nil,
 				},
 				mock{
-					query:  `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\d\)\s*OR\s*status\s*=\s*\(\d\)\)\s*AND\s*id\s*!=\s*\(\d\);$`,
+					query:  `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\?\)\s*OR\s*status\s*=\s*\(\?\)\)\s*AND\s*id\s*!=\s*\(\?\);$`,
 					column: []string{"count(*)"},
 					result: [][]driver.Value{
 						[]driver.Value{
@@ -1711,6 +1981,51 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			want1: 2,
 		},
+		{
+			name: "With Filter",
+			args: args{
+				id: 2,
+				filter: DashboardFilter{
+					Program:   "Teknik Informatika",
+					Cohort:    2014,
+					AdvisorID: 3,
+				},
+				limit:   1,
+				offset:  0,
+				isCount: true,
+			},
+			mocks: []mock{
+				mock{
+					query:  `^SELECT\s*identity_code,\s*name,\s*email,\s*status,\s*program,\s*cohort\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\?\)\s*OR\s*status\s*=\s*\(\?\)\)\s*AND\s*id\s*!=\s*\(\?\)\s*AND\s*program\s*=\s*\(\?\)\s*AND\s*cohort\s*=\s*\(\?\)\s*AND\s*advisor_id\s*=\s*\(\?\)\s*LIMIT\s*\?\s*OFFSET\s*\?;$`,
+					column: []string{"identity_code", "name", "email", "status"},
+					result: [][]driver.Value{
+						[]driver.Value{
+							"140810140016", "Risal Falah", "risal@live.com", "2",
+						},
+					},
+					err: nil,
+				},
+				mock{
+					query:  `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\?\)\s*OR\s*status\s*=\s*\(\?\)\)\s*AND\s*id\s*!=\s*\(\?\)\s*AND\s*program\s*=\s*\(\?\)\s*AND\s*cohort\s*=\s*\(\?\)\s*AND\s*advisor_id\s*=\s*\(\?\);$`,
+					column: []string{"count(*)"},
+					result: [][]driver.Value{
+						[]driver.Value{
+							"1",
+						},
+					},
+					err: nil,
+				},
+			},
+			want: []User{
+				User{
+					IdentityCode: 140810140016,
+					Name:         "Risal Falah",
+					Email:        "risal@live.com",
+					Status:       2,
+				},
+			},
+			want1: 1,
+		},
 		{
 			name: "Error Connection First Query",
 			args: args{
@@ -1721,7 +2036,7 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			mocks: []mock{
 				mock{
-					query: `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+					query: `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status,(\s)*program,(\s)*cohort(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 					err:   fmt.Errorf("Error Connection"),
 				},
 			},
@@ -1737,7 +2052,7 @@ func TestSelectDashboard(t *testing.T) {
 			},
 			mocks: []mock{
 				mock{
-					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+					query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status,(\s)*program,(\s)*cohort(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 					column: []string{"identity_code", "name", "email", "status"},
 					result: [][]driver.Value{
 						[]driver.Value{
@@ -1750,7 +2065,7 @@ func TestSelectDashboard(t *testing.T) {
 					err: nil,
 				},
 				mock{
-					query: `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\d\)\s*OR\s*status\s*=\s*\(\d\)\)\s*AND\s*id\s*!=\s*\(\d\);$`,
+					query: `^SELECT\s*COUNT\(\*\)\s*FROM\s*users\s*WHERE\s*\(status\s*=\s*\(\?\)\s*OR\s*status\s*=\s*\(\?\)\)\s*AND\s*id\s*!=\s*\(\?\);$`,
 					err:   fmt.Errorf("Error connection"),
 				},
 			},
@@ -1787,7 +2102,7 @@ func TestSelectDashboard(t *testing.T) {
 		}
 
 		t.Run(tt.name, func(t *testing.T) {
-			got, got1, err := SelectDashboard(tt.args.id, tt.args.limit, tt.args.offset, tt.args.isCount)
+			got, got1, err := SelectDashboard(tt.args.id, tt.args.filter, tt.args.limit, tt.args.offset, tt.args.isCount)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("SelectDashboard() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1829,7 +2144,7 @@ func TestSelectDashboard(t *testing.T) {
 // 				offset: 10,
 // 			},
 // mock: mock{
-// 	query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\d\)(\s)*OR(\s)*status(\s)*=(\s)*\(\d\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\d\)(\s)*LIMIT(\s)*\d(\s)*OFFSET(\s)*\d;$`,
+// 	query:  `^SELECT(\s)*identity_code,(\s)*name,(\s)*email,(\s)*status(\s)*FROM(\s)*users(\s)*WHERE(\s)*\(status(\s)*=(\s)*\(\?\)(\s)*OR(\s)*status(\s)*=(\s)*\(\?\)\)(\s)*AND(\s)*id(\s)*!=(\s)*\(\?\)(\s)*LIMIT(\s)*\?(\s)*OFFSET(\s)*\?;$`,
 // 	column: []string{"identity_code", "name", "email", "status"},
 // 	result: [][]driver.Value{
 // 		[]driver.Value{
@@ -1907,3 +2222,97 @@ func TestSelectDashboard(t *testing.T) {
 // 		})
 // 	}
 // }
+
+func TestSelectRegisteredEmail(t *testing.T) {
+	query := `^\s*SELECT\s*email\s*FROM\s*users\s*WHERE\s*email\s*IN\s*\(\?, \?\)\s*;$`
+	tests := []struct {
+		name    string
+		email   []string
+		rows    []string
+		err     error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "Test Case 1",
+			email: []string{"risal@live.com", "khairil_azmi_ashari@yahoo.com"},
+			rows:  []string{"risal@live.com"},
+			want:  []string{"risal@live.com"},
+		},
+		{
+			name:    "Test Case 2",
+			email:   []string{"risal@live.com", "khairil_azmi_ashari@yahoo.com"},
+			err:     fmt.Errorf("Error connection"),
+			wantErr: true,
+		},
+		{
+			name:  "Test Case 3",
+			email: []string{},
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		if len(tt.email) > 0 {
+			q := db.ExpectQuery(query).WithArgs("risal@live.com", "khairil_azmi_ashari@yahoo.com")
+			if tt.err == nil {
+				rows := sqlmock.NewRows([]string{"email"})
+				for _, v := range tt.rows {
+					rows.AddRow(v)
+				}
+				q.WillReturnRows(rows)
+			} else {
+				q.WillReturnError(tt.err)
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectRegisteredEmail(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SelectRegisteredEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectRegisteredEmail() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnonymize(t *testing.T) {
+	query := `^\s*UPDATE\s*users\s*SET\s*name\s*=\s*\(\?\),\s*email\s*=\s*\(\?\),\s*password\s*=\s*\('x'\),.*identity_code\s*=\s*\(\?\),\s*status\s*=\s*\(\?\),.*sso_subject\s*=\s*NULL,\s*updated_at\s*=\s*NOW\(\)\s*WHERE\s*id\s*=\s*\(\?\);$`
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		err          error
+		wantErr      bool
+	}{
+		{
+			name:         "Test Case 1",
+			rowsAffected: 1,
+		},
+		{
+			name:         "Test Case 2",
+			rowsAffected: 0,
+			wantErr:      true,
+		},
+		{
+			name:    "Test Case 3",
+			err:     fmt.Errorf("Error connection"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		db, _ := conn.InitDBMock()
+		q := db.ExpectExec(query).
+			WithArgs(AnonymousName, "erased.12@meiko.invalid", GenderUndefined, "900000000000000012", StatusUnverified, 12)
+		if tt.err == nil {
+			q.WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+		} else {
+			q.WillReturnError(tt.err)
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Anonymize(12); (err != nil) != tt.wantErr {
+				t.Errorf("Anonymize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,4 +16,6 @@ const (
 	UserEmailLengthMax    = 45
 	UserCollegeLengthMax  = 45
 	UserNoteLengthMax     = 100
+	UserProgramLengthMax  = 100
+	UserCohortMin         = 1900
 )
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/melodiez14/meiko/src/module/apikey"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+const (
+	apiKeyPrefix       = "mk_"
+	apiKeyPrefixLength = len(apiKeyPrefix) + 8
+)
+
+// NewAPIKey generates a new personal API key. Only the prefix and the hash should be stored,
+// the key has to be shown to its owner once and can't be recovered
+func NewAPIKey() (key, prefix, hash string, err error) {
+	token, err := newToken()
+	if err != nil {
+		return "", "", "", err
+	}
+	key = apiKeyPrefix + token
+	return key, key[:apiKeyPrefixLength], HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash of the API key to be stored and looked up.
+// API keys are random, so an unsalted fast hash is sufficient unlike passwords
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerToken returns the token of Authorization: Bearer header
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return "", false
+	}
+	token := strings.TrimSpace(header[7:])
+	return token, len(token) > 0
+}
+
+// getAPIKeyUser resolves the owner of the API key. The roles of the owner are narrowed
+// to the scopes of the key, so a key never has more privilege than its owner currently has
+func getAPIKeyUser(key string) (*User, error) {
+	k, err := apikey.GetByHash(HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := user.SelectByID([]int64{k.UserID}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 || users[0].Status != user.StatusActivated {
+		return nil, errSessionNotlogin
+	}
+	u := users[0]
+
+	roles := map[string][]string{}
+	if u.RoleGroupsID.Valid {
+		roles, err = rg.SelectModuleAccess(u.RoleGroupsID.Int64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scopes := map[string][]string{}
+	err = json.Unmarshal([]byte(k.Scopes), &scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	go apikey.UpdateLastUsed(k.ID)
+
+	return &User{
+		ID:            u.ID,
+		Name:          u.Name,
+		Email:         u.Email,
+		Gender:        u.Gender,
+		Note:          u.Note,
+		Status:        u.Status,
+		IdentityCode:  u.IdentityCode,
+		LineID:        u.LineID.String,
+		Phone:         u.Phone.String,
+		PhoneVerified: u.PhoneVerified,
+		Program:       u.Program.String,
+		Cohort:        uint16(u.Cohort.Int64),
+		AdvisorID:     u.AdvisorID.Int64,
+		Roles:         scopeRoles(roles, scopes),
+		APIKeyID:      k.ID,
+	}, nil
+}
+
+// scopeRoles returns the abilities which exist on both roles and scopes
+func scopeRoles(roles, scopes map[string][]string) map[string][]string {
+	res := map[string][]string{}
+	for module, abilities := range scopes {
+		for _, val := range abilities {
+			if helper.IsStringInSlice(val, roles[module]) {
+				res[module] = append(res[module], val)
+			}
+		}
+	}
+	return res
+}
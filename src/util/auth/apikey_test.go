@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	if err != nil {
+		t.Errorf("NewAPIKey() error = %v", err)
+		return
+	}
+	if !strings.HasPrefix(key, apiKeyPrefix) || !strings.HasPrefix(key, prefix) {
+		t.Errorf("NewAPIKey() key = %v, prefix = %v", key, prefix)
+	}
+	if len(prefix) != apiKeyPrefixLength {
+		t.Errorf("NewAPIKey() prefix length = %d, want %d", len(prefix), apiKeyPrefixLength)
+	}
+	if hash != HashAPIKey(key) || len(hash) != 64 {
+		t.Errorf("NewAPIKey() hash = %v", hash)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+		wantOK bool
+	}{
+		{name: "No header", header: "", want: "", wantOK: false},
+		{name: "Bearer", header: "Bearer mk_abc", want: "mk_abc", wantOK: true},
+		{name: "Lowercase", header: "bearer token", want: "token", wantOK: true},
+		{name: "Empty token", header: "Bearer  ", want: "", wantOK: false},
+		{name: "Basic", header: "Basic dXNlcjpwYXNz", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/course", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			got, ok := bearerToken(r)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("bearerToken() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestScopeRoles(t *testing.T) {
+	roles := map[string][]string{
+		"courses": {"READ", "CREATE"},
+		"users":   {"XREAD"},
+	}
+	scopes := map[string][]string{
+		"courses": {"READ", "DELETE"},
+		"roles":   {"XREAD"},
+	}
+	want := map[string][]string{
+		"courses": {"READ"},
+	}
+	if got := scopeRoles(roles, scopes); !reflect.DeepEqual(got, want) {
+		t.Errorf("scopeRoles() = %v, want %v", got, want)
+	}
+}
+
+func TestRejectAPIKey(t *testing.T) {
+	tests := []struct {
+		name string
+		user *User
+		want int
+	}{
+		{name: "Signed in", user: &User{ID: 1}, want: http.StatusOK},
+		{name: "API key", user: &User{ID: 1, APIKeyID: 7}, want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/user/sessions", nil)
+			r = r.WithContext(context.WithValue(r.Context(), "User", tt.user))
+			w := httptest.NewRecorder()
+			rejectAPIKey(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+				w.WriteHeader(http.StatusOK)
+			})(w, r, nil)
+			if w.Code != tt.want {
+				t.Errorf("rejectAPIKey() code = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -20,38 +20,65 @@ import (
 
 type (
 	Config struct {
-		SessionKey string `json:"sessionkey"`
+		SessionKey     string        `json:"sessionkey"`
+		SessionTTL     int64         `json:"sessionttl"`
+		SecureCookie   bool          `json:"securecookie"`
+		CSRFKey        string        `json:"csrfkey"`
+		CSRFHeader     string        `json:"csrfheader"`
+		TrustedOrigins []string      `json:"trustedorigins"`
+		Lockout        LockoutConfig `json:"lockout"`
+		LinkSecret     string        `json:"linksecret"`
+		LinkURL        string        `json:"linkurl"`
+		TrustedProxies []string      `json:"trustedproxies"`
+
+		// trustedProxies are the parsed IPs or CIDRs of the reverse proxies whose X-Forwarded-For is honoured
+		trustedProxies []*net.IPNet
 	}
 )
 
 const (
-	sessionPrefix     = "session:"
-	character         = "!QAZ@WSX#EDC$RFV%TGB^YHN&UJM*IK<(OL>)P:?_{+}|1qaz2wsx3edc4rfv5tgb6yhn7ujm8ik,9ol.0p-[=]"
-	listPrefixSession = "session:list:"
+	sessionPrefix        = "session:"
+	listPrefixSession    = "session:list:"
+	devicePrefixSession  = "session:device:"
+	versionPrefixSession = "session:version:"
+	defaultSessionTTL    = 7 * 24 * 60 * 60
+	defaultCSRFKey       = "_CSRF_Meiko_"
+	defaultCSRFHeader    = "X-CSRF-Token"
+	tokenLength          = 32
 )
 
 var (
 	c                  Config
 	errSessionNotlogin = errors.New("SessionNotLogin")
-	charMaxIndex       = len(character)
+	errInvalidCSRF     = errors.New("InvalidCSRF")
 )
 
 func Init(cfg Config) {
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = defaultSessionTTL
+	}
+	if len(cfg.CSRFKey) < 1 {
+		cfg.CSRFKey = defaultCSRFKey
+	}
+	if len(cfg.CSRFHeader) < 1 {
+		cfg.CSRFHeader = defaultCSRFHeader
+	}
+	cfg.Lockout = cfg.Lockout.withDefault()
+	cfg.trustedProxies = parseTrustedProxies(cfg.TrustedProxies)
 	c = cfg
 }
 
-// MustAuthorize you must provide the Bearer token on header if you're using this middleware
+// MustAuthorize you must provide the session cookie or the Bearer token on header if you're using this middleware.
+// The Bearer token is either a session token or a personal API key
 func MustAuthorize(h httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-		cookie, err := r.Cookie(c.SessionKey)
-		if err != nil {
+		userData, err := authenticate(w, r)
+		if err == errInvalidCSRF {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusForbidden).
-				AddError("Invalid Session"))
+				AddError("Invalid CSRF token"))
 			return
 		}
-
-		userData, err := getUserInfo(cookie.Value)
 		if err != nil {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusForbidden).
@@ -65,13 +92,35 @@ func MustAuthorize(h httprouter.Handle) httprouter.Handle {
 	}
 }
 
-// OptionalAuthorize you don't really have to pass the Bearer token if using this middleware
+// MustAuthorizeSession is MustAuthorize which rejects the personal API key. It guards the handlers which manage
+// the account itself, e.g. the sessions, the two factor authentication or the API keys, which a scoped key must never reach
+func MustAuthorizeSession(h httprouter.Handle) httprouter.Handle {
+	return MustAuthorize(rejectAPIKey(h))
+}
+
+// rejectAPIKey lets only the user who signed in reach the handler
+func rejectAPIKey(h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		sess, ok := r.Context().Value("User").(*User)
+		if !ok || sess == nil || sess.APIKeyID != 0 {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusForbidden).
+				AddError("You don't have privilege"))
+			return
+		}
+		h(w, r, ps)
+	}
+}
+
+// OptionalAuthorize you don't really have to pass the session cookie or the Bearer token if using this middleware
 func OptionalAuthorize(h httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-		var userData *User
-		cookie, err := r.Cookie(c.SessionKey)
-		if err == nil {
-			userData, _ = getUserInfo(cookie.Value)
+		userData, err := authenticate(w, r)
+		if err == errInvalidCSRF {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusForbidden).
+				AddError("Invalid CSRF token"))
+			return
 		}
 
 		r = r.WithContext(context.WithValue(r.Context(), "User", userData))
@@ -79,6 +128,46 @@ func OptionalAuthorize(h httprouter.Handle) httprouter.Handle {
 	}
 }
 
+// authenticate resolves the user of the request from the Bearer token or the session cookie.
+// Only the cookie is sent by the browser automatically, so CSRF is checked for the cookie only
+func authenticate(w http.ResponseWriter, r *http.Request) (*User, error) {
+	if token, ok := bearerToken(r); ok {
+		if strings.HasPrefix(token, apiKeyPrefix) {
+			return getAPIKeyUser(token)
+		}
+
+		userData, err := getUserInfo(token)
+		if err != nil {
+			return nil, err
+		}
+		userData.renewSession(r, token)
+		return userData, nil
+	}
+
+	ensureCSRF(w, r)
+	cookie, err := r.Cookie(c.SessionKey)
+	if err != nil {
+		return nil, errSessionNotlogin
+	}
+
+	userData, err := getUserInfo(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isValidCSRF(r) {
+		return nil, errInvalidCSRF
+	}
+
+	// sliding expiration, every authorized request extends the session
+	renewed, err := userData.renewSession(r, cookie.Value)
+	if err == nil {
+		http.SetCookie(w, renewed)
+	}
+
+	return userData, nil
+}
+
 func getUserInfo(session string) (*User, error) {
 
 	session = strings.Trim(session, " ")
@@ -102,20 +191,30 @@ func getUserInfo(session string) (*User, error) {
 		return nil, errSessionNotlogin
 	}
 
+	// the roles of the session are stale once the version has been bumped
+	version, err := sessionVersion(client, res.ID)
+	if err != nil {
+		return nil, err
+	}
+	if res.Version != version {
+		client.Do("DEL", key, deviceKey(key))
+		client.Do("SREM", fmt.Sprintf("%s%d", listPrefixSession, res.ID), key)
+		return nil, errSessionNotlogin
+	}
+
 	return res, nil
 }
 
 // DestroySession is used for destroying logged in user session
 func (u User) DestroySession(r *http.Request) (*http.Cookie, error) {
-	cookie, _ := r.Cookie(c.SessionKey)
-	session := strings.Trim(cookie.Value, " ")
+	session := strings.TrimPrefix(currentSessionKey(r), sessionPrefix)
 	client := conn.Redis.Get()
 	defer client.Close()
 
 	key := sessionPrefix + session
 	keyList := fmt.Sprintf("%s%d", listPrefixSession, u.ID)
 
-	_, err := redis.Bool(client.Do("DEL", key))
+	_, err := redis.Bool(client.Do("DEL", key, devicePrefixSession+session))
 	if err != nil && err != redis.ErrNil {
 		return nil, err
 	}
@@ -125,12 +224,7 @@ func (u User) DestroySession(r *http.Request) (*http.Cookie, error) {
 		return nil, err
 	}
 
-	return &http.Cookie{
-		Name:    c.SessionKey,
-		Expires: time.Now(),
-		Value:   "unuse",
-		Path:    "/",
-	}, nil
+	return sessionCookie("unuse", time.Now()), nil
 }
 
 // DestroyAllSession is used for destroying all listed session of user
@@ -147,7 +241,7 @@ func DestroyAllSession(id int64) error {
 
 	// delete all logged in session
 	for _, key := range keys {
-		_, err = redis.Bool(client.Do("DEL", key))
+		_, err = redis.Bool(client.Do("DEL", key, deviceKey(key)))
 		if err != nil && err != redis.ErrNil {
 			return err
 		}
@@ -162,50 +256,76 @@ func DestroyAllSession(id int64) error {
 	return nil
 }
 
-// UpdateSession will updates the cookies and listsession
+// UpdateSession will updates the cookies and listsession.
+// The session version is bumped, so the sessions which are not updated are rejected
 func (u User) UpdateSession() {
 	listSession := fmt.Sprintf("%s%d", listPrefixSession, u.ID)
-	data, err := json.Marshal(u)
 
 	client := conn.Redis.Get()
 	defer client.Close()
 
+	version, err := redis.Int64(client.Do("INCR", fmt.Sprintf("%s%d", versionPrefixSession, u.ID)))
+	if err != nil {
+		fmt.Printf("Error func UpdateSession: %s", err.Error())
+		return
+	}
+	u.Version = version
+	data, err := json.Marshal(u)
+
 	keys, err := redis.Strings(client.Do("SMEMBERS", listSession))
 	if err != nil {
 		fmt.Printf("Error func UpdateSession: %s", err.Error())
 	}
 
 	for _, key := range keys {
-		_, err = redis.String(client.Do("SET", key, data))
+		// XX keeps expired sessions from being brought back to life
+		_, err = redis.String(client.Do("SET", key, data, "EX", c.SessionTTL, "XX"))
+		if err == redis.ErrNil {
+			client.Do("SREM", listSession, key)
+			continue
+		}
 		if err != nil {
 			fmt.Printf("Error func UpdateSession: %s", err.Error())
 		}
 	}
 }
 
-func (u User) SetSession() (*http.Cookie, error) {
+// SetSession creates a new session of the user for the device which sent r
+func (u User) SetSession(r *http.Request) (*http.Cookie, error) {
 
-	var cookie string
-	rand.Seed(time.Now().UTC().UnixNano())
-	for i := 0; i < 32; i++ {
-		cookie = cookie + string(character[rand.Intn(charMaxIndex)])
+	cookie, err := newToken()
+	if err != nil {
+		return nil, err
 	}
 
 	key := sessionPrefix + cookie
 	data, err := json.Marshal(u)
 	if err != nil {
-		return nil, fmt.Errorf(err.Error())
+		return nil, err
 	}
 
 	client := conn.Redis.Get()
 	defer client.Close()
 
 	// Session cookie
-	_, err = redis.String(client.Do("SET", key, data))
+	_, err = redis.String(client.Do("SET", key, data, "EX", c.SessionTTL))
 	if err != nil {
 		return nil, fmt.Errorf("Failed to set session to Redis")
 	}
 
+	// Device informations
+	now := time.Now().Unix()
+	_, err = redis.String(client.Do("HMSET", deviceKey(key),
+		"user_agent", r.UserAgent(),
+		"ip", remoteIP(r),
+		"created_at", now,
+		"last_seen", now,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to set session device to Redis")
+	}
+	client.Do("EXPIRE", deviceKey(key), c.SessionTTL)
+
 	val := key
 	key = fmt.Sprintf("%s%d", listPrefixSession, u.ID)
 	// Sesion List Informations
@@ -213,13 +333,9 @@ func (u User) SetSession() (*http.Cookie, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Failed to add list session to Redis")
 	}
+	client.Do("EXPIRE", key, c.SessionTTL)
 
-	return &http.Cookie{
-		Name:    c.SessionKey,
-		Expires: time.Now().AddDate(0, 1, 0),
-		Value:   cookie,
-		Path:    "/",
-	}, nil
+	return sessionCookie(cookie, time.Now().Add(time.Duration(c.SessionTTL)*time.Second)), nil
 }
 
 func (u User) IsHasRoles(module string, roles ...string) bool {
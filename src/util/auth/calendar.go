@@ -0,0 +1,16 @@
+package auth
+
+// NewCalendarToken generates the secret token of the calendar feed. The feed is fetched by
+// calendar apps which can't send the session, so the token is the only credential and only its hash is stored
+func NewCalendarToken() (token, hash string, err error) {
+	token, err = newToken()
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashCalendarToken(token), nil
+}
+
+// HashCalendarToken returns the hash of the calendar feed token to be stored and looked up
+func HashCalendarToken(token string) string {
+	return HashAPIKey(token)
+}
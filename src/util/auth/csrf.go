@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// newToken returns a random url safe token generated by crypto/rand
+func newToken() (string, error) {
+	b := make([]byte, tokenLength)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// sessionCookie returns the session cookie which can't be read by javascript
+func sessionCookie(value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     c.SessionKey,
+		Expires:  expires,
+		Value:    value,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   c.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// CSRFCookie returns a new double submit token cookie. The client has to read
+// the cookie and send the value back on the header named by Config.CSRFHeader
+// for every POST, PATCH, PUT and DELETE request
+func CSRFCookie() (*http.Cookie, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Cookie{
+		Name:     c.CSRFKey,
+		Expires:  time.Now().Add(time.Duration(c.SessionTTL) * time.Second),
+		Value:    token,
+		Path:     "/",
+		Secure:   c.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	}, nil
+}
+
+// ensureCSRF gives the client a token cookie when it doesn't have one yet
+func ensureCSRF(w http.ResponseWriter, r *http.Request) {
+	if _, err := r.Cookie(c.CSRFKey); err == nil {
+		return
+	}
+	cookie, err := CSRFCookie()
+	if err != nil {
+		return
+	}
+	http.SetCookie(w, cookie)
+}
+
+// isValidCSRF checks the origin and the double submit token of unsafe requests
+func isValidCSRF(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	if !isTrustedOrigin(r) {
+		return false
+	}
+
+	cookie, err := r.Cookie(c.CSRFKey)
+	if err != nil || len(cookie.Value) < 1 {
+		return false
+	}
+
+	token := r.Header.Get(c.CSRFHeader)
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(token)) == 1
+}
+
+// isTrustedOrigin rejects cross origin requests when Config.TrustedOrigins is set.
+// Requests without Origin and Referer header are left to the token check
+func isTrustedOrigin(r *http.Request) bool {
+	if len(c.TrustedOrigins) < 1 {
+		return true
+	}
+
+	origin := r.Header.Get("Origin")
+	if len(origin) < 1 {
+		referer, err := url.Parse(r.Referer())
+		if err != nil || len(referer.Host) < 1 {
+			return true
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	for _, val := range c.TrustedOrigins {
+		if strings.EqualFold(strings.TrimRight(val, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewToken(t *testing.T) {
+	a, err := newToken()
+	if err != nil {
+		t.Errorf("newToken() error = %v", err)
+		return
+	}
+	b, _ := newToken()
+	if a == b {
+		t.Errorf("newToken() generate the same token twice")
+	}
+	if len(a) != 43 {
+		t.Errorf("newToken() length = %d, want %d", len(a), 43)
+	}
+}
+
+func TestIsValidCSRF(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		cookie  string
+		header  string
+		origin  string
+		trusted []string
+		want    bool
+	}{
+		{
+			name:   "Safe method",
+			method: "GET",
+			want:   true,
+		},
+		{
+			name:   "Matching token",
+			method: "POST",
+			cookie: "token",
+			header: "token",
+			want:   true,
+		},
+		{
+			name:   "Missing header",
+			method: "DELETE",
+			cookie: "token",
+			want:   false,
+		},
+		{
+			name:   "Missing cookie",
+			method: "PATCH",
+			header: "token",
+			want:   false,
+		},
+		{
+			name:   "Different token",
+			method: "POST",
+			cookie: "token",
+			header: "other",
+			want:   false,
+		},
+		{
+			name:    "Trusted origin",
+			method:  "POST",
+			cookie:  "token",
+			header:  "token",
+			origin:  "https://meiko.example.com",
+			trusted: []string{"https://meiko.example.com/"},
+			want:    true,
+		},
+		{
+			name:    "Untrusted origin",
+			method:  "POST",
+			cookie:  "token",
+			header:  "token",
+			origin:  "https://evil.example.com",
+			trusted: []string{"https://meiko.example.com"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Init(Config{SessionKey: "_SID_Meiko_", TrustedOrigins: tt.trusted})
+			r := httptest.NewRequest(tt.method, "/api/v1/user/profile", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: defaultCSRFKey, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(defaultCSRFHeader, tt.header)
+			}
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if got := isValidCSRF(r); got != tt.want {
+				t.Errorf("isValidCSRF() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// LinkVerification is the purpose of the link sent to verify the email
+	LinkVerification = "verify"
+	// LinkForgot is the purpose of the link sent to reset the password
+	LinkForgot = "forgot"
+)
+
+// ErrInvalidLink is returned when the link is malformed, tampered, expired or used for another purpose
+var ErrInvalidLink = fmt.Errorf("Invalid or expired link")
+
+type linkPayload struct {
+	Purpose string `json:"p"`
+	Email   string `json:"e"`
+	Code    uint16 `json:"c"`
+	Expire  int64  `json:"x"`
+}
+
+// IsLinkEnabled returns true if the secret to sign the links is configured
+func IsLinkEnabled() bool {
+	return len(c.LinkSecret) > 0
+}
+
+// SignLink creates a token which carries the verification code of the email for the purpose until expire.
+// The token is signed so it can be verified without reading the database, but it is only usable
+// once because the code it carries is cleared after it is used
+func SignLink(purpose, email string, code uint16, expire time.Time) (string, error) {
+	if !IsLinkEnabled() {
+		return "", fmt.Errorf("Link secret is not configured")
+	}
+
+	payload, err := json.Marshal(linkPayload{
+		Purpose: purpose,
+		Email:   email,
+		Code:    code,
+		Expire:  expire.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + signLink(encoded), nil
+}
+
+// VerifyLink checks the signature, purpose and expiry of the token and returns the email and code it carries
+func VerifyLink(purpose, token string) (string, uint16, error) {
+	if !IsLinkEnabled() {
+		return "", 0, ErrInvalidLink
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return "", 0, ErrInvalidLink
+	}
+	if !hmac.Equal([]byte(parts[1]), []byte(signLink(parts[0]))) {
+		return "", 0, ErrInvalidLink
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", 0, ErrInvalidLink
+	}
+
+	var payload linkPayload
+	if err = json.Unmarshal(raw, &payload); err != nil {
+		return "", 0, ErrInvalidLink
+	}
+	if payload.Purpose != purpose || time.Now().Unix() > payload.Expire {
+		return "", 0, ErrInvalidLink
+	}
+
+	return payload.Email, payload.Code, nil
+}
+
+// LinkURL returns the url of the page which handles the token for the purpose
+func LinkURL(purpose, token string) string {
+	return fmt.Sprintf("%s/%s?token=%s", strings.TrimRight(c.LinkURL, "/"), purpose, url.QueryEscape(token))
+}
+
+func signLink(payload string) string {
+	mac := hmac.New(sha256.New, []byte(c.LinkSecret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyLink(t *testing.T) {
+	c.LinkSecret = "secret"
+	defer func() { c.LinkSecret = "" }()
+
+	valid, err := SignLink(LinkVerification, "risal@live.com", 1234, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("SignLink() error = %v", err)
+	}
+	expired, _ := SignLink(LinkVerification, "risal@live.com", 1234, time.Now().Add(-time.Minute))
+	parts := strings.Split(valid, ".")
+
+	tests := []struct {
+		name    string
+		purpose string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			purpose: LinkVerification,
+			token:   valid,
+		},
+		{
+			name:    "Test Case 2",
+			purpose: LinkForgot,
+			token:   valid,
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			purpose: LinkVerification,
+			token:   expired,
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 4",
+			purpose: LinkVerification,
+			token:   parts[0] + "x." + parts[1],
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 5",
+			purpose: LinkVerification,
+			token:   parts[0],
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, code, err := VerifyLink(tt.purpose, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyLink() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && (email != "risal@live.com" || code != 1234) {
+				t.Errorf("VerifyLink() = %v, %v, want %v, %v", email, code, "risal@live.com", 1234)
+			}
+		})
+	}
+
+	c.LinkSecret = ""
+	if _, _, err := VerifyLink(LinkVerification, valid); err == nil {
+		t.Errorf("VerifyLink() should fail when the link is disabled")
+	}
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/melodiez14/meiko/src/module/lockout"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+type (
+	// LockoutConfig limits the failed attempts per email and per IP address. Durations are in seconds
+	LockoutConfig struct {
+		Free         int64 `json:"free"`
+		MaxAttempt   int64 `json:"maxattempt"`
+		MaxAttemptIP int64 `json:"maxattemptip"`
+		BaseDelay    int64 `json:"basedelay"`
+		MaxDelay     int64 `json:"maxdelay"`
+		Window       int64 `json:"window"`
+		Duration     int64 `json:"duration"`
+	}
+)
+
+const (
+	ScopeSignIn    = "signin"
+	ScopeForgot    = "forgot"
+	ScopeTwoFactor = "2fa"
+	attemptPrefix  = "attempt:"
+)
+
+var scopes = []string{ScopeSignIn, ScopeForgot, ScopeTwoFactor}
+
+func (l LockoutConfig) withDefault() LockoutConfig {
+	if l.Free <= 0 {
+		l.Free = 3
+	}
+	if l.MaxAttempt <= 0 {
+		l.MaxAttempt = 10
+	}
+	if l.MaxAttemptIP <= 0 {
+		l.MaxAttemptIP = 100
+	}
+	if l.BaseDelay <= 0 {
+		l.BaseDelay = 1
+	}
+	if l.MaxDelay <= 0 {
+		l.MaxDelay = 60
+	}
+	if l.Window <= 0 {
+		l.Window = 15 * 60
+	}
+	if l.Duration <= 0 {
+		l.Duration = 15 * 60
+	}
+	return l
+}
+
+// CheckAttempt returns how long the client has to wait before the next attempt of the scope.
+// Zero means the attempt is allowed
+func CheckAttempt(scope string, r *http.Request, email string) (time.Duration, error) {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	var wait int64
+	for _, key := range []string{
+		attemptKey(scope, "lock", "email", email),
+		attemptKey(scope, "lock", "ip", remoteIP(r)),
+		attemptKey(scope, "wait", "email", email),
+		attemptKey(scope, "wait", "ip", remoteIP(r)),
+	} {
+		ttl, err := redis.Int64(client.Do("TTL", key))
+		if err != nil {
+			return 0, err
+		}
+		if ttl > wait {
+			wait = ttl
+		}
+	}
+	return time.Duration(wait) * time.Second, nil
+}
+
+// FailAttempt counts a failed attempt of the email and the IP address. Every failure after
+// the free attempts doubles the delay, reaching the max attempt locks them out for a while
+func FailAttempt(scope string, r *http.Request, email string) {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	ip := remoteIP(r)
+	for _, val := range []struct {
+		kind string
+		id   string
+		max  int64
+	}{
+		{kind: "email", id: email, max: c.Lockout.MaxAttempt},
+		{kind: "ip", id: ip, max: c.Lockout.MaxAttemptIP},
+	} {
+		key := attemptKey(scope, "fail", val.kind, val.id)
+		n, err := redis.Int64(client.Do("INCR", key))
+		if err != nil {
+			fmt.Printf("Error func FailAttempt: %s", err.Error())
+			return
+		}
+		if n == 1 {
+			client.Do("EXPIRE", key, c.Lockout.Window)
+		}
+
+		if n >= val.max {
+			client.Do("SET", attemptKey(scope, "lock", val.kind, val.id), n, "EX", c.Lockout.Duration)
+			client.Do("DEL", key)
+
+			until := time.Now().Add(time.Duration(c.Lockout.Duration) * time.Second)
+			var lockedEmail string
+			if val.kind == "email" {
+				lockedEmail = normalizeEmail(email)
+			}
+			go lockout.Insert(lockedEmail, ip, scope, lockout.EventLock, n, until, sql.NullInt64{})
+			continue
+		}
+
+		if delay := backoff(n); delay > 0 {
+			client.Do("SET", attemptKey(scope, "wait", val.kind, val.id), 1, "EX", delay)
+		}
+	}
+}
+
+// SucceedAttempt resets the failures of the email. The failures of the IP address are kept,
+// otherwise signing in to an owned account would reset the limit of the IP address
+func SucceedAttempt(scope string, r *http.Request, email string) {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	client.Do("DEL",
+		attemptKey(scope, "fail", "email", email),
+		attemptKey(scope, "wait", "email", email),
+	)
+}
+
+// Unlock removes the lockout and the failures of the email on every scope, actorID is the admin who unlocks it
+func Unlock(email string, actorID int64) error {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	var keys []interface{}
+	for _, scope := range scopes {
+		keys = append(keys,
+			attemptKey(scope, "lock", "email", email),
+			attemptKey(scope, "wait", "email", email),
+			attemptKey(scope, "fail", "email", email),
+		)
+	}
+
+	_, err := client.Do("DEL", keys...)
+	if err != nil {
+		return err
+	}
+
+	actor := sql.NullInt64{Int64: actorID, Valid: actorID != 0}
+	return lockout.Insert(normalizeEmail(email), "", ScopeSignIn, lockout.EventUnlock, 0, time.Now(), actor)
+}
+
+// backoff returns the delay in seconds after the n-th failure
+func backoff(n int64) int64 {
+	n -= c.Lockout.Free
+	if n <= 0 {
+		return 0
+	}
+	delay := c.Lockout.BaseDelay
+	for i := int64(1); i < n && delay < c.Lockout.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > c.Lockout.MaxDelay {
+		delay = c.Lockout.MaxDelay
+	}
+	return delay
+}
+
+// attemptKey returns the key of the attempt, e.g. attempt:signin:fail:email:risal@live.com
+func attemptKey(scope, name, kind, id string) string {
+	if kind == "email" {
+		id = normalizeEmail(id)
+	}
+	return attemptPrefix + scope + ":" + name + ":" + kind + ":" + id
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
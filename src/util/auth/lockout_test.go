@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestBackoff(t *testing.T) {
+	Init(Config{SessionKey: "_SID_Meiko_", Lockout: LockoutConfig{MaxDelay: 8}})
+	tests := []struct {
+		n    int64
+		want int64
+	}{
+		{n: 1, want: 0},
+		{n: 3, want: 0},
+		{n: 4, want: 1},
+		{n: 5, want: 2},
+		{n: 7, want: 8},
+		{n: 30, want: 8},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.n); got != tt.want {
+			t.Errorf("backoff(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCheckAttempt(t *testing.T) {
+	mock := initRedisMock()
+	r := httptest.NewRequest("POST", "/api/v1/user/signin", nil)
+	r.RemoteAddr = "10.10.1.20:4321"
+
+	mock.Command("TTL", "attempt:signin:lock:email:risal@live.com").Expect(int64(-2))
+	mock.Command("TTL", "attempt:signin:lock:ip:10.10.1.20").Expect(int64(-2))
+	mock.Command("TTL", "attempt:signin:wait:email:risal@live.com").Expect(int64(4))
+	mock.Command("TTL", "attempt:signin:wait:ip:10.10.1.20").Expect(int64(-2))
+
+	wait, err := CheckAttempt(ScopeSignIn, r, " Risal@Live.com")
+	if err != nil {
+		t.Errorf("CheckAttempt() error = %v", err)
+		return
+	}
+	if wait != 4*time.Second {
+		t.Errorf("CheckAttempt() = %v, want %v", wait, 4*time.Second)
+	}
+}
+
+func TestFailAttempt(t *testing.T) {
+	mock := initRedisMock()
+	r := httptest.NewRequest("POST", "/api/v1/user/signin", nil)
+	r.RemoteAddr = "10.10.1.20:4321"
+
+	mock.Command("INCR", "attempt:signin:fail:email:risal@live.com").Expect(int64(5))
+	mock.Command("INCR", "attempt:signin:fail:ip:10.10.1.20").Expect(int64(1))
+	expire := mock.Command("EXPIRE", "attempt:signin:fail:ip:10.10.1.20", int64(900)).Expect(int64(1))
+	wait := mock.Command("SET", "attempt:signin:wait:email:risal@live.com", 1, "EX", int64(2)).Expect("OK")
+
+	FailAttempt(ScopeSignIn, r, "risal@live.com")
+
+	if mock.Stats(expire) != 1 {
+		t.Errorf("FailAttempt() should start the window of the first failure")
+	}
+	if mock.Stats(wait) != 1 {
+		t.Errorf("FailAttempt() should set the backoff delay")
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	mock := initRedisMock()
+	db, err := conn.InitDBMock()
+	if err != nil {
+		t.Fatalf("InitDBMock() error = %v", err)
+	}
+
+	del := mock.Command("DEL",
+		"attempt:signin:lock:email:risal@live.com",
+		"attempt:signin:wait:email:risal@live.com",
+		"attempt:signin:fail:email:risal@live.com",
+		"attempt:forgot:lock:email:risal@live.com",
+		"attempt:forgot:wait:email:risal@live.com",
+		"attempt:forgot:fail:email:risal@live.com",
+		"attempt:2fa:lock:email:risal@live.com",
+		"attempt:2fa:wait:email:risal@live.com",
+		"attempt:2fa:fail:email:risal@live.com",
+	).Expect(int64(2))
+	db.ExpectExec("INSERT INTO lockout_logs").
+		WithArgs("risal@live.com", "", ScopeSignIn, 2, 0, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := Unlock("risal@live.com", 1); err != nil {
+		t.Errorf("Unlock() error = %v", err)
+		return
+	}
+	if mock.Stats(del) != 1 {
+		t.Errorf("Unlock() should remove the lockout keys")
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unlock() %v", err)
+	}
+}
@@ -1,14 +1,33 @@
 package auth
 
+import "time"
+
 type User struct {
-	ID           int64               `json:"id"`
-	Name         string              `json:"name"`
-	Email        string              `json:"email"`
-	Gender       int8                `json:"gender"`
-	Note         string              `json:"note"`
-	Roles        map[string][]string `json:"roles"`
-	IdentityCode int64               `json:"identity_code"`
-	LineID       string              `json:"line_id"`
-	Phone        string              `json:"phone"`
-	Status       int8                `json:"active"`
+	ID            int64               `json:"id"`
+	Name          string              `json:"name"`
+	Email         string              `json:"email"`
+	Gender        int8                `json:"gender"`
+	Note          string              `json:"note"`
+	Roles         map[string][]string `json:"roles"`
+	IdentityCode  int64               `json:"identity_code"`
+	LineID        string              `json:"line_id"`
+	Phone         string              `json:"phone"`
+	PhoneVerified bool                `json:"phone_verified"`
+	Program       string              `json:"program"`
+	Cohort        uint16              `json:"cohort"`
+	AdvisorID     int64               `json:"advisor_id"`
+	Status        int8                `json:"active"`
+	Version       int64               `json:"version"`
+	APIKeyID      int64               `json:"-"`
+}
+
+// Session is an active sign in of the user on a device
+type Session struct {
+	ID        string
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+	LastSeen  time.Time
+	ExpiresAt time.Time
+	IsCurrent bool
 }
@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+var errSessionNotFound = fmt.Errorf("Session not found")
+
+// renewSession extends the lifetime of the session and records the device activity
+func (u User) renewSession(r *http.Request, session string) (*http.Cookie, error) {
+	session = strings.Trim(session, " ")
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	key := sessionPrefix + session
+	ok, err := redis.Bool(client.Do("EXPIRE", key, c.SessionTTL))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errSessionNotFound
+	}
+
+	device := deviceKey(key)
+	client.Do("HMSET", device, "ip", remoteIP(r), "last_seen", time.Now().Unix())
+	client.Do("EXPIRE", device, c.SessionTTL)
+	client.Do("EXPIRE", fmt.Sprintf("%s%d", listPrefixSession, u.ID), c.SessionTTL)
+
+	return sessionCookie(session, time.Now().Add(time.Duration(c.SessionTTL)*time.Second)), nil
+}
+
+// SessionList returns all active sessions of the user, the session which sent r is marked as current.
+// Expired sessions found on the list are removed from it
+func (u User) SessionList(r *http.Request) ([]Session, error) {
+	var sessions []Session
+	current := currentSessionKey(r)
+
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	listSession := fmt.Sprintf("%s%d", listPrefixSession, u.ID)
+	keys, err := redis.Strings(client.Do("SMEMBERS", listSession))
+	if err != nil {
+		return sessions, err
+	}
+
+	for _, key := range keys {
+		ttl, err := redis.Int64(client.Do("TTL", key))
+		if err != nil {
+			return sessions, err
+		}
+
+		// -2 means the key does not exist anymore
+		if ttl == -2 {
+			client.Do("SREM", listSession, key)
+			continue
+		}
+
+		device, err := redis.StringMap(client.Do("HGETALL", deviceKey(key)))
+		if err != nil {
+			return sessions, err
+		}
+
+		sessions = append(sessions, Session{
+			ID:        sessionID(key),
+			UserAgent: device["user_agent"],
+			IP:        device["ip"],
+			CreatedAt: unixString(device["created_at"]),
+			LastSeen:  unixString(device["last_seen"]),
+			ExpiresAt: time.Now().Add(time.Duration(ttl) * time.Second),
+			IsCurrent: key == current,
+		})
+	}
+
+	return sessions, nil
+}
+
+// DestroySessionByID destroys the session of the user which has the id given by SessionList
+func (u User) DestroySessionByID(id string) error {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	listSession := fmt.Sprintf("%s%d", listPrefixSession, u.ID)
+	keys, err := redis.Strings(client.Do("SMEMBERS", listSession))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if sessionID(key) != id {
+			continue
+		}
+
+		_, err = client.Do("DEL", key, deviceKey(key))
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Do("SREM", listSession, key)
+		return err
+	}
+
+	return errSessionNotFound
+}
+
+// DestroyOtherSession destroys all sessions of the user except the session which sent r
+func (u User) DestroyOtherSession(r *http.Request) error {
+	current := currentSessionKey(r)
+
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	listSession := fmt.Sprintf("%s%d", listPrefixSession, u.ID)
+	keys, err := redis.Strings(client.Do("SMEMBERS", listSession))
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if key == current {
+			continue
+		}
+
+		_, err = client.Do("DEL", key, deviceKey(key))
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Do("SREM", listSession, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// currentSessionKey returns the session key of the request, either from the Bearer token or the cookie
+func currentSessionKey(r *http.Request) string {
+	if token, ok := bearerToken(r); ok {
+		return sessionPrefix + token
+	}
+	cookie, err := r.Cookie(c.SessionKey)
+	if err != nil {
+		return ""
+	}
+	return sessionPrefix + strings.Trim(cookie.Value, " ")
+}
+
+// deviceKey returns the key of device informations which belongs to the session key
+func deviceKey(key string) string {
+	return devicePrefixSession + strings.TrimPrefix(key, sessionPrefix)
+}
+
+// sessionID returns public identifier of the session key, the token itself is never exposed
+func sessionID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// remoteIP returns the address of the client. X-Forwarded-For can be forged by the client, so it is only honoured
+// when the request comes from a trusted proxy, then the right-most hop which is not a trusted proxy is the client
+func remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !isTrustedProxy(ip) {
+		return ip
+	}
+
+	hops := strings.Split(strings.Join(r.Header["X-Forwarded-For"], ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrustedProxy(hop) {
+			return hop
+		}
+		ip = hop
+	}
+	return ip
+}
+
+// isTrustedProxy reports whether the address belongs to one of the trusted proxies
+func isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, val := range c.trustedProxies {
+		if val.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTrustedProxies parses the IPs or CIDRs of the trusted proxies, the invalid ones are ignored
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, val := range proxies {
+		val = strings.TrimSpace(val)
+		if !strings.Contains(val, "/") {
+			ip := net.ParseIP(val)
+			if ip == nil {
+				log.Printf("Invalid trusted proxy %s", val)
+				continue
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(val)
+		if err != nil {
+			log.Printf("Invalid trusted proxy %s", val)
+			continue
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+func unixString(text string) time.Time {
+	var sec int64
+	fmt.Sscanf(text, "%d", &sec)
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// SessionVersion returns the current session version of the user.
+// Read it before loading the roles of a new session, so a change made meanwhile makes the session stale
+func SessionVersion(userID int64) (int64, error) {
+	client := conn.Redis.Get()
+	defer client.Close()
+	return sessionVersion(client, userID)
+}
+
+// RefreshRoles replaces the roles of all sessions of the users and bumps their session version.
+// Sessions missed by the refresh, e.g. signed in meanwhile, keep the old version and are rejected
+func RefreshRoles(roles map[string][]string, usersID ...int64) error {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	for _, id := range usersID {
+		version, err := redis.Int64(client.Do("INCR", fmt.Sprintf("%s%d", versionPrefixSession, id)))
+		if err != nil {
+			return err
+		}
+
+		listSession := fmt.Sprintf("%s%d", listPrefixSession, id)
+		keys, err := redis.Strings(client.Do("SMEMBERS", listSession))
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			jsd, err := redis.Bytes(client.Do("GET", key))
+			if err == redis.ErrNil {
+				client.Do("SREM", listSession, key)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			u := User{}
+			err = json.Unmarshal(jsd, &u)
+			if err != nil {
+				return err
+			}
+			u.Roles = roles
+			u.Version = version
+
+			data, err := json.Marshal(u)
+			if err != nil {
+				return err
+			}
+
+			// XX keeps expired sessions from being brought back to life
+			_, err = redis.String(client.Do("SET", key, data, "EX", c.SessionTTL, "XX"))
+			if err == redis.ErrNil {
+				client.Do("SREM", listSession, key)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sessionVersion returns the session version of the user, 0 if it has never been bumped
+func sessionVersion(client redis.Conn, userID int64) (int64, error) {
+	version, err := redis.Int64(client.Do("GET", fmt.Sprintf("%s%d", versionPrefixSession, userID)))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	return version, err
+}
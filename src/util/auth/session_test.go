@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/rafaeljusto/redigomock"
+)
+
+func initRedisMock() *redigomock.Conn {
+	mock := redigomock.NewConn()
+	conn.Redis = &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 10 * time.Second,
+		Dial:        func() (redis.Conn, error) { return mock, nil },
+	}
+	Init(Config{SessionKey: "_SID_Meiko_"})
+	return mock
+}
+
+func TestSessionList(t *testing.T) {
+	mock := initRedisMock()
+	u := User{ID: 1}
+
+	r := httptest.NewRequest("GET", "/api/v1/user/sessions", nil)
+	r.AddCookie(&http.Cookie{Name: "_SID_Meiko_", Value: "current"})
+
+	mock.Command("SMEMBERS", "session:list:1").Expect([]interface{}{
+		[]byte("session:current"),
+		[]byte("session:expired"),
+	})
+	mock.Command("TTL", "session:current").Expect(int64(600))
+	mock.Command("TTL", "session:expired").Expect(int64(-2))
+	mock.Command("HGETALL", "session:device:current").Expect([]interface{}{
+		[]byte("user_agent"), []byte("Mozilla/5.0"),
+		[]byte("ip"), []byte("10.10.1.20"),
+		[]byte("created_at"), []byte("1508313600"),
+	})
+	prune := mock.Command("SREM", "session:list:1", "session:expired").Expect(int64(1))
+
+	sessions, err := u.SessionList(r)
+	if err != nil {
+		t.Errorf("SessionList() error = %v", err)
+		return
+	}
+	if len(sessions) != 1 {
+		t.Errorf("SessionList() = %d sessions, want %d", len(sessions), 1)
+		return
+	}
+	if !sessions[0].IsCurrent || sessions[0].IP != "10.10.1.20" || sessions[0].ID != sessionID("session:current") {
+		t.Errorf("SessionList() = %+v", sessions[0])
+	}
+	if sessions[0].CreatedAt.Unix() != 1508313600 || !sessions[0].LastSeen.IsZero() {
+		t.Errorf("SessionList() time = %v %v", sessions[0].CreatedAt, sessions[0].LastSeen)
+	}
+	if mock.Stats(prune) != 1 {
+		t.Errorf("SessionList() expired session is not removed from the list")
+	}
+}
+
+func TestDestroyOtherSession(t *testing.T) {
+	mock := initRedisMock()
+	u := User{ID: 1}
+
+	r := httptest.NewRequest("DELETE", "/api/v1/user/sessions", nil)
+	r.AddCookie(&http.Cookie{Name: "_SID_Meiko_", Value: "current"})
+
+	mock.Command("SMEMBERS", "session:list:1").Expect([]interface{}{
+		[]byte("session:current"),
+		[]byte("session:other"),
+	})
+	keep := mock.Command("DEL", "session:current", "session:device:current").Expect(int64(2))
+	del := mock.Command("DEL", "session:other", "session:device:other").Expect(int64(2))
+	mock.Command("SREM", "session:list:1", "session:other").Expect(int64(1))
+
+	if err := u.DestroyOtherSession(r); err != nil {
+		t.Errorf("DestroyOtherSession() error = %v", err)
+	}
+	if mock.Stats(keep) != 0 {
+		t.Errorf("DestroyOtherSession() destroyed the current session")
+	}
+	if mock.Stats(del) != 1 {
+		t.Errorf("DestroyOtherSession() did not destroy the other session")
+	}
+}
+
+func TestDestroySessionByID(t *testing.T) {
+	mock := initRedisMock()
+	u := User{ID: 1}
+
+	mock.Command("SMEMBERS", "session:list:1").Expect([]interface{}{
+		[]byte("session:other"),
+	})
+	mock.Command("DEL", "session:other", "session:device:other").Expect(int64(2))
+	mock.Command("SREM", "session:list:1", "session:other").Expect(int64(1))
+
+	if err := u.DestroySessionByID(sessionID("session:other")); err != nil {
+		t.Errorf("DestroySessionByID() error = %v", err)
+	}
+	if err := u.DestroySessionByID("unknown"); err != errSessionNotFound {
+		t.Errorf("DestroySessionByID() error = %v, want %v", err, errSessionNotFound)
+	}
+}
+
+func TestGetUserInfoVersion(t *testing.T) {
+	mock := initRedisMock()
+
+	mock.Command("GET", "session:current").Expect([]byte(`{"id":1,"version":2}`))
+	mock.Command("GET", "session:stale").Expect([]byte(`{"id":1,"version":1}`))
+	mock.Command("GET", "session:version:1").Expect([]byte("2"))
+	del := mock.Command("DEL", "session:stale", "session:device:stale").Expect(int64(2))
+	mock.Command("SREM", "session:list:1", "session:stale").Expect(int64(1))
+
+	u, err := getUserInfo("current")
+	if err != nil || u.ID != 1 {
+		t.Errorf("getUserInfo() = %v, error = %v", u, err)
+	}
+	if _, err := getUserInfo("stale"); err != errSessionNotlogin {
+		t.Errorf("getUserInfo() error = %v, want %v", err, errSessionNotlogin)
+	}
+	if mock.Stats(del) != 1 {
+		t.Errorf("getUserInfo() stale session is not destroyed")
+	}
+}
+
+func TestRefreshRoles(t *testing.T) {
+	mock := initRedisMock()
+	roles := map[string][]string{"users": {"READ"}}
+
+	mock.Command("INCR", "session:version:1").Expect(int64(3))
+	mock.Command("SMEMBERS", "session:list:1").Expect([]interface{}{
+		[]byte("session:current"),
+		[]byte("session:expired"),
+	})
+	mock.Command("GET", "session:current").Expect([]byte(`{"id":1,"roles":{"users":["XREAD"]},"version":2}`))
+	mock.Command("GET", "session:expired").ExpectError(redis.ErrNil)
+	prune := mock.Command("SREM", "session:list:1", "session:expired").Expect(int64(1))
+
+	data, _ := json.Marshal(User{ID: 1, Roles: roles, Version: 3})
+	set := mock.Command("SET", "session:current", data, "EX", int64(defaultSessionTTL), "XX").Expect("OK")
+
+	if err := RefreshRoles(roles, 1); err != nil {
+		t.Errorf("RefreshRoles() error = %v", err)
+	}
+	if mock.Stats(set) != 1 {
+		t.Errorf("RefreshRoles() session is not refreshed")
+	}
+	if mock.Stats(prune) != 1 {
+		t.Errorf("RefreshRoles() expired session is not removed from the list")
+	}
+}
+
+func TestRemoteIP(t *testing.T) {
+	defer Init(Config{})
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "Forwarded by the untrusted client",
+			remoteAddr: "203.0.113.7:51234",
+			forwarded:  []string{"10.0.0.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Forwarded by the trusted proxy",
+			proxies:    []string{"10.0.0.0/8"},
+			remoteAddr: "10.0.0.2:51234",
+			forwarded:  []string{"198.51.100.1, 203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Forwarded by the chain of trusted proxies",
+			proxies:    []string{"10.0.0.2", "10.1.0.0/16"},
+			remoteAddr: "10.0.0.2:51234",
+			forwarded:  []string{"198.51.100.1, 203.0.113.7", "10.1.0.3"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "Trusted proxy without forwarded header",
+			proxies:    []string{"10.0.0.2", "invalid"},
+			remoteAddr: "10.0.0.2:51234",
+			want:       "10.0.0.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Init(Config{TrustedProxies: tt.proxies})
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, val := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", val)
+			}
+			if got := remoteIP(r); got != tt.want {
+				t.Errorf("remoteIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/melodiez14/meiko/src/util/conn"
+)
+
+const (
+	statePrefixSSO = "sso:state:"
+	stateCookieSSO = "_SSO_State_"
+	stateSSOTTL    = 10 * 60
+)
+
+var errInvalidSSOState = fmt.Errorf("Invalid single sign on state")
+
+// SetSSOState starts a single sign on. The state is bound to the browser by the returned cookie
+// and the nonce is bound to the ID token, so a callback can't be replayed or sent to another browser
+func SetSSOState() (state, nonce string, cookie *http.Cookie, err error) {
+	state, err = newToken()
+	if err != nil {
+		return
+	}
+	nonce, err = newToken()
+	if err != nil {
+		return
+	}
+
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	_, err = redis.String(client.Do("SET", statePrefixSSO+state, nonce, "EX", stateSSOTTL))
+	if err != nil {
+		err = fmt.Errorf("Failed to set single sign on state to Redis")
+		return
+	}
+
+	cookie = &http.Cookie{
+		Name:     stateCookieSSO,
+		Value:    state,
+		Path:     "/",
+		Expires:  time.Now().Add(stateSSOTTL * time.Second),
+		HttpOnly: true,
+		Secure:   c.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	}
+	return
+}
+
+// ConsumeSSOState returns the nonce of the state which is sent back by the provider. The state
+// can be used once and must match the cookie of r, the returned cookie removes it from the browser
+func ConsumeSSOState(r *http.Request, state string) (string, *http.Cookie, error) {
+	expired := &http.Cookie{
+		Name:     stateCookieSSO,
+		Value:    "unuse",
+		Path:     "/",
+		Expires:  time.Now(),
+		HttpOnly: true,
+		Secure:   c.SecureCookie,
+		SameSite: http.SameSiteLaxMode,
+	}
+
+	cookie, err := r.Cookie(stateCookieSSO)
+	if err != nil || len(state) < 1 || cookie.Value != state {
+		return "", expired, errInvalidSSOState
+	}
+
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	key := statePrefixSSO + state
+	nonce, err := redis.String(client.Do("GET", key))
+	if err != nil {
+		return "", expired, errInvalidSSOState
+	}
+	client.Do("DEL", key)
+
+	return nonce, expired, nil
+}
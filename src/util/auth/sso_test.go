@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+func TestConsumeSSOState(t *testing.T) {
+	mock := initRedisMock()
+	mock.Command("GET", "sso:state:state-1").Expect([]byte("nonce-1"))
+	mock.Command("GET", "sso:state:used").ExpectError(redis.ErrNil)
+	del := mock.Command("DEL", "sso:state:state-1").Expect(int64(1))
+
+	tests := []struct {
+		name    string
+		cookie  string
+		state   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "Test Case 1",
+			cookie: "state-1",
+			state:  "state-1",
+			want:   "nonce-1",
+		},
+		{
+			name:    "Test Case 2",
+			cookie:  "other",
+			state:   "state-1",
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			state:   "state-1",
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 4",
+			cookie:  "used",
+			state:   "used",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/user/signin/sso/callback", nil)
+			if len(tt.cookie) > 0 {
+				r.AddCookie(&http.Cookie{Name: stateCookieSSO, Value: tt.cookie})
+			}
+			got, cookie, err := ConsumeSSOState(r, tt.state)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConsumeSSOState() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ConsumeSSOState() = %v, want %v", got, tt.want)
+			}
+			if cookie == nil || cookie.Name != stateCookieSSO {
+				t.Errorf("ConsumeSSOState() cookie = %v", cookie)
+			}
+		})
+	}
+	if mock.Stats(del) != 1 {
+		t.Errorf("ConsumeSSOState() state is not removed")
+	}
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+const (
+	pendingPrefixSession = "session:pending:"
+	usedPrefixTOTP       = "totp:used:"
+	pendingSessionTTL    = 5 * 60
+)
+
+// SetPendingSession stores the user who passed the password check but still has to pass
+// the two factor authentication. The token is not a session and can't authorize any request
+func SetPendingSession(userID int64) (string, error) {
+	token, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	_, err = redis.String(client.Do("SET", pendingPrefixSession+token, userID, "EX", pendingSessionTTL))
+	if err != nil {
+		return "", fmt.Errorf("Failed to set pending session to Redis")
+	}
+	return token, nil
+}
+
+// GetPendingSession returns the user id of the pending session
+func GetPendingSession(token string) (int64, error) {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	return redis.Int64(client.Do("GET", pendingPrefixSession+strings.TrimSpace(token)))
+}
+
+// DestroyPendingSession removes the pending session after the second step is passed
+func DestroyPendingSession(token string) {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	client.Do("DEL", pendingPrefixSession+strings.TrimSpace(token))
+}
+
+// UseTOTPCode marks the code of the user as used, so a valid code can't be replayed
+// while it is still accepted. It returns false when the code has been used before
+func UseTOTPCode(userID int64, code string) bool {
+	client := conn.Redis.Get()
+	defer client.Close()
+
+	key := fmt.Sprintf("%s%d:%s", usedPrefixTOTP, userID, code)
+	_, err := redis.String(client.Do("SET", key, 1, "EX", 3*helper.TOTPPeriod, "NX"))
+	return err == nil
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/garyburd/redigo/redis"
+)
+
+func TestGetPendingSession(t *testing.T) {
+	mock := initRedisMock()
+	mock.Command("GET", "session:pending:token").Expect([]byte("12"))
+	mock.Command("GET", "session:pending:expired").ExpectError(redis.ErrNil)
+
+	id, err := GetPendingSession(" token ")
+	if err != nil || id != 12 {
+		t.Errorf("GetPendingSession() = %v, %v, want %v", id, err, 12)
+	}
+	if _, err := GetPendingSession("expired"); err == nil {
+		t.Errorf("GetPendingSession() should fail on expired token")
+	}
+}
+
+func TestUseTOTPCode(t *testing.T) {
+	mock := initRedisMock()
+	mock.Command("SET", "totp:used:12:123456", 1, "EX", 90, "NX").Expect("OK")
+	mock.Command("SET", "totp:used:12:654321", 1, "EX", 90, "NX").Expect(nil)
+
+	if !UseTOTPCode(12, "123456") {
+		t.Errorf("UseTOTPCode() should accept an unused code")
+	}
+	if UseTOTPCode(12, "654321") {
+		t.Errorf("UseTOTPCode() should reject a used code")
+	}
+}
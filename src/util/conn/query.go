@@ -0,0 +1,90 @@
+package conn
+
+import (
+	"database/sql"
+	"reflect"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// In expands every slice argument of query into its own list of bound
+// placeholders and rebinds the result for the connected driver. Queries must
+// be written with the `?` bindvar, for example:
+//
+//	SELECT id FROM users WHERE id IN (?) AND status = (?)
+func In(query string, args ...interface{}) (string, []interface{}, error) {
+	if hasSlice(args) {
+		var err error
+		query, args, err = sqlx.In(query, args...)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	return DB.Rebind(query), args, nil
+}
+
+// Get executes query with bound args on the database and scans the first row into dest
+func Get(dest interface{}, query string, args ...interface{}) error {
+	return TxGet(nil, dest, query, args...)
+}
+
+// Select executes query with bound args on the database and scans all rows into dest
+func Select(dest interface{}, query string, args ...interface{}) error {
+	return TxSelect(nil, dest, query, args...)
+}
+
+// Exec executes query with bound args on the database
+func Exec(query string, args ...interface{}) (sql.Result, error) {
+	return TxExec(nil, query, args...)
+}
+
+// TxGet is like Get but runs inside tx, falling back to the database when tx is nil
+func TxGet(tx *sqlx.Tx, dest interface{}, query string, args ...interface{}) error {
+	query, args, err := In(query, args...)
+	if err != nil {
+		return err
+	}
+	return sqlx.Get(queryer(tx), dest, query, args...)
+}
+
+// TxSelect is like Select but runs inside tx, falling back to the database when tx is nil
+func TxSelect(tx *sqlx.Tx, dest interface{}, query string, args ...interface{}) error {
+	query, args, err := In(query, args...)
+	if err != nil {
+		return err
+	}
+	return sqlx.Select(queryer(tx), dest, query, args...)
+}
+
+// TxExec is like Exec but runs inside tx, falling back to the database when tx is nil
+func TxExec(tx *sqlx.Tx, query string, args ...interface{}) (sql.Result, error) {
+	query, args, err := In(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return queryer(tx).Exec(query, args...)
+}
+
+func queryer(tx *sqlx.Tx) sqlx.Ext {
+	if tx != nil {
+		return tx
+	}
+	return DB
+}
+
+// hasSlice reports whether any of args has to be expanded into an IN-list.
+// []byte is a single value for the driver and is therefore left untouched.
+func hasSlice(args []interface{}) bool {
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		if _, ok := arg.([]byte); ok {
+			continue
+		}
+		if reflect.TypeOf(arg).Kind() == reflect.Slice {
+			return true
+		}
+	}
+	return false
+}
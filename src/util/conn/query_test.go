@@ -0,0 +1,99 @@
+package conn
+
+import (
+	"reflect"
+	"testing"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestIn(t *testing.T) {
+	_, err := InitDBMock()
+	if err != nil {
+		t.Errorf("Failed to InitMockDB")
+	}
+
+	cases := []struct {
+		query     string
+		args      []interface{}
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			query:     "SELECT id FROM users WHERE id = (?)",
+			args:      []interface{}{int64(1)},
+			wantQuery: "SELECT id FROM users WHERE id = (?)",
+			wantArgs:  []interface{}{int64(1)},
+		},
+		{
+			query:     "SELECT id FROM users WHERE id IN (?) AND status = (?)",
+			args:      []interface{}{[]int64{1, 2, 3}, int8(2)},
+			wantQuery: "SELECT id FROM users WHERE id IN (?, ?, ?) AND status = (?)",
+			wantArgs:  []interface{}{int64(1), int64(2), int64(3), int8(2)},
+		},
+		{
+			query:     "UPDATE files SET content = (?) WHERE id = (?)",
+			args:      []interface{}{[]byte("abc"), "x"},
+			wantQuery: "UPDATE files SET content = (?) WHERE id = (?)",
+			wantArgs:  []interface{}{[]byte("abc"), "x"},
+		},
+		{
+			query:   "SELECT id FROM users WHERE id IN (?)",
+			args:    []interface{}{[]int64{}},
+			wantErr: true,
+		},
+	}
+	for _, val := range cases {
+		query, args, err := In(val.query, val.args...)
+		if (err != nil) != val.wantErr {
+			t.Errorf("In() error = %v, wantErr %v", err, val.wantErr)
+			continue
+		}
+		if val.wantErr {
+			continue
+		}
+		if query != val.wantQuery {
+			t.Errorf("In() query = %v, expected %v", query, val.wantQuery)
+		}
+		if !reflect.DeepEqual(args, val.wantArgs) {
+			t.Errorf("In() args = %v, expected %v", args, val.wantArgs)
+		}
+	}
+}
+
+func TestTxExec(t *testing.T) {
+	mock, err := InitDBMock()
+	if err != nil {
+		t.Errorf("Failed to InitMockDB")
+	}
+
+	mock.ExpectExec(`^DELETE FROM users WHERE id IN \(\?, \?\)$`).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	result, err := Exec("DELETE FROM users WHERE id IN (?)", []int64{1, 2})
+	if err != nil {
+		t.Errorf("Exec() error = %v", err)
+		return
+	}
+	if rows, _ := result.RowsAffected(); rows != 2 {
+		t.Errorf("Exec() rows = %v, expected %v", rows, 2)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE users SET name = \(\?\) WHERE id = \(\?\)$`).
+		WithArgs("name'); DROP TABLE users; --", int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx := DB.MustBegin()
+	_, err = TxExec(tx, "UPDATE users SET name = (?) WHERE id = (?)", "name'); DROP TABLE users; --", int64(1))
+	if err != nil {
+		t.Errorf("TxExec() error = %v", err)
+	}
+	tx.Commit()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("TxExec() expectations = %v", err)
+	}
+}
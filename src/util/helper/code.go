@@ -0,0 +1,22 @@
+package helper
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// GenerateVerificationCode generate a random 4 digits code to be sent by email or SMS, it is generated
+// by crypto/rand so the code can't be guessed from the time of the request
+/*
+	@params:
+	@example:
+	@return
+		code	= 1408
+*/
+func GenerateVerificationCode() (uint16, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(9000))
+	if err != nil {
+		return 0, err
+	}
+	return uint16(n.Int64() + 1000), nil
+}
@@ -0,0 +1,15 @@
+package helper
+
+import "testing"
+
+func TestGenerateVerificationCode(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		code, err := GenerateVerificationCode()
+		if err != nil {
+			t.Fatalf("GenerateVerificationCode() error = %v", err)
+		}
+		if code < 1000 || code > 9999 {
+			t.Fatalf("GenerateVerificationCode() = %v, want 4 digits", code)
+		}
+	}
+}
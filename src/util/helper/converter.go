@@ -195,6 +195,21 @@ func Int64ToStringSlice(value []int64) []string {
 	return str
 }
 
+// EscapeLike escapes the wildcard characters of text so it can be bound as a literal LIKE pattern
+/*
+	@params:
+		text	= string
+	@example:
+		text	= 100%_done
+	@return
+		string	= 100\%\_done
+*/
+func EscapeLike(text string) string {
+	return likeReplacer.Replace(text)
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // TimeToDayInt converts time.Time slice into days int8
 /*
 	@params:
@@ -684,6 +684,46 @@ func TestInt64ToStringSlice(t *testing.T) {
 	}
 }
 
+func TestEscapeLike(t *testing.T) {
+	type args struct {
+		text string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "Plain text",
+			args: args{
+				text: "risal falah",
+			},
+			want: "risal falah",
+		},
+		{
+			name: "Wildcard",
+			args: args{
+				text: "100%_done",
+			},
+			want: `100\%\_done`,
+		},
+		{
+			name: "Escape character",
+			args: args{
+				text: `a\b`,
+			},
+			want: `a\\b`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeLike(tt.args.text); got != tt.want {
+				t.Errorf("EscapeLike() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTimeToDayInt(t *testing.T) {
 	type args struct {
 		time []time.Time
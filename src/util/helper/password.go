@@ -0,0 +1,64 @@
+package helper
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordCost is the bcrypt cost used for every newly stored password.
+// Stored hashes with a lower cost are upgraded on the next successful sign in.
+const PasswordCost = 11
+
+// bcrypt hashes are self describing: $<version>$<cost>$<salt+hash>.
+// Anything else in the password column is a legacy unsalted MD5 digest.
+const passwordPrefix = "$2"
+
+// HashPassword generate salted bcrypt hash of the password to be stored
+/*
+	@params:
+		password	= string
+	@example:
+		password	= 2af9b1ba42dc5eb01743e6b3759b6e4b
+	@return
+		hash		= $2a$11$FQGcsbRmZ1iOPfE3A/Q4K.3mN2vXoS5ZbF3Yh6b7Ljq6hSh8nq7xK
+*/
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// ComparePassword check whether the password match the stored hash.
+// rehash is true when the password match but the hash is a legacy format
+// or weaker than PasswordCost and should be replaced by HashPassword
+/*
+	@params:
+		hash		= string
+		password	= string
+	@example:
+		hash		= 2af9b1ba42dc5eb01743e6b3759b6e4b
+		password	= 2af9b1ba42dc5eb01743e6b3759b6e4b
+	@return
+		match		= true
+		rehash		= true
+*/
+func ComparePassword(hash, password string) (match, rehash bool) {
+	if !strings.HasPrefix(hash, passwordPrefix) {
+		match = subtle.ConstantTimeCompare([]byte(hash), []byte(password)) == 1
+		return match, match
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) != nil {
+		return false, false
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil || cost < PasswordCost {
+		return true, true
+	}
+	return true, false
+}
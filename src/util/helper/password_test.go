@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashPassword(t *testing.T) {
+	password := StringToMD5("Khairil14001")
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		t.Errorf("HashPassword() error = %v", err)
+		return
+	}
+	if !strings.HasPrefix(hash, passwordPrefix) {
+		t.Errorf("HashPassword() = %v, want bcrypt format", hash)
+	}
+
+	other, _ := HashPassword(password)
+	if hash == other {
+		t.Errorf("HashPassword() generate the same hash twice, want salted hash")
+	}
+}
+
+func TestComparePassword(t *testing.T) {
+	password := StringToMD5("Khairil14001")
+	current, _ := HashPassword(password)
+	weak, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+
+	type args struct {
+		hash     string
+		password string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantMatch  bool
+		wantRehash bool
+	}{
+		{
+			name:       "Current hash",
+			args:       args{hash: current, password: password},
+			wantMatch:  true,
+			wantRehash: false,
+		},
+		{
+			name:       "Current hash wrong password",
+			args:       args{hash: current, password: StringToMD5("Khairil14002")},
+			wantMatch:  false,
+			wantRehash: false,
+		},
+		{
+			name:       "Weak hash",
+			args:       args{hash: string(weak), password: password},
+			wantMatch:  true,
+			wantRehash: true,
+		},
+		{
+			name:       "Legacy hash",
+			args:       args{hash: password, password: password},
+			wantMatch:  true,
+			wantRehash: true,
+		},
+		{
+			name:       "Legacy hash wrong password",
+			args:       args{hash: password, password: StringToMD5("Khairil14002")},
+			wantMatch:  false,
+			wantRehash: false,
+		},
+		{
+			name:       "Unusable password",
+			args:       args{hash: "x", password: password},
+			wantMatch:  false,
+			wantRehash: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, rehash := ComparePassword(tt.args.hash, tt.args.password)
+			if match != tt.wantMatch || rehash != tt.wantRehash {
+				t.Errorf("ComparePassword() = %v, %v, want %v, %v", match, rehash, tt.wantMatch, tt.wantRehash)
+			}
+		})
+	}
+}
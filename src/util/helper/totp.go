@@ -0,0 +1,175 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters of RFC 6238, the defaults of most authenticator apps
+const (
+	TOTPPeriod = 30
+	TOTPDigits = 6
+	totpSkew   = 1
+	totpSecret = 20
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret generate a random base32 secret to be shared with the authenticator app
+/*
+	@params:
+	@example:
+	@return
+		secret	= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+*/
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, totpSecret)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPCode returns the code of the secret at t
+/*
+	@params:
+		secret	= string
+		t		= time.Time
+	@example:
+		secret	= GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ
+		t		= 1970-01-01 00:00:59 UTC
+	@return
+		code	= 287082
+*/
+func TOTPCode(secret string, t time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, uint64(t.Unix()/TOTPPeriod)), nil
+}
+
+// IsValidTOTP check the code against the secret, a code of the previous and the next period is accepted for clock drift
+/*
+	@params:
+		secret	= string
+		code	= string
+		t		= time.Time
+	@example:
+		secret	= GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ
+		code	= 287082
+		t		= 1970-01-01 00:00:59 UTC
+	@return
+		valid	= true
+*/
+func IsValidTOTP(secret, code string, t time.Time) bool {
+	if len(code) != TOTPDigits {
+		return false
+	}
+
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return false
+	}
+
+	counter := t.Unix() / TOTPPeriod
+	for i := int64(-totpSkew); i <= totpSkew; i++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, uint64(counter+i))), []byte(code)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// TOTPURI returns the otpauth URI to be rendered as QR code for the authenticator app
+/*
+	@params:
+		issuer	= string
+		account	= string
+		secret	= string
+	@example:
+		issuer	= Meiko
+		account	= risal@live.com
+		secret	= JBSWY3DPEHPK3PXP
+	@return
+		uri		= otpauth://totp/Meiko:risal@live.com?algorithm=SHA1&digits=6&issuer=Meiko&period=30&secret=JBSWY3DPEHPK3PXP
+*/
+func TOTPURI(issuer, account, secret string) string {
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprintf("%d", TOTPDigits))
+	values.Set("period", fmt.Sprintf("%d", TOTPPeriod))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: values.Encode(),
+	}
+	return u.String()
+}
+
+// hotp is the HMAC based one time password of RFC 4226
+func hotp(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
+
+// GenerateRecoveryCode generate a single use code to sign in when the authenticator app is lost
+/*
+	@params:
+	@example:
+	@return
+		code	= k3pxp-jbswy
+*/
+func GenerateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(totpEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// HashRecoveryCode returns the hash of the recovery code to be stored and compared.
+// Dash and letter case are ignored
+/*
+	@params:
+		code	= string
+	@example:
+		code	= K3PXP-JBSWY
+	@return
+		hash	= sha256 hex of k3pxpjbswy
+*/
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.Replace(strings.TrimSpace(code), "-", "", -1))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// secret of RFC 6238 test vectors, "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "Test Case 1", unix: 59, want: "287082"},
+		{name: "Test Case 2", unix: 1111111109, want: "081804"},
+		{name: "Test Case 3", unix: 1234567890, want: "005924"},
+		{name: "Test Case 4", unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TOTPCode(rfcSecret, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Errorf("TOTPCode() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TOTPCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	tests := []struct {
+		name string
+		code string
+		t    time.Time
+		want bool
+	}{
+		{name: "Current period", code: "005924", t: now, want: true},
+		{name: "Previous period", code: "005924", t: now.Add(TOTPPeriod * time.Second), want: true},
+		{name: "Expired", code: "005924", t: now.Add(3 * TOTPPeriod * time.Second), want: false},
+		{name: "Wrong code", code: "123456", t: now, want: false},
+		{name: "Wrong length", code: "5924", t: now, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidTOTP(rfcSecret, tt.code, tt.t); got != tt.want {
+				t.Errorf("IsValidTOTP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Errorf("GenerateTOTPSecret() error = %v", err)
+		return
+	}
+	if len(secret) != 32 {
+		t.Errorf("GenerateTOTPSecret() length = %d, want %d", len(secret), 32)
+	}
+	if _, err := TOTPCode(secret, time.Now()); err != nil {
+		t.Errorf("TOTPCode() error = %v", err)
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	got := TOTPURI("Meiko", "risal@live.com", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(got, "otpauth://totp/Meiko:risal@live.com?") {
+		t.Errorf("TOTPURI() = %v", got)
+	}
+	if !strings.Contains(got, "secret=JBSWY3DPEHPK3PXP") || !strings.Contains(got, "issuer=Meiko") {
+		t.Errorf("TOTPURI() = %v", got)
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	code, err := GenerateRecoveryCode()
+	if err != nil {
+		t.Errorf("GenerateRecoveryCode() error = %v", err)
+		return
+	}
+	if len(code) != 11 || code[5] != '-' {
+		t.Errorf("GenerateRecoveryCode() = %v", code)
+	}
+	if HashRecoveryCode(code) != HashRecoveryCode(" "+strings.ToUpper(strings.Replace(code, "-", "", 1))) {
+		t.Errorf("HashRecoveryCode() should ignore dash and letter case")
+	}
+}
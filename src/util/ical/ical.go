@@ -0,0 +1,97 @@
+package ical
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	timeLayout = "20060102T150405Z"
+	lineLength = 75
+)
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// Write encodes the calendar as RFC 5545, the times are written in UTC
+/*
+	@params:
+		w		= io.Writer
+		c		= Calendar
+	@example:
+		c		= {ProductID: -//Meiko//Timetable//EN, Name: Risal Falah, Events: [...]}
+	@return
+*/
+func Write(w io.Writer, c Calendar) error {
+	var buf bytes.Buffer
+	line(&buf, "BEGIN:VCALENDAR")
+	line(&buf, "VERSION:2.0")
+	line(&buf, "PRODID:"+c.ProductID)
+	line(&buf, "CALSCALE:GREGORIAN")
+	line(&buf, "METHOD:PUBLISH")
+	if len(c.Name) > 0 {
+		line(&buf, "X-WR-CALNAME:"+Escape(c.Name))
+	}
+
+	stamp := formatTime(time.Now())
+	for _, e := range c.Events {
+		line(&buf, "BEGIN:VEVENT")
+		line(&buf, "UID:"+e.UID)
+		line(&buf, "DTSTAMP:"+stamp)
+		line(&buf, "DTSTART:"+formatTime(e.Start))
+		line(&buf, "DTEND:"+formatTime(e.End))
+		if !e.Until.IsZero() {
+			line(&buf, "RRULE:FREQ=WEEKLY;UNTIL="+formatTime(e.Until))
+		}
+		line(&buf, "SUMMARY:"+Escape(e.Summary))
+		if len(e.Description) > 0 {
+			line(&buf, "DESCRIPTION:"+Escape(e.Description))
+		}
+		if len(e.Location) > 0 {
+			line(&buf, "LOCATION:"+Escape(e.Location))
+		}
+		line(&buf, "END:VEVENT")
+	}
+	line(&buf, "END:VCALENDAR")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Escape escapes the text value of a property
+func Escape(value string) string {
+	return escaper.Replace(value)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// line writes the content line, a line longer than 75 octets is folded without splitting a character
+func line(buf *bytes.Buffer, value string) {
+	limit := lineLength
+	for len(value) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(value[cut]) {
+			cut--
+		}
+		buf.WriteString(value[:cut])
+		buf.WriteString("\r\n ")
+		value = value[cut:]
+		// the leading space of the folded line counts to its length
+		limit = lineLength - 1
+	}
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,68 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestWrite(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	c := Calendar{
+		ProductID: "-//Meiko//Timetable//EN",
+		Name:      "Risal Falah",
+		Events: []Event{
+			{
+				UID:      "schedule-100192@meiko",
+				Summary:  "Algoritma, A",
+				Location: "UDJT-102",
+				Start:    time.Date(2018, 8, 27, 10, 0, 0, 0, loc),
+				End:      time.Date(2018, 8, 27, 11, 40, 0, 0, loc),
+				Until:    time.Date(2018, 12, 21, 23, 59, 59, 0, loc),
+			},
+			{
+				UID:         "assignment-1@meiko",
+				Summary:     "Tugas 1",
+				Description: "Line one\nline two; " + strings.Repeat("é", 60),
+				Start:       time.Date(2018, 9, 1, 23, 59, 0, 0, loc),
+				End:         time.Date(2018, 9, 1, 23, 59, 0, 0, loc),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, c); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	got := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Meiko//Timetable//EN\r\n",
+		"X-WR-CALNAME:Risal Falah\r\n",
+		"DTSTART:20180827T030000Z\r\nDTEND:20180827T044000Z\r\nRRULE:FREQ=WEEKLY;UNTIL=20181221T165959Z\r\nSUMMARY:Algoritma\\, A\r\n",
+		"LOCATION:UDJT-102\r\n",
+		"DESCRIPTION:Line one\\nline two\\; ",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Write() = %q, should contain %q", got, want)
+		}
+	}
+	if strings.Count(got, "BEGIN:VEVENT") != 2 || strings.Count(got, "RRULE") != 1 {
+		t.Errorf("Write() = %q, want 2 events with 1 recurrence", got)
+	}
+
+	for _, l := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+		if len(l) > lineLength {
+			t.Errorf("Write() line %q is longer than %d octets", l, lineLength)
+		}
+		if !utf8.ValidString(l) {
+			t.Errorf("Write() line %q splits a character", l)
+		}
+	}
+	if !strings.Contains(strings.Replace(got, "\r\n ", "", -1), strings.Repeat("é", 60)) {
+		t.Errorf("Write() folded description can't be unfolded")
+	}
+}
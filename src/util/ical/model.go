@@ -0,0 +1,26 @@
+package ical
+
+import (
+	"time"
+)
+
+// ContentType is the media type of the iCalendar document
+const ContentType = "text/calendar; charset=utf-8"
+
+// Calendar is an iCalendar document which can be subscribed by calendar apps
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []Event
+}
+
+// Event is a VEVENT of the calendar, a valid Until repeats it weekly until that time
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	Until       time.Time
+}
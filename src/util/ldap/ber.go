@@ -0,0 +1,134 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// maxPacketLength protects the client from a directory which sends a huge length
+const maxPacketLength = 1 << 20
+
+// encode returns the BER encoding of the element with the given content
+func encode(tag byte, content ...[]byte) []byte {
+	var n int
+	for _, v := range content {
+		n += len(v)
+	}
+
+	b := []byte{tag}
+	if n < 0x80 {
+		b = append(b, byte(n))
+	} else {
+		var l []byte
+		for x := n; x > 0; x >>= 8 {
+			l = append([]byte{byte(x)}, l...)
+		}
+		b = append(b, 0x80|byte(len(l)))
+		b = append(b, l...)
+	}
+	for _, v := range content {
+		b = append(b, v...)
+	}
+	return b
+}
+
+func encodeString(tag byte, s string) []byte {
+	return encode(tag, []byte(s))
+}
+
+// encodeInt returns the two's complement encoding of the non negative integer
+func encodeInt(tag byte, i int64) []byte {
+	b := []byte{byte(i)}
+	for x := i >> 8; x > 0; x >>= 8 {
+		b = append([]byte{byte(x)}, b...)
+	}
+	if b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return encode(tag, b)
+}
+
+func encodeBool(b bool) []byte {
+	if b {
+		return encode(tagBoolean, []byte{0xff})
+	}
+	return encode(tagBoolean, []byte{0x00})
+}
+
+// read reads a whole element from the connection
+func read(r *bufio.Reader) (packet, error) {
+	var p packet
+	tag, err := r.ReadByte()
+	if err != nil {
+		return p, err
+	}
+
+	l, err := r.ReadByte()
+	if err != nil {
+		return p, err
+	}
+	n := int(l)
+	if l&0x80 != 0 {
+		size := int(l & 0x7f)
+		if size < 1 || size > 4 {
+			return p, fmt.Errorf("Invalid BER length")
+		}
+		n = 0
+		for i := 0; i < size; i++ {
+			b, err := r.ReadByte()
+			if err != nil {
+				return p, err
+			}
+			n = n<<8 | int(b)
+		}
+	}
+	if n > maxPacketLength {
+		return p, fmt.Errorf("BER element is too long")
+	}
+
+	value := make([]byte, n)
+	_, err = io.ReadFull(r, value)
+	if err != nil {
+		return p, err
+	}
+	return decode(tag, value)
+}
+
+// decode decodes the children of the constructed element
+func decode(tag byte, value []byte) (packet, error) {
+	p := packet{Tag: tag, Value: value}
+	if tag&0x20 == 0 {
+		return p, nil
+	}
+
+	r := bufio.NewReader(bytes.NewReader(value))
+	for {
+		child, err := read(r)
+		if err == io.EOF {
+			return p, nil
+		}
+		if err != nil {
+			return p, fmt.Errorf("Invalid BER element: %s", err.Error())
+		}
+		p.Children = append(p.Children, child)
+	}
+}
+
+// Int returns the value of the integer or the enumerated element
+func (p packet) Int() int64 {
+	var i int64
+	for k, b := range p.Value {
+		if k == 0 && b&0x80 != 0 {
+			i = -1
+		}
+		i = i<<8 | int64(b)
+	}
+	return i
+}
+
+// String returns the value of the octet string element
+func (p packet) String() string {
+	return string(p.Value)
+}
@@ -0,0 +1,322 @@
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimeout           = 10
+	defaultObjectClass       = "person"
+	defaultLoginAttribute    = "mail"
+	defaultNameAttribute     = "cn"
+	defaultEmailAttribute    = "mail"
+	defaultIdentityAttribute = "employeeNumber"
+	defaultGroupAttribute    = "memberOf"
+)
+
+// ErrInvalidCredentials is returned when the directory doesn't have the user or the password is wrong
+var ErrInvalidCredentials = fmt.Errorf("Invalid credentials")
+
+var c Config
+
+// Init sets the directory, the connection is made on every authentication
+func Init(cfg Config) {
+	if len(cfg.ObjectClass) < 1 {
+		cfg.ObjectClass = defaultObjectClass
+	}
+	if len(cfg.LoginAttribute) < 1 {
+		cfg.LoginAttribute = defaultLoginAttribute
+	}
+	if len(cfg.NameAttribute) < 1 {
+		cfg.NameAttribute = defaultNameAttribute
+	}
+	if len(cfg.EmailAttribute) < 1 {
+		cfg.EmailAttribute = defaultEmailAttribute
+	}
+	if len(cfg.IdentityAttribute) < 1 {
+		cfg.IdentityAttribute = defaultIdentityAttribute
+	}
+	if len(cfg.GroupAttribute) < 1 {
+		cfg.GroupAttribute = defaultGroupAttribute
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	groups := map[string]int64{}
+	for dn, id := range cfg.Groups {
+		groups[normalizeDN(dn)] = id
+	}
+	cfg.Groups = groups
+	c = cfg
+}
+
+// IsEnabled reports whether the directory authentication is configured
+func IsEnabled() bool {
+	return len(c.Address) > 0 && len(c.BaseDN) > 0
+}
+
+// Authenticate finds the user by the login attribute using the service account
+// then binds as the user to check the password
+/*
+	@params:
+		login		= string
+		password	= string
+	@example:
+		login		= risal@unpad.ac.id
+		password	= Qwerty123
+	@return
+		Entry
+*/
+func Authenticate(login, password string) (Entry, error) {
+	var entry Entry
+
+	// an empty password is an unauthenticated bind which always succeeds
+	if len(login) < 1 || len(password) < 1 {
+		return entry, ErrInvalidCredentials
+	}
+
+	conn, err := dial()
+	if err != nil {
+		return entry, err
+	}
+	defer conn.close()
+
+	res, err := conn.bind(c.BindDN, c.BindPassword)
+	if err != nil {
+		return entry, err
+	}
+	if res.Code != resultSuccess {
+		return entry, fmt.Errorf("Service bind failed: %d %s", res.Code, res.Message)
+	}
+
+	entries, err := conn.search(login)
+	if err != nil {
+		return entry, err
+	}
+	if len(entries) != 1 {
+		return entry, ErrInvalidCredentials
+	}
+	entry = entries[0]
+
+	res, err = conn.bind(entry.DN, password)
+	if err != nil {
+		return Entry{}, err
+	}
+	switch res.Code {
+	case resultSuccess:
+		return entry, nil
+	case resultInvalidCredentials:
+		return Entry{}, ErrInvalidCredentials
+	default:
+		return Entry{}, fmt.Errorf("User bind failed: %d %s", res.Code, res.Message)
+	}
+}
+
+// Rolegroup returns the rolegroup of the first group of the entry which is mapped in the configuration
+/*
+	@params:
+		entry	= Entry
+	@example:
+		entry	= Entry{Groups: []string{"cn=lecturers,ou=groups,dc=unpad,dc=ac,dc=id"}}
+	@return
+		id		= 2
+		ok		= true
+*/
+func Rolegroup(entry Entry) (int64, bool) {
+	for _, dn := range entry.Groups {
+		id, ok := c.Groups[normalizeDN(dn)]
+		if ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// normalizeDN makes the DN comparable regardless of the case and the spaces after the separator
+func normalizeDN(dn string) string {
+	rdn := strings.Split(dn, ",")
+	for i, v := range rdn {
+		rdn[i] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return strings.Join(rdn, ",")
+}
+
+// client is a connection to the directory, the operations are sent one at a time
+type client struct {
+	conn net.Conn
+	r    *bufio.Reader
+	id   int64
+}
+
+func dial() (*client, error) {
+	timeout := time.Duration(c.Timeout) * time.Second
+	d := &net.Dialer{Timeout: timeout}
+
+	var conn net.Conn
+	var err error
+	if c.TLS {
+		host, _, _ := net.SplitHostPort(c.Address)
+		conn, err = tls.DialWithDialer(d, "tcp", c.Address, &tls.Config{ServerName: host})
+	} else {
+		conn, err = d.Dial("tcp", c.Address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(time.Now().Add(timeout))
+	return &client{conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+func (cl *client) close() {
+	cl.send(encode(tagUnbindRequest))
+	cl.conn.Close()
+}
+
+// send wraps the operation into LDAPMessage with the next message ID
+func (cl *client) send(op []byte) (int64, error) {
+	cl.id++
+	_, err := cl.conn.Write(encode(tagSequence, encodeInt(tagInteger, cl.id), op))
+	return cl.id, err
+}
+
+// receive returns the operation of the next message of the request
+func (cl *client) receive(id int64) (packet, error) {
+	for {
+		msg, err := read(cl.r)
+		if err != nil {
+			return msg, err
+		}
+		if msg.Tag != tagSequence || len(msg.Children) < 2 || msg.Children[0].Tag != tagInteger {
+			return msg, fmt.Errorf("Invalid LDAP message")
+		}
+		// skip the unsolicited notification
+		if msg.Children[0].Int() != id {
+			continue
+		}
+		return msg.Children[1], nil
+	}
+}
+
+func (cl *client) bind(dn, password string) (result, error) {
+	id, err := cl.send(encode(tagBindRequest,
+		encodeInt(tagInteger, protocolVersion),
+		encodeString(tagOctetString, dn),
+		encodeString(tagSimpleAuth, password),
+	))
+	if err != nil {
+		return result{}, err
+	}
+
+	op, err := cl.receive(id)
+	if err != nil {
+		return result{}, err
+	}
+	if op.Tag != tagBindResponse {
+		return result{}, fmt.Errorf("Unexpected LDAP response: %x", op.Tag)
+	}
+	return parseResult(op)
+}
+
+// search returns the entries of the user, the size limit of 2 is enough to detect an ambiguous login
+func (cl *client) search(login string) ([]Entry, error) {
+	filter := encode(tagFilterAnd,
+		encode(tagFilterEqual, encodeString(tagOctetString, "objectClass"), encodeString(tagOctetString, c.ObjectClass)),
+		encode(tagFilterEqual, encodeString(tagOctetString, c.LoginAttribute), encodeString(tagOctetString, login)),
+	)
+	attributes := encode(tagSequence,
+		encodeString(tagOctetString, c.NameAttribute),
+		encodeString(tagOctetString, c.EmailAttribute),
+		encodeString(tagOctetString, c.IdentityAttribute),
+		encodeString(tagOctetString, c.GroupAttribute),
+	)
+	id, err := cl.send(encode(tagSearchRequest,
+		encodeString(tagOctetString, c.BaseDN),
+		encodeInt(tagEnumerated, scopeSubtree),
+		encodeInt(tagEnumerated, derefNever),
+		encodeInt(tagInteger, 2),
+		encodeInt(tagInteger, c.Timeout),
+		encodeBool(false),
+		filter,
+		attributes,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for {
+		op, err := cl.receive(id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch op.Tag {
+		case tagSearchEntry:
+			entries = append(entries, parseEntry(op))
+		case tagSearchReference:
+			continue
+		case tagSearchDone:
+			res, err := parseResult(op)
+			if err != nil {
+				return nil, err
+			}
+			// more than one entry exceeds the size limit, the login is ambiguous anyway
+			if res.Code != resultSuccess && len(entries) < 2 {
+				return nil, fmt.Errorf("Search failed: %d %s", res.Code, res.Message)
+			}
+			return entries, nil
+		default:
+			return nil, fmt.Errorf("Unexpected LDAP response: %x", op.Tag)
+		}
+	}
+}
+
+func parseResult(op packet) (result, error) {
+	if len(op.Children) < 3 {
+		return result{}, fmt.Errorf("Invalid LDAP result")
+	}
+	return result{
+		Code:    op.Children[0].Int(),
+		Message: op.Children[2].String(),
+	}, nil
+}
+
+// parseEntry maps the attributes of SearchResultEntry, only the first value is used except the groups
+func parseEntry(op packet) Entry {
+	var entry Entry
+	if len(op.Children) < 1 {
+		return entry
+	}
+	entry.DN = op.Children[0].String()
+	if len(op.Children) < 2 {
+		return entry
+	}
+
+	for _, attr := range op.Children[1].Children {
+		if len(attr.Children) < 2 || len(attr.Children[1].Children) < 1 {
+			continue
+		}
+		name := attr.Children[0].String()
+		values := attr.Children[1].Children
+		switch {
+		case strings.EqualFold(name, c.GroupAttribute):
+			for _, v := range values {
+				entry.Groups = append(entry.Groups, v.String())
+			}
+		case strings.EqualFold(name, c.NameAttribute):
+			entry.Name = values[0].String()
+		case strings.EqualFold(name, c.EmailAttribute):
+			entry.Email = values[0].String()
+		case strings.EqualFold(name, c.IdentityAttribute):
+			entry.IdentityCode = values[0].String()
+		}
+	}
+	return entry
+}
@@ -0,0 +1,253 @@
+package ldap
+
+import (
+	"bufio"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// directory is a stub LDAP server with the users keyed by DN
+type directory struct {
+	ln      net.Listener
+	users   map[string]fakeUser
+	service string
+}
+
+type fakeUser struct {
+	password string
+	mail     string
+	attrs    [][]string
+}
+
+func newDirectory(t *testing.T) *directory {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &directory{
+		ln:      ln,
+		service: "secret",
+		users: map[string]fakeUser{
+			"uid=risal,ou=people,dc=unpad,dc=ac,dc=id": {
+				password: "Qwerty123",
+				mail:     "risal@unpad.ac.id",
+				attrs: [][]string{
+					{"cn", "Risal Falah"},
+					{"mail", "risal@unpad.ac.id"},
+					{"employeeNumber", "140810140016"},
+					{"memberOf", "cn=staff,ou=groups,dc=unpad,dc=ac,dc=id", "CN=Lecturers, OU=Groups,DC=unpad,DC=ac,DC=id"},
+				},
+			},
+			"uid=twin1,ou=people,dc=unpad,dc=ac,dc=id": {password: "Qwerty123", mail: "twin@unpad.ac.id"},
+			"uid=twin2,ou=people,dc=unpad,dc=ac,dc=id": {password: "Qwerty123", mail: "twin@unpad.ac.id"},
+		},
+	}
+	go d.serve()
+	return d
+}
+
+func (d *directory) serve() {
+	for {
+		conn, err := d.ln.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func (d *directory) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		msg, err := read(r)
+		if err != nil {
+			return
+		}
+		id := encodeInt(tagInteger, msg.Children[0].Int())
+		op := msg.Children[1]
+		reply := func(ops ...[]byte) {
+			for _, v := range ops {
+				conn.Write(encode(tagSequence, id, v))
+			}
+		}
+		done := func(tag byte, code int64) []byte {
+			return encode(tag, encodeInt(tagEnumerated, code), encodeString(tagOctetString, ""), encodeString(tagOctetString, ""))
+		}
+
+		switch op.Tag {
+		case tagUnbindRequest:
+			return
+		case tagBindRequest:
+			dn, password := op.Children[1].String(), op.Children[2].String()
+			u, ok := d.users[dn]
+			if (dn == "cn=admin,dc=unpad,dc=ac,dc=id" && password == d.service) || (ok && u.password == password) {
+				reply(done(tagBindResponse, resultSuccess))
+			} else {
+				reply(done(tagBindResponse, resultInvalidCredentials))
+			}
+		case tagSearchRequest:
+			login := op.Children[6].Children[1].Children[1].String()
+			var ops [][]byte
+			for dn, u := range d.users {
+				if u.mail != login {
+					continue
+				}
+				var attrs [][]byte
+				for _, a := range u.attrs {
+					var values [][]byte
+					for _, v := range a[1:] {
+						values = append(values, encodeString(tagOctetString, v))
+					}
+					attrs = append(attrs, encode(tagSequence, encodeString(tagOctetString, a[0]), encode(tagSet, values...)))
+				}
+				ops = append(ops, encode(tagSearchEntry, encodeString(tagOctetString, dn), encode(tagSequence, attrs...)))
+			}
+			reply(append(ops, done(tagSearchDone, resultSuccess))...)
+		}
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	d := newDirectory(t)
+	defer d.ln.Close()
+
+	Init(Config{
+		Address:      d.ln.Addr().String(),
+		BindDN:       "cn=admin,dc=unpad,dc=ac,dc=id",
+		BindPassword: "secret",
+		BaseDN:       "ou=people,dc=unpad,dc=ac,dc=id",
+		Groups: map[string]int64{
+			"cn=lecturers,ou=groups,dc=unpad,dc=ac,dc=id": 2,
+		},
+	})
+
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		service  string
+		want     Entry
+		wantErr  error
+	}{
+		{
+			name:     "Test Case 1",
+			login:    "risal@unpad.ac.id",
+			password: "Qwerty123",
+			service:  "secret",
+			want: Entry{
+				DN:           "uid=risal,ou=people,dc=unpad,dc=ac,dc=id",
+				Name:         "Risal Falah",
+				Email:        "risal@unpad.ac.id",
+				IdentityCode: "140810140016",
+				Groups:       []string{"cn=staff,ou=groups,dc=unpad,dc=ac,dc=id", "CN=Lecturers, OU=Groups,DC=unpad,DC=ac,DC=id"},
+			},
+		},
+		{
+			name:     "Test Case 2",
+			login:    "risal@unpad.ac.id",
+			password: "Qwerty12",
+			service:  "secret",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "Test Case 3",
+			login:    "risal@unpad.ac.id",
+			password: "",
+			service:  "secret",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "Test Case 4",
+			login:    "unknown@unpad.ac.id",
+			password: "Qwerty123",
+			service:  "secret",
+			wantErr:  ErrInvalidCredentials,
+		},
+		{
+			name:     "Test Case 5",
+			login:    "twin@unpad.ac.id",
+			password: "Qwerty123",
+			service:  "secret",
+			wantErr:  ErrInvalidCredentials,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d.service = tt.service
+			got, err := Authenticate(tt.login, tt.password)
+			if err != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Authenticate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the service account is rejected
+	d.service = "changed"
+	_, err := Authenticate("risal@unpad.ac.id", "Qwerty123")
+	if err == nil || err == ErrInvalidCredentials {
+		t.Errorf("Authenticate() error = %v, want service bind error", err)
+	}
+}
+
+func TestRolegroup(t *testing.T) {
+	Init(Config{
+		Groups: map[string]int64{
+			"CN=Lecturers,OU=Groups,DC=unpad,DC=ac,DC=id": 2,
+			"cn=admins,ou=groups,dc=unpad,dc=ac,dc=id":    1,
+		},
+	})
+
+	tests := []struct {
+		name   string
+		groups []string
+		want   int64
+		wantOk bool
+	}{
+		{
+			name:   "Test Case 1",
+			groups: nil,
+		},
+		{
+			name:   "Test Case 2",
+			groups: []string{"cn=staff,ou=groups,dc=unpad,dc=ac,dc=id"},
+		},
+		{
+			name:   "Test Case 3",
+			groups: []string{"cn=staff,ou=groups,dc=unpad,dc=ac,dc=id", "cn=lecturers, ou=groups, dc=unpad, dc=ac, dc=id"},
+			want:   2,
+			wantOk: true,
+		},
+		{
+			name:   "Test Case 4",
+			groups: []string{"cn=admins,ou=groups,dc=unpad,dc=ac,dc=id", "cn=lecturers,ou=groups,dc=unpad,dc=ac,dc=id"},
+			want:   1,
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Rolegroup(Entry{Groups: tt.groups})
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Rolegroup() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestEncodeLength(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	p, err := read(bufio.NewReader(strings.NewReader(string(encode(tagSequence, encodeString(tagOctetString, long), encodeInt(tagInteger, 128))))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Children) != 2 || p.Children[0].String() != long || p.Children[1].Int() != 128 {
+		t.Errorf("read() = %v", p)
+	}
+}
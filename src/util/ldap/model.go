@@ -0,0 +1,69 @@
+package ldap
+
+// Config is the directory used to authenticate the staff, an empty Address disables it.
+// Groups maps the DN of the directory groups to the rolegroup which is assigned to the members
+type Config struct {
+	Address           string           `json:"address"`
+	TLS               bool             `json:"tls"`
+	BindDN            string           `json:"binddn"`
+	BindPassword      string           `json:"bindpassword"`
+	BaseDN            string           `json:"basedn"`
+	ObjectClass       string           `json:"objectclass"`
+	LoginAttribute    string           `json:"loginattribute"`
+	NameAttribute     string           `json:"nameattribute"`
+	EmailAttribute    string           `json:"emailattribute"`
+	IdentityAttribute string           `json:"identityattribute"`
+	GroupAttribute    string           `json:"groupattribute"`
+	Groups            map[string]int64 `json:"groups"`
+	Timeout           int64            `json:"timeout"`
+}
+
+// Entry is the directory entry of the authenticated user with the mapped attributes
+type Entry struct {
+	DN           string
+	Name         string
+	Email        string
+	IdentityCode string
+	Groups       []string
+}
+
+// packet is a decoded BER element, the children are only decoded for constructed elements
+type packet struct {
+	Tag      byte
+	Value    []byte
+	Children []packet
+}
+
+// result is the LDAPResult of the bind and the search operation
+type result struct {
+	Code    int64
+	Message string
+}
+
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagBoolean     = 0x01
+	tagSequence    = 0x30
+	tagSet         = 0x31
+
+	tagBindRequest     = 0x60
+	tagBindResponse    = 0x61
+	tagUnbindRequest   = 0x42
+	tagSearchRequest   = 0x63
+	tagSearchEntry     = 0x64
+	tagSearchDone      = 0x65
+	tagSearchReference = 0x73
+
+	tagSimpleAuth  = 0x80
+	tagFilterAnd   = 0xa0
+	tagFilterEqual = 0xa3
+
+	protocolVersion = 3
+	scopeSubtree    = 2
+	derefNever      = 0
+
+	resultSuccess            = 0
+	resultInvalidCredentials = 49
+)
@@ -0,0 +1,56 @@
+package oidc
+
+import "time"
+
+// Config is the client registration on the OpenID Connect provider, an empty Issuer disables it
+type Config struct {
+	Issuer        string   `json:"issuer"`
+	ClientID      string   `json:"clientid"`
+	ClientSecret  string   `json:"clientsecret"`
+	RedirectURL   string   `json:"redirecturl"`
+	Scopes        []string `json:"scopes"`
+	IdentityClaim string   `json:"identityclaim"`
+	Timeout       int64    `json:"timeout"`
+}
+
+// Claims are the verified claims of the ID token which are used to find or create the user
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+	IdentityCode  string
+}
+
+// provider is the discovery document of the issuer
+type provider struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+	Error   string `json:"error"`
+}
+
+type jwks struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// leeway is the tolerated clock difference with the provider
+const leeway = 60 * time.Second
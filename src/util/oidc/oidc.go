@@ -0,0 +1,332 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout       = 10
+	defaultIdentityClaim = "identity_code"
+	discoveryPath        = "/.well-known/openid-configuration"
+)
+
+var (
+	c      Config
+	client *http.Client
+
+	// the discovery document and the signing keys are cached until the next Init
+	mu   sync.Mutex
+	p    *provider
+	keys map[string]*rsa.PublicKey
+)
+
+// Init sets the client registration, the provider is discovered on the first use
+func Init(cfg Config) {
+	if len(cfg.Scopes) < 1 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if len(cfg.IdentityClaim) < 1 {
+		cfg.IdentityClaim = defaultIdentityClaim
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+
+	mu.Lock()
+	defer mu.Unlock()
+	c = cfg
+	client = &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second}
+	p = nil
+	keys = nil
+}
+
+// IsEnabled reports whether the single sign on is configured
+func IsEnabled() bool {
+	return len(c.Issuer) > 0 && len(c.ClientID) > 0
+}
+
+// AuthCodeURL returns the URL of the provider sign in page, the provider redirects back
+// to Config.RedirectURL with the state and the code which is exchanged by Exchange
+/*
+	@params:
+		state	= string
+		nonce	= string
+	@example:
+		state	= Rk1YqTmGz0
+		nonce	= 3WbQ0cM1zA
+	@return
+		url		= https://sso.unpad.ac.id/authorize?client_id=meiko&nonce=3WbQ0cM1zA&...
+*/
+func AuthCodeURL(state, nonce string) (string, error) {
+	pv, err := getProvider()
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", c.ClientID)
+	q.Set("redirect_uri", c.RedirectURL)
+	q.Set("scope", strings.Join(c.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(pv.AuthURL, "?") {
+		sep = "&"
+	}
+	return pv.AuthURL + sep + q.Encode(), nil
+}
+
+// Exchange trades the authorization code for the ID token and returns its verified claims.
+// The caller still has to compare the nonce with the one sent by AuthCodeURL
+func Exchange(code string) (Claims, error) {
+	var claims Claims
+	pv, err := getProvider()
+	if err != nil {
+		return claims, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.RedirectURL)
+
+	req, err := http.NewRequest(http.MethodPost, pv.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return claims, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return claims, err
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return claims, err
+	}
+	if resp.StatusCode != http.StatusOK || len(token.IDToken) < 1 {
+		return claims, fmt.Errorf("Token exchange failed: %d %s", resp.StatusCode, token.Error)
+	}
+
+	return verify(token.IDToken, time.Now())
+}
+
+// verify checks the signature and the registered claims of the ID token
+func verify(raw string, now time.Time) (Claims, error) {
+	var claims Claims
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return claims, fmt.Errorf("Malformed ID token")
+	}
+
+	var h header
+	err := decodeSegment(parts[0], &h)
+	if err != nil {
+		return claims, err
+	}
+	if h.Alg != "RS256" {
+		return claims, fmt.Errorf("Unsupported ID token algorithm %s", h.Alg)
+	}
+
+	key, err := getKey(h.Kid)
+	if err != nil {
+		return claims, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, err
+	}
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig)
+	if err != nil {
+		return claims, fmt.Errorf("Invalid ID token signature")
+	}
+
+	payload := map[string]interface{}{}
+	err = decodeSegment(parts[1], &payload)
+	if err != nil {
+		return claims, err
+	}
+
+	pv, err := getProvider()
+	if err != nil {
+		return claims, err
+	}
+	if stringClaim(payload, "iss") != pv.Issuer {
+		return claims, fmt.Errorf("Invalid ID token issuer")
+	}
+	if !hasAudience(payload["aud"], c.ClientID) {
+		return claims, fmt.Errorf("Invalid ID token audience")
+	}
+	exp, ok := payload["exp"].(json.Number)
+	if !ok {
+		return claims, fmt.Errorf("Invalid ID token expiry")
+	}
+	expiry, err := exp.Int64()
+	if err != nil || now.Add(-leeway).After(time.Unix(expiry, 0)) {
+		return claims, fmt.Errorf("ID token is expired")
+	}
+
+	claims = Claims{
+		Subject:       stringClaim(payload, "sub"),
+		Email:         strings.ToLower(stringClaim(payload, "email")),
+		EmailVerified: boolClaim(payload, "email_verified"),
+		Name:          stringClaim(payload, "name"),
+		Nonce:         stringClaim(payload, "nonce"),
+		IdentityCode:  stringClaim(payload, c.IdentityClaim),
+	}
+	if len(claims.Subject) < 1 {
+		return claims, fmt.Errorf("ID token has no subject")
+	}
+	return claims, nil
+}
+
+// getProvider returns the discovery document of the issuer
+func getProvider() (provider, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if p != nil {
+		return *p, nil
+	}
+
+	if !IsEnabled() {
+		return provider{}, fmt.Errorf("OpenID Connect is not configured")
+	}
+
+	var pv provider
+	err := getJSON(c.Issuer+discoveryPath, &pv)
+	if err != nil {
+		return pv, err
+	}
+	if strings.TrimSuffix(pv.Issuer, "/") != c.Issuer {
+		return pv, fmt.Errorf("Discovered issuer %s does not match", pv.Issuer)
+	}
+	p = &pv
+	return pv, nil
+}
+
+// getKey returns the signing key by its id, the keys are fetched again
+// when the id is unknown since the provider may have rotated them
+func getKey(kid string) (*rsa.PublicKey, error) {
+	pv, err := getProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+
+	var set jwks
+	err = getJSON(pv.JWKSURL, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys = map[string]*rsa.PublicKey{}
+	for _, val := range set.Keys {
+		if val.Kty != "RSA" || (len(val.Use) > 0 && val.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(val.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(val.E)
+		if err != nil {
+			continue
+		}
+		keys[val.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("Unknown ID token key %s", kid)
+	}
+	return key, nil
+}
+
+func getJSON(u string, v interface{}) error {
+	resp, err := client.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(strings.NewReader(string(b)))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// hasAudience reports whether aud, either a string or an array, contains the client id
+func hasAudience(aud interface{}, clientID string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == clientID
+	case []interface{}:
+		for _, val := range v {
+			if s, ok := val.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringClaim returns the claim as string, numeric claims such as the identity code are allowed
+func stringClaim(payload map[string]interface{}, name string) string {
+	switch v := payload[name].(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return ""
+}
+
+// boolClaim returns the claim as bool, some providers send email_verified as string
+func boolClaim(payload map[string]interface{}, name string) bool {
+	switch v := payload[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// stubIdP is a local OpenID Connect provider which issues the ID token of the test case
+type stubIdP struct {
+	*httptest.Server
+	key     *rsa.PrivateKey
+	idToken string
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &stubIdP{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 s.URL,
+			"authorization_endpoint": s.URL + "/authorize",
+			"token_endpoint":         s.URL + "/token",
+			"jwks_uri":               s.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "k1",
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		if r.FormValue("code") != "good-code" || id != "meiko" || secret != "secret" ||
+			r.FormValue("grant_type") != "authorization_code" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": s.idToken, "token_type": "Bearer"})
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *stubIdP) sign(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	h, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": kid, "typ": "JWT"})
+	p, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.Close()
+	Init(Config{Issuer: idp.URL, ClientID: "meiko", ClientSecret: "secret", RedirectURL: "http://localhost/callback"})
+
+	u, err := AuthCodeURL("state-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	parsed, _ := url.Parse(u)
+	q := parsed.Query()
+	if parsed.Path != "/authorize" || q.Get("client_id") != "meiko" || q.Get("state") != "state-1" ||
+		q.Get("nonce") != "nonce-1" || q.Get("scope") != "openid email profile" ||
+		q.Get("redirect_uri") != "http://localhost/callback" || q.Get("response_type") != "code" {
+		t.Errorf("AuthCodeURL() = %s", u)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	idp := newStubIdP(t)
+	defer idp.Close()
+	Init(Config{Issuer: idp.URL, ClientID: "meiko", ClientSecret: "secret", IdentityClaim: "npm"})
+
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	claims := func(change map[string]interface{}) map[string]interface{} {
+		val := map[string]interface{}{
+			"iss":            idp.URL,
+			"sub":            "u-140810140016",
+			"aud":            "meiko",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          "nonce-1",
+			"email":          "Risal@Live.com",
+			"email_verified": true,
+			"name":           "Risal Falah",
+			"npm":            140810140016,
+		}
+		for k, v := range change {
+			val[k] = v
+		}
+		return val
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		token   string
+		want    Claims
+		wantErr bool
+	}{
+		{
+			name:  "Test Case 1",
+			code:  "good-code",
+			token: idp.sign(t, idp.key, "k1", claims(nil)),
+			want: Claims{
+				Subject:       "u-140810140016",
+				Email:         "risal@live.com",
+				EmailVerified: true,
+				Name:          "Risal Falah",
+				Nonce:         "nonce-1",
+				IdentityCode:  "140810140016",
+			},
+		},
+		{
+			name:  "Test Case 2",
+			code:  "good-code",
+			token: idp.sign(t, idp.key, "k1", claims(map[string]interface{}{"aud": []string{"other", "meiko"}})),
+			want: Claims{
+				Subject:       "u-140810140016",
+				Email:         "risal@live.com",
+				EmailVerified: true,
+				Name:          "Risal Falah",
+				Nonce:         "nonce-1",
+				IdentityCode:  "140810140016",
+			},
+		},
+		{
+			name:    "Test Case 3",
+			code:    "bad-code",
+			token:   idp.sign(t, idp.key, "k1", claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 4",
+			code:    "good-code",
+			token:   idp.sign(t, other, "k1", claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 5",
+			code:    "good-code",
+			token:   idp.sign(t, idp.key, "k2", claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 6",
+			code:    "good-code",
+			token:   idp.sign(t, idp.key, "k1", claims(map[string]interface{}{"aud": "other"})),
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 7",
+			code:    "good-code",
+			token:   idp.sign(t, idp.key, "k1", claims(map[string]interface{}{"iss": "https://evil.example.com"})),
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 8",
+			code:    "good-code",
+			token:   idp.sign(t, idp.key, "k1", claims(map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()})),
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 9",
+			code:    "good-code",
+			token:   idp.sign(t, idp.key, "k1", claims(map[string]interface{}{"sub": ""})),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp.idToken = tt.token
+			got, err := Exchange(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Exchange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("Exchange() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	Init(Config{})
+	if IsEnabled() {
+		t.Errorf("IsEnabled() = true, want false")
+	}
+	if _, err := AuthCodeURL("state", "nonce"); err == nil {
+		t.Errorf("AuthCodeURL() error = nil on disabled provider")
+	}
+}
@@ -0,0 +1,61 @@
+package policy
+
+import (
+	cs "github.com/melodiez14/meiko/src/module/course"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/util/auth"
+)
+
+// xAbilities maps the abilities on own schedules to the abilities on all schedules
+var xAbilities = map[string]string{
+	rg.RoleCreate: rg.RoleXCreate,
+	rg.RoleRead:   rg.RoleXRead,
+	rg.RoleUpdate: rg.RoleXUpdate,
+	rg.RoleDelete: rg.RoleXDelete,
+}
+
+// isOwner reports whether the user assists or created the schedule
+var isOwner = func(userID, scheduleID int64) bool {
+	return cs.IsAssistant(userID, scheduleID) || cs.IsCreator(userID, scheduleID)
+}
+
+// Can reports whether the user is allowed to do the ability on the module of the schedule.
+// The X ability allows it on all schedules, the plain ability only on the schedules the user assists or created.
+// scheduleID 0 asks for the module itself, which either of them allows. Passing an X ability requires it exactly
+/*
+	@params:
+		u			= *auth.User, nil is never allowed
+		ability		= string
+		module		= string
+		scheduleID	= int64
+	@example:
+		ability		= READ
+		module		= assignments
+		scheduleID	= 12
+	@return
+		true
+*/
+func Can(u *auth.User, ability, module string, scheduleID int64) bool {
+	if u == nil {
+		return false
+	}
+
+	xAbility, ok := xAbilities[ability]
+	if !ok {
+		return u.IsHasRoles(module, ability)
+	}
+
+	if u.IsHasRoles(module, xAbility) {
+		return true
+	}
+
+	if !u.IsHasRoles(module, ability) {
+		return false
+	}
+
+	if scheduleID == 0 {
+		return true
+	}
+
+	return isOwner(u.ID, scheduleID)
+}
@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/util/auth"
+)
+
+func TestCan(t *testing.T) {
+	// user 1 assists or created schedule 10 only
+	isOwner = func(userID, scheduleID int64) bool {
+		return userID == 1 && scheduleID == 10
+	}
+
+	abilities := []string{rg.RoleCreate, rg.RoleRead, rg.RoleUpdate, rg.RoleDelete}
+	roles := []struct {
+		name   string
+		grant  func(ability string) []string
+		own    bool // allowed on the own schedule
+		other  bool // allowed on the other schedule
+		module bool // allowed on the module itself
+	}{
+		{
+			name:  "none",
+			grant: func(ability string) []string { return nil },
+		},
+		{
+			name:   "plain",
+			grant:  func(ability string) []string { return []string{ability} },
+			own:    true,
+			module: true,
+		},
+		{
+			name:   "x",
+			grant:  func(ability string) []string { return []string{xAbilities[ability]} },
+			own:    true,
+			other:  true,
+			module: true,
+		},
+		{
+			name:   "both",
+			grant:  func(ability string) []string { return []string{ability, xAbilities[ability]} },
+			own:    true,
+			other:  true,
+			module: true,
+		},
+	}
+
+	for _, module := range rg.GetModuleList() {
+		for _, ability := range abilities {
+			for _, role := range roles {
+				u := &auth.User{
+					ID:    1,
+					Roles: map[string][]string{module: role.grant(ability)},
+				}
+				cases := []struct {
+					scheduleID int64
+					want       bool
+				}{
+					{0, role.module},
+					{10, role.own},
+					{20, role.other},
+				}
+				for _, c := range cases {
+					name := fmt.Sprintf("%s/%s/%s/%d", module, ability, role.name, c.scheduleID)
+					if got := Can(u, ability, module, c.scheduleID); got != c.want {
+						t.Errorf("Can() %s = %v, want %v", name, got, c.want)
+					}
+				}
+
+				// the ability on the other modules is never allowed
+				other := rg.ModuleUser
+				if module == rg.ModuleUser {
+					other = rg.ModuleCourse
+				}
+				if Can(u, ability, other, 0) {
+					t.Errorf("Can() %s/%s/%s on %s = true, want false", module, ability, role.name, other)
+				}
+			}
+		}
+	}
+}
+
+func TestCanXAbility(t *testing.T) {
+	isOwner = func(userID, scheduleID int64) bool { return true }
+
+	tests := []struct {
+		name  string
+		roles []string
+		want  bool
+	}{
+		{
+			name:  "Test Case 1",
+			roles: []string{rg.RoleRead},
+			want:  false,
+		},
+		{
+			name:  "Test Case 2",
+			roles: []string{rg.RoleXRead},
+			want:  true,
+		},
+		{
+			name:  "Test Case 3",
+			roles: []string{rg.RoleXUpdate},
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &auth.User{ID: 1, Roles: map[string][]string{rg.ModuleAudit: tt.roles}}
+			if got := Can(u, rg.RoleXRead, rg.ModuleAudit, 10); got != tt.want {
+				t.Errorf("Can() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanNilUser(t *testing.T) {
+	if Can(nil, rg.RoleRead, rg.ModuleCourse, 0) {
+		t.Errorf("Can() nil user = true, want false")
+	}
+}
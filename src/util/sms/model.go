@@ -0,0 +1,38 @@
+package sms
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Config is the gateway used to send the SMS, an empty Driver disables it.
+// The http driver posts the message to URL, the file driver appends it to File or prints it when File is empty
+type Config struct {
+	Driver  string `json:"driver"`
+	URL     string `json:"url"`
+	Token   string `json:"token"`
+	From    string `json:"from"`
+	File    string `json:"file"`
+	Timeout int64  `json:"timeout"`
+}
+
+// Sender sends a text message to a phone number
+type Sender interface {
+	Send(to, message string) error
+}
+
+// HTTPSender posts the message as a form to the gateway, the token is sent as the bearer token
+type HTTPSender struct {
+	URL    string
+	Token  string
+	From   string
+	Client *http.Client
+}
+
+// WriterSender writes a line of the phone number and the message for every SMS, it is used on
+// development and tests so the code can be read without a gateway
+type WriterSender struct {
+	mu sync.Mutex
+	W  io.Writer
+}
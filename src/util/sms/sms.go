@@ -0,0 +1,127 @@
+package sms
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DriverHTTP sends the SMS through the HTTP gateway
+	DriverHTTP = "http"
+	// DriverFile writes the SMS to a file or the standard output
+	DriverFile = "file"
+
+	defaultTimeout = 10
+)
+
+// ErrDisabled is returned when the SMS is sent but no gateway is configured
+var ErrDisabled = fmt.Errorf("SMS gateway is not configured")
+
+var sender Sender
+
+// Init sets the sender of the configured driver, an unknown driver disables the SMS
+func Init(cfg Config) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	switch cfg.Driver {
+	case DriverHTTP:
+		sender = &HTTPSender{
+			URL:    cfg.URL,
+			Token:  cfg.Token,
+			From:   cfg.From,
+			Client: &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+		}
+	case DriverFile:
+		if len(cfg.File) < 1 {
+			sender = &WriterSender{W: os.Stdout}
+			return
+		}
+		f, err := os.OpenFile(cfg.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Printf("Failed to open the SMS file %s: %s", cfg.File, err.Error())
+			sender = nil
+			return
+		}
+		sender = &WriterSender{W: f}
+	default:
+		sender = nil
+	}
+}
+
+// SetSender replaces the sender, it is used by tests
+func SetSender(s Sender) {
+	sender = s
+}
+
+// IsEnabled reports whether the SMS can be sent
+func IsEnabled() bool {
+	return sender != nil
+}
+
+// Send sends the message to the phone number using the configured sender
+/*
+	@params:
+		to		= string
+		message	= string
+	@example:
+		to		= 085860141146
+		message	= Your Meiko verification code is 1408
+	@return
+*/
+func Send(to, message string) error {
+	if sender == nil {
+		return ErrDisabled
+	}
+	return sender.Send(to, message)
+}
+
+// Send posts the message to the gateway, a non 2xx status is an error
+func (s *HTTPSender) Send(to, message string) error {
+	form := url.Values{}
+	form.Set("to", to)
+	form.Set("message", message)
+	if len(s.From) > 0 {
+		form.Set("from", s.From)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if len(s.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("SMS gateway failed: %d %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// Send writes the phone number and the message as a line
+func (s *WriterSender) Send(to, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := fmt.Fprintf(s.W, "[sms] %s %s\t%s\n", time.Now().Format(time.RFC3339), to, strings.Replace(message, "\n", " ", -1))
+	return err
+}
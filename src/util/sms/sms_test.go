@@ -0,0 +1,59 @@
+package sms
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPSender(t *testing.T) {
+	var got http.Request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		got = *r
+		if r.PostForm.Get("to") == "000" {
+			http.Error(w, "invalid number", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	s := &HTTPSender{URL: ts.URL, Token: "secret", From: "Meiko"}
+	if err := s.Send("085860141146", "Your code is 1408"); err != nil {
+		t.Fatalf("HTTPSender.Send() error = %v", err)
+	}
+	if got.Header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("HTTPSender.Send() authorization = %v", got.Header.Get("Authorization"))
+	}
+	if got.PostForm.Get("to") != "085860141146" || got.PostForm.Get("message") != "Your code is 1408" || got.PostForm.Get("from") != "Meiko" {
+		t.Errorf("HTTPSender.Send() form = %v", got.PostForm)
+	}
+
+	if err := s.Send("000", "Your code is 1408"); err == nil {
+		t.Errorf("HTTPSender.Send() should fail on a non 2xx status")
+	}
+}
+
+func TestSend(t *testing.T) {
+	SetSender(nil)
+	if IsEnabled() {
+		t.Errorf("IsEnabled() = true, want false")
+	}
+	if err := Send("085860141146", "Your code is 1408"); err != ErrDisabled {
+		t.Errorf("Send() error = %v, want %v", err, ErrDisabled)
+	}
+
+	var buf bytes.Buffer
+	SetSender(&WriterSender{W: &buf})
+	defer SetSender(nil)
+
+	if err := Send("085860141146", "Your code\nis 1408"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "085860141146\tYour code is 1408\n") {
+		t.Errorf("Send() wrote %q", buf.String())
+	}
+}
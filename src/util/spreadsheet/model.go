@@ -0,0 +1,49 @@
+package spreadsheet
+
+const (
+	// maxXMLSize protects the server from the compressed file which expands too much
+	maxXMLSize = 32 << 20
+	// maxXLSXRow and maxXLSXColumn are the size of the sheet, the references beyond them are invalid
+	maxXLSXRow    = 1048576
+	maxXLSXColumn = 16384
+)
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Name  string `xml:"name,attr"`
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxSST struct {
+	Items []xlsxString `xml:"si"`
+}
+
+// xlsxString is either a plain text or a rich text which is made of runs
+type xlsxString struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Num   int        `xml:"r,attr"`
+		Cells []xlsxCell `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type xlsxCell struct {
+	Ref    string     `xml:"r,attr"`
+	Type   string     `xml:"t,attr"`
+	Value  string     `xml:"v"`
+	Inline xlsxString `xml:"is"`
+}
@@ -0,0 +1,256 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ExtensionCSV is the comma separated values file
+	ExtensionCSV = "csv"
+	// ExtensionXLSX is the Office Open XML workbook, only the first sheet is read
+	ExtensionXLSX = "xlsx"
+)
+
+// Read returns the rows of the CSV or the XLSX file, the trailing empty rows are removed. The reading stops
+// after rowMax+1 rows, so the caller still sees that the file has too many rows without reading all of them
+/*
+	@params:
+		data	= []byte
+		ext		= string
+		rowMax	= int
+	@example:
+		data	= id,name,email\n140810140016,Risal Falah,risal@live.com
+		ext		= csv
+		rowMax	= 501
+	@return
+		rows	= [[id name email] [140810140016 Risal Falah risal@live.com]]
+*/
+func Read(data []byte, ext string, rowMax int) ([][]string, error) {
+	var rows [][]string
+	var err error
+	switch strings.ToLower(ext) {
+	case ExtensionCSV:
+		rows, err = readCSV(data, rowMax)
+	case ExtensionXLSX:
+		rows, err = readXLSX(data, rowMax)
+	default:
+		return nil, fmt.Errorf("File should be csv or xlsx")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for len(rows) > 0 && isEmptyRow(rows[len(rows)-1]) {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+func isEmptyRow(row []string) bool {
+	for _, v := range row {
+		if len(strings.TrimSpace(v)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func readCSV(data []byte, rowMax int) ([][]string, error) {
+	// the byte order mark is written by the spreadsheet applications
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows := [][]string{}
+	for len(rows) <= rowMax {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid csv file")
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readXLSX(data []byte, rowMax int) ([][]string, error) {
+	z, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid xlsx file")
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+
+	sheet, err := firstSheet(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst xlsxSST
+		err = decodeXML(f, &sst)
+		if err != nil {
+			return nil, err
+		}
+		for _, si := range sst.Items {
+			shared = append(shared, si.text())
+		}
+	}
+
+	var ws xlsxWorksheet
+	err = decodeXML(sheet, &ws)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	for i, row := range ws.Rows {
+		// the empty rows are omitted by the file, keep the row number of the sheet
+		num := row.Num
+		if num < 1 {
+			num = i + 1
+		}
+		if num > maxXLSXRow {
+			return nil, fmt.Errorf("Invalid xlsx file")
+		}
+		for len(rows) < num-1 && len(rows) <= rowMax {
+			rows = append(rows, []string{})
+		}
+		if len(rows) > rowMax {
+			break
+		}
+
+		values := []string{}
+		for _, cell := range row.Cells {
+			col := len(values)
+			if len(cell.Ref) > 0 {
+				col = column(cell.Ref)
+			}
+			if col >= maxXLSXColumn {
+				return nil, fmt.Errorf("Invalid xlsx file")
+			}
+			for len(values) < col {
+				values = append(values, "")
+			}
+			values = append(values, cell.value(shared))
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+// firstSheet returns the worksheet of the first sheet of the workbook
+func firstSheet(files map[string]*zip.File) (*zip.File, error) {
+	var wb xlsxWorkbook
+	var rels xlsxRelationships
+	wf, ok := files["xl/workbook.xml"]
+	rf, ok2 := files["xl/_rels/workbook.xml.rels"]
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("Invalid xlsx file")
+	}
+	if err := decodeXML(wf, &wb); err != nil {
+		return nil, err
+	}
+	if err := decodeXML(rf, &rels); err != nil {
+		return nil, err
+	}
+	if len(wb.Sheets) < 1 {
+		return nil, fmt.Errorf("xlsx file doesn't have a sheet")
+	}
+
+	for _, rel := range rels.Items {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		f, ok := files[target]
+		if !ok {
+			break
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("Invalid xlsx file")
+}
+
+func decodeXML(f *zip.File, v interface{}) error {
+	r, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("Invalid xlsx file")
+	}
+	defer r.Close()
+
+	err = xml.NewDecoder(io.LimitReader(r, maxXMLSize)).Decode(v)
+	if err != nil {
+		return fmt.Errorf("Invalid xlsx file")
+	}
+	return nil
+}
+
+// column returns the zero based column of the cell reference, e.g. 2 for C7. The column beyond
+// the sheet is returned as maxXLSXColumn, so the long reference can't overflow
+func column(ref string) int {
+	var col int
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+		col = col*26 + int(c-'A'+1)
+		if col > maxXLSXColumn {
+			return maxXLSXColumn
+		}
+	}
+	return col - 1
+}
+
+func (c xlsxCell) value(shared []string) string {
+	switch c.Type {
+	case "s":
+		i, err := strconv.Atoi(c.Value)
+		if err != nil || i < 0 || i >= len(shared) {
+			return ""
+		}
+		return shared[i]
+	case "inlineStr":
+		return c.Inline.text()
+	case "", "n":
+		// the long numbers such as identity code can be stored in exponent form
+		if strings.ContainsAny(c.Value, ".eE") {
+			f, err := strconv.ParseFloat(c.Value, 64)
+			if err == nil {
+				return strconv.FormatFloat(f, 'f', -1, 64)
+			}
+		}
+	}
+	return c.Value
+}
+
+func (s xlsxString) text() string {
+	if len(s.Runs) < 1 {
+		return s.Text
+	}
+	var b strings.Builder
+	for _, r := range s.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
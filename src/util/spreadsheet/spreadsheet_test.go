@@ -0,0 +1,163 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func newXLSX(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	z.Close()
+	return buf.Bytes()
+}
+
+func TestRead(t *testing.T) {
+	workbook := `<?xml version="1.0" encoding="UTF-8"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Roster" sheetId="1" r:id="rId2"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets>
+</workbook>`
+	rels := `<?xml version="1.0" encoding="UTF-8"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>
+</Relationships>`
+	shared := `<?xml version="1.0" encoding="UTF-8"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<si><t>id</t></si><si><t>name</t></si><si><t>email</t></si><si><r><t>Risal </t></r><r><t>Falah</t></r></si>
+</sst>`
+	sheet := `<?xml version="1.0" encoding="UTF-8"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c></row>
+<row r="2"><c r="A2"><v>1.40810140016E11</v></c><c r="B2" t="s"><v>3</v></c><c r="C2" t="inlineStr"><is><t>risal@live.com</t></is></c></row>
+<row r="4"><c r="A4"><v>140810140060</v></c><c r="C4" t="str"><v>khairil@live.com</v></c></row>
+<row r="5"><c r="A5" t="s"><v>9</v></c></row>
+</sheetData></worksheet>`
+
+	sheetOf := func(content string) []byte {
+		return newXLSX(t, map[string]string{
+			"xl/workbook.xml":            workbook,
+			"xl/_rels/workbook.xml.rels": rels,
+			"xl/worksheets/sheet2.xml":   `<worksheet><sheetData>` + content + `</sheetData></worksheet>`,
+		})
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		ext     string
+		rowMax  int
+		want    [][]string
+		wantErr bool
+	}{
+		{
+			name: "Test Case 1",
+			data: []byte("\xef\xbb\xbfid,name,email\n140810140016, Risal Falah,risal@live.com\n140810140060,Khairil\n,,\n"),
+			ext:  "CSV",
+			want: [][]string{
+				{"id", "name", "email"},
+				{"140810140016", "Risal Falah", "risal@live.com"},
+				{"140810140060", "Khairil"},
+			},
+		},
+		{
+			name:    "Test Case 2",
+			data:    []byte("id,name\n\"140810140016,Risal"),
+			ext:     "csv",
+			wantErr: true,
+		},
+		{
+			name: "Test Case 3",
+			data: newXLSX(t, map[string]string{
+				"xl/workbook.xml":            workbook,
+				"xl/_rels/workbook.xml.rels": rels,
+				"xl/sharedStrings.xml":       shared,
+				"xl/worksheets/sheet1.xml":   `<worksheet><sheetData/></worksheet>`,
+				"xl/worksheets/sheet2.xml":   sheet,
+			}),
+			ext: "xlsx",
+			want: [][]string{
+				{"id", "name", "email"},
+				{"140810140016", "Risal Falah", "risal@live.com"},
+				{},
+				{"140810140060", "", "khairil@live.com"},
+			},
+		},
+		{
+			name:    "Test Case 4",
+			data:    []byte("id,name,email"),
+			ext:     "xlsx",
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 5",
+			data:    []byte("id,name,email"),
+			ext:     "xls",
+			wantErr: true,
+		},
+		{
+			name:    "Row beyond the sheet",
+			data:    sheetOf(`<row r="20000000"><c r="A20000000" t="inlineStr"><is><t>1</t></is></c></row>`),
+			ext:     "xlsx",
+			wantErr: true,
+		},
+		{
+			name:    "Column beyond the sheet",
+			data:    sheetOf(`<row r="1"><c r="AAAAA1" t="inlineStr"><is><t>1</t></is></c></row>`),
+			ext:     "xlsx",
+			wantErr: true,
+		},
+		{
+			name:    "Overflowing column",
+			data:    sheetOf(`<row r="1"><c r="ZZZZZZZZZZZZZZZZZZZZZZZZZZZZZZ1" t="inlineStr"><is><t>1</t></is></c></row>`),
+			ext:     "xlsx",
+			wantErr: true,
+		},
+		{
+			name:   "Distant row stops the reading",
+			data:   sheetOf(`<row r="1"><c r="A1"><v>1</v></c></row><row r="1000000"><c r="A1000000"><v>2</v></c></row>`),
+			ext:    "xlsx",
+			rowMax: 2,
+			want:   [][]string{{"1"}},
+		},
+		{
+			name:   "Too many xlsx rows",
+			data:   sheetOf(`<row r="1"><c r="A1"><v>1</v></c></row><row r="2"><c r="A2"><v>2</v></c></row><row r="3"><c r="A3"><v>3</v></c></row><row r="4"><c r="A4"><v>4</v></c></row>`),
+			ext:    "xlsx",
+			rowMax: 2,
+			want:   [][]string{{"1"}, {"2"}, {"3"}},
+		},
+		{
+			name:   "Too many csv rows",
+			data:   []byte("1\n2\n3\n4\n"),
+			ext:    "csv",
+			rowMax: 2,
+			want:   [][]string{{"1"}, {"2"}, {"3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rowMax := tt.rowMax
+			if rowMax == 0 {
+				rowMax = 10
+			}
+			got, err := Read(tt.data, tt.ext, rowMax)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/apikey"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// ReadHandler handles the http request for listing API keys of the user.
+// Admin lists API keys of another user through /api/admin/v1/user/:id/apikeys
+/*
+	@params:
+		id	= optional, identity code of the owner, admin only
+	@example:
+		id	= 140810140016
+	@return
+		id				= 1
+		name			= grade sync script
+		prefix			= mk_X3k9aQ2b
+		scopes			= {"courses":["READ"]}
+		last_used_at	= 1508317200
+		created_at		= 1508313600
+*/
+func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	params := readParams{
+		identityCode: ps.ByName("id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	userID, code := getOwnerID(sess, args.identityCode, rg.RoleXRead)
+	if code != http.StatusOK {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(code))
+		return
+	}
+
+	keys, err := apikey.SelectByUserID(userID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := []readResponse{}
+	for _, val := range keys {
+		scopes := map[string][]string{}
+		json.Unmarshal([]byte(val.Scopes), &scopes)
+
+		var lastUsedAt int64
+		if val.LastUsedAt.Valid {
+			lastUsedAt = val.LastUsedAt.Time.Unix()
+		}
+
+		res = append(res, readResponse{
+			ID:         val.ID,
+			Name:       val.Name,
+			Prefix:     val.Prefix,
+			Scopes:     scopes,
+			LastUsedAt: lastUsedAt,
+			CreatedAt:  val.CreatedAt.Unix(),
+		})
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// CreateHandler handles the http request for creating a new API key.
+// The key is only shown on this response
+/*
+	@params:
+		id		= optional, identity code of the owner, admin only
+		name	= required, alphanumericspace, 0<characters<=50
+		scopes	= optional, json of module and abilities
+	@example:
+		name	= grade sync script
+		scopes	= {"courses":["READ"]}
+	@return
+		id		= 1
+		name	= grade sync script
+		key		= mk_X3k9aQ2b...
+		scopes	= {"courses":["READ"]}
+*/
+func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	params := createParams{
+		identityCode: ps.ByName("id"),
+		name:         r.FormValue("name"),
+		scopes:       r.FormValue("scopes"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	userID, code := getOwnerID(sess, args.identityCode, rg.RoleXCreate)
+	if code != http.StatusOK {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(code))
+		return
+	}
+
+	scopes, err := json.Marshal(args.scopes)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	id, err := apikey.Insert(userID, args.name, prefix, hash, string(scopes))
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("API key has been created, it will not be shown again").
+		SetData(createResponse{
+			ID:     id,
+			Name:   args.name,
+			Key:    key,
+			Scopes: args.scopes,
+		}))
+	return
+}
+
+// DeleteHandler handles the http request for revoking an API key
+/*
+	@params:
+		id			= optional, identity code of the owner, admin only
+		apikey_id	= required, numeric
+	@example:
+		apikey_id	= 1
+	@return
+*/
+func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	params := deleteParams{
+		identityCode: ps.ByName("id"),
+		id:           ps.ByName("apikey_id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	userID, code := getOwnerID(sess, args.identityCode, rg.RoleXDelete)
+	if code != http.StatusOK {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(code))
+		return
+	}
+
+	err = apikey.Delete(args.id, userID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("API key not found"))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("API key has been revoked"))
+	return
+}
+
+// getOwnerID returns the user id of the API keys owner. Zero identity code means
+// the current user, otherwise the current user should have the ability on users module
+func getOwnerID(sess *auth.User, identityCode int64, ability string) (int64, int) {
+	if identityCode == 0 || identityCode == sess.IdentityCode {
+		return sess.ID, http.StatusOK
+	}
+
+	if !policy.Can(sess, ability, rg.ModuleUser, 0) {
+		return 0, http.StatusForbidden
+	}
+
+	u, err := user.GetByIdentityCode(identityCode, user.ColID)
+	if err != nil {
+		return 0, http.StatusNotFound
+	}
+	return u.ID, http.StatusOK
+}
@@ -0,0 +1,47 @@
+package apikey
+
+type readParams struct {
+	identityCode string
+}
+
+type readArgs struct {
+	identityCode int64
+}
+
+type createParams struct {
+	identityCode string
+	name         string
+	scopes       string
+}
+
+type createArgs struct {
+	identityCode int64
+	name         string
+	scopes       map[string][]string
+}
+
+type deleteParams struct {
+	identityCode string
+	id           string
+}
+
+type deleteArgs struct {
+	identityCode int64
+	id           int64
+}
+
+type readResponse struct {
+	ID         int64               `json:"id"`
+	Name       string              `json:"name"`
+	Prefix     string              `json:"prefix"`
+	Scopes     map[string][]string `json:"scopes"`
+	LastUsedAt int64               `json:"last_used_at"`
+	CreatedAt  int64               `json:"created_at"`
+}
+
+type createResponse struct {
+	ID     int64               `json:"id"`
+	Name   string              `json:"name"`
+	Key    string              `json:"key"`
+	Scopes map[string][]string `json:"scopes"`
+}
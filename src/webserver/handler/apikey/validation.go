@@ -0,0 +1,103 @@
+package apikey
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+func (params readParams) validate() (readArgs, error) {
+	var args readArgs
+	if helper.IsEmpty(params.identityCode) {
+		return args, nil
+	}
+
+	identityCode, err := helper.NormalizeIdentity(params.identityCode)
+	if err != nil {
+		return args, fmt.Errorf("Error validation: ID should be numeric")
+	}
+
+	return readArgs{identityCode: identityCode}, nil
+}
+
+func (params createParams) validate() (createArgs, error) {
+	var args createArgs
+	params = createParams{
+		identityCode: params.identityCode,
+		name:         html.EscapeString(helper.Trim(params.name)),
+		scopes:       params.scopes,
+	}
+
+	var identityCode int64
+	if !helper.IsEmpty(params.identityCode) {
+		id, err := helper.NormalizeIdentity(params.identityCode)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: ID should be numeric")
+		}
+		identityCode = id
+	}
+
+	if helper.IsEmpty(params.name) {
+		return args, fmt.Errorf("Name cannot be empty")
+	}
+	if len(params.name) > 50 {
+		return args, fmt.Errorf("Name maximum consist of 50 characters")
+	}
+	if !helper.IsAlphaNumericSpace(params.name) {
+		return args, fmt.Errorf("Name should only contain an alphabet, numeric and space")
+	}
+
+	scopes := map[string][]string{}
+	if !helper.IsEmpty(params.scopes) {
+		err := json.Unmarshal([]byte(params.scopes), &scopes)
+		if err != nil {
+			return args, fmt.Errorf("Invalid scopes")
+		}
+	}
+
+	listModule := rg.GetModuleList()
+	listAbility := rg.GetAbilityList()
+	for mdl, abilities := range scopes {
+		if !helper.IsStringInSlice(mdl, listModule) {
+			return args, fmt.Errorf("Invalid scopes")
+		}
+		for _, val := range abilities {
+			if !helper.IsStringInSlice(val, listAbility) {
+				return args, fmt.Errorf("Invalid scopes")
+			}
+		}
+	}
+
+	return createArgs{
+		identityCode: identityCode,
+		name:         params.name,
+		scopes:       scopes,
+	}, nil
+}
+
+func (params deleteParams) validate() (deleteArgs, error) {
+	var args deleteArgs
+
+	var identityCode int64
+	if !helper.IsEmpty(params.identityCode) {
+		id, err := helper.NormalizeIdentity(params.identityCode)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: ID should be numeric")
+		}
+		identityCode = id
+	}
+
+	id, err := strconv.ParseInt(params.id, 10, 64)
+	if err != nil || id < 1 {
+		return args, fmt.Errorf("Invalid request")
+	}
+
+	return deleteArgs{
+		identityCode: identityCode,
+		id:           id,
+	}, nil
+}
@@ -10,13 +10,14 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	asg "github.com/melodiez14/meiko/src/module/assignment"
-	att "github.com/melodiez14/meiko/src/module/attendance"
+	"github.com/melodiez14/meiko/src/module/audit"
 	cs "github.com/melodiez14/meiko/src/module/course"
 	fl "github.com/melodiez14/meiko/src/module/file"
 	rg "github.com/melodiez14/meiko/src/module/rolegroup"
 	usr "github.com/melodiez14/meiko/src/module/user"
 	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -385,7 +386,7 @@ func SubmitHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // GetAvailableGP ..
 func GetAvailableGP(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -403,7 +404,7 @@ func GetAvailableGP(w http.ResponseWriter, r *http.Request, ps httprouter.Params
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, args.id) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, args.id) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -433,7 +434,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	resp := readResponse{Assignments: []read{}}
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -454,7 +455,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, args.scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -519,7 +520,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 // CreateHandler ..
 func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -552,7 +553,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, scheduleID) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -615,7 +616,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // UpdateHandler ..
 func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleUpdate, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -649,7 +650,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, scheduleID) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleAssignment, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -826,7 +827,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -867,7 +868,7 @@ func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, scheduleID) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAssignment, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1060,7 +1061,7 @@ func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXDelete, rg.RoleDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1114,7 +1115,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, scheduleID) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleAssignment, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1153,6 +1154,15 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 
 	err = asg.DeleteAssignment(args.id, tx)
 	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionDelete, auditTable, args.id, auditValue(assignment), nil, tx)
+	if err != nil {
+		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
 		return
@@ -1174,7 +1184,6 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // not finished yet
 func GetReportHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
-	resp := []getReportResponse{}
 	sess := r.Context().Value("User").(*auth.User)
 
 	payload := r.FormValue("schedule_id")
@@ -1199,163 +1208,66 @@ func GetReportHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Para
 		return
 	}
 
-	schedulesID, err := cs.SelectScheduleIDByUserID(sess.ID, cs.PStatusStudent)
-	if err != nil {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusInternalServerError))
-		return
-	}
-
-	if len(schedulesID) < 1 {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusOK).
-			SetData(resp))
-		return
-	}
-
-	courses, err := cs.SelectByScheduleID(schedulesID, cs.StatusScheduleActive)
+	resp, err := handleReport(sess.ID)
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
 		return
 	}
 
-	if len(courses) < 1 {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusOK).
-			SetData(resp))
-		return
-	}
-
-	schedulesID = []int64{}
-	for _, val := range courses {
-		schedulesID = append(schedulesID, val.Schedule.ID)
-	}
-
-	gps, err := cs.SelectGPBySchedule(schedulesID)
-	if err != nil {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusInternalServerError))
-		return
-	}
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(resp))
+	return
+}
 
-	if len(gps) < 1 {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusOK).
-			SetData(resp))
-		return
-	}
+// GetAdviseeReportHandler shows the course and grade summary of a student to the academic advisor of the student
+func GetAdviseeReportHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
-	var gpsID []int64
-	scheduleGP := map[int64][]cs.GradeParameter{}
-	for _, gp := range gps {
-		gpsID = append(gpsID, gp.ID)
-		scheduleGP[gp.ScheduleID] = append(scheduleGP[gp.ScheduleID], gp)
+	sess := r.Context().Value("User").(*auth.User)
+	params := adviseeReportParams{
+		identityCode: ps.ByName("id"),
 	}
 
-	assignments, err := asg.SelectByGP(gpsID, false)
+	args, err := params.validate()
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusInternalServerError))
-		return
-	}
-
-	var asgID []int64
-	gpAsg := map[int64][]asg.Assignment{}
-	for _, val := range assignments {
-		asgID = append(asgID, val.ID)
-		gpAsg[val.GradeParameterID] = append(gpAsg[val.GradeParameterID], val)
-	}
-
-	if len(asgID) < 1 {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusOK).
-			SetData(resp))
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
 		return
 	}
 
-	submitted, err := asg.SelectSubmittedByUser(asgID, sess.ID)
-	if err != nil {
+	u, err := usr.GetByIdentityCode(args.identityCode)
+	if err != nil || !u.AdvisorID.Valid || u.AdvisorID.Int64 != sess.ID {
 		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusInternalServerError))
+			SetCode(http.StatusNotFound).
+			AddError("Advisee not found"))
 		return
 	}
 
-	asgSubmit := map[int64]asg.UserAssignment{}
-	for _, val := range submitted {
-		asgSubmit[val.AssignmentID] = val
-	}
-
-	attReport, err := att.CountByUserSchedule(sess.ID, schedulesID)
+	courses, err := handleReport(u.ID)
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
 		return
 	}
 
-	for _, c := range courses {
-		rep := getReportResponse{
-			CourseName: c.Course.Name,
-			ScheduleID: c.Schedule.ID,
-			Assignment: "-",
-			Attendance: "-",
-			Quiz:       "-",
-			Mid:        "-",
-			Final:      "-",
-			Total:      "-",
-		}
-		total := float64(0)
-		for _, gp := range scheduleGP[c.Schedule.ID] {
-			scoreFloat64 := float64(0)
-			if gp.Type == "ATTENDANCE" {
-				attendance := attReport[gp.ScheduleID]
-				if attendance.MeetingTotal > 0 {
-					scoreFloat64 = (float64(attendance.AttendanceTotal) / float64(attendance.MeetingTotal)) * float64(gp.Percentage) / 100
-				}
-				rep.Attendance = fmt.Sprintf("%.3g", scoreFloat64)
-			} else {
-				count := len(gpAsg[gp.ID])
-				for _, assignment := range gpAsg[gp.ID] {
-					submit, exist := asgSubmit[assignment.ID]
-					if !exist {
-						continue
-					}
-					if submit.Score.Valid {
-						scoreFloat64 += submit.Score.Float64
-					} else {
-						count--
-					}
-				}
-				if count > 0 {
-					scoreFloat64 = scoreFloat64 / float64(count)
-				}
-				total += (scoreFloat64 * float64(gp.Percentage) / 100)
-				switch gp.Type {
-				case "ASSIGNMENT":
-					rep.Assignment = fmt.Sprintf("%.3g", scoreFloat64)
-				case "QUIZ":
-					rep.Quiz = fmt.Sprintf("%.3g", scoreFloat64)
-				case "MID":
-					rep.Mid = fmt.Sprintf("%.3g", scoreFloat64)
-				case "FINAL":
-					rep.Final = fmt.Sprintf("%.3g", scoreFloat64)
-				}
-			}
-		}
-		rep.Total = fmt.Sprintf("%.3g", total)
-		resp = append(resp, rep)
-	}
-
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
-		SetData(resp))
+		SetData(adviseeReportResponse{
+			IdentityCode: u.IdentityCode,
+			Name:         u.Name,
+			Program:      u.Program.String,
+			Cohort:       uint16(u.Cohort.Int64),
+			Courses:      courses,
+		}))
 	return
 }
 
 // GetGradeByAdmin ..
 func GetGradeByAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1384,7 +1296,7 @@ func GetGradeByAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 			SetCode(http.StatusNotFound))
 		return
 	}
-	if !cs.IsAssistant(sess.ID, scheduleID) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAssignment, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusBadRequest).
 			AddError("You dont have privillage"))
@@ -1468,7 +1380,7 @@ func GetGradeByAdmin(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 // UpdateScoreHandler ...
 func UpdateScoreHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXUpdate, rg.RoleUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1499,7 +1411,7 @@ func UpdateScoreHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 			SetCode(http.StatusNotFound))
 		return
 	}
-	if !cs.IsAssistant(sess.ID, scheduleID) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleAssignment, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusBadRequest).
 			AddError("You dont have privillage"))
@@ -1511,6 +1423,25 @@ func UpdateScoreHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 			AddError("Wrong user list"))
 		return
 	}
+	// the schedule of a term can only be scored in its grading window
+	isOpen, err := isGradingOpen(scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	if !isOpen {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("The grading of the term is closed"))
+		return
+	}
+	scores, err := asg.SelectUserScoreByID(args.AssignmentID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
 	tx, err := conn.DB.Beginx()
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -1519,6 +1450,15 @@ func UpdateScoreHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 	}
 	err = asg.CreateScore(args.AssignmentID, args.UserID, args.Score, tx)
 	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditScoreTable, args.AssignmentID,
+		auditScoreBefore(args.UserID, scores), auditScoreAfter(args.UserID, args.Score), tx)
+	if err != nil {
+		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
 		return
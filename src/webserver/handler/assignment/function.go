@@ -6,12 +6,15 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/melodiez14/meiko/src/util/conn"
 
 	asg "github.com/melodiez14/meiko/src/module/assignment"
+	att "github.com/melodiez14/meiko/src/module/attendance"
 	cs "github.com/melodiez14/meiko/src/module/course"
 	fl "github.com/melodiez14/meiko/src/module/file"
+	tm "github.com/melodiez14/meiko/src/module/term"
 	"github.com/melodiez14/meiko/src/util/helper"
 )
 
@@ -150,3 +153,176 @@ func handleGradeBySchedule(scheduleID, userID int64) ([]getGradeResponse, int, e
 
 	return resp, http.StatusOK, nil
 }
+
+const (
+	auditTable      = "assignments"
+	auditScoreTable = "p_users_assignments"
+)
+
+// handleReport summarizes the grade of every active course taken by the user
+func handleReport(userID int64) ([]getReportResponse, error) {
+
+	resp := []getReportResponse{}
+	schedulesID, err := cs.SelectScheduleIDByUserID(userID, cs.PStatusStudent)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(schedulesID) < 1 {
+		return resp, nil
+	}
+
+	courses, err := cs.SelectByScheduleID(schedulesID, cs.StatusScheduleActive)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(courses) < 1 {
+		return resp, nil
+	}
+
+	schedulesID = []int64{}
+	for _, val := range courses {
+		schedulesID = append(schedulesID, val.Schedule.ID)
+	}
+
+	gps, err := cs.SelectGPBySchedule(schedulesID)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(gps) < 1 {
+		return resp, nil
+	}
+
+	var gpsID []int64
+	scheduleGP := map[int64][]cs.GradeParameter{}
+	for _, gp := range gps {
+		gpsID = append(gpsID, gp.ID)
+		scheduleGP[gp.ScheduleID] = append(scheduleGP[gp.ScheduleID], gp)
+	}
+
+	assignments, err := asg.SelectByGP(gpsID, false)
+	if err != nil {
+		return resp, err
+	}
+
+	var asgID []int64
+	gpAsg := map[int64][]asg.Assignment{}
+	for _, val := range assignments {
+		asgID = append(asgID, val.ID)
+		gpAsg[val.GradeParameterID] = append(gpAsg[val.GradeParameterID], val)
+	}
+
+	if len(asgID) < 1 {
+		return resp, nil
+	}
+
+	submitted, err := asg.SelectSubmittedByUser(asgID, userID)
+	if err != nil {
+		return resp, err
+	}
+
+	asgSubmit := map[int64]asg.UserAssignment{}
+	for _, val := range submitted {
+		asgSubmit[val.AssignmentID] = val
+	}
+
+	attReport, err := att.CountByUserSchedule(userID, schedulesID)
+	if err != nil {
+		return resp, err
+	}
+
+	for _, c := range courses {
+		rep := getReportResponse{
+			CourseName: c.Course.Name,
+			ScheduleID: c.Schedule.ID,
+			Assignment: "-",
+			Attendance: "-",
+			Quiz:       "-",
+			Mid:        "-",
+			Final:      "-",
+			Total:      "-",
+		}
+		total := float64(0)
+		for _, gp := range scheduleGP[c.Schedule.ID] {
+			scoreFloat64 := float64(0)
+			if gp.Type == "ATTENDANCE" {
+				attendance := attReport[gp.ScheduleID]
+				if attendance.MeetingTotal > 0 {
+					scoreFloat64 = (float64(attendance.AttendanceTotal) / float64(attendance.MeetingTotal)) * float64(gp.Percentage) / 100
+				}
+				rep.Attendance = fmt.Sprintf("%.3g", scoreFloat64)
+			} else {
+				scoreFloat64 = asg.Score(gpAsg[gp.ID], asgSubmit)
+				total += (scoreFloat64 * float64(gp.Percentage) / 100)
+				switch gp.Type {
+				case "ASSIGNMENT":
+					rep.Assignment = fmt.Sprintf("%.3g", scoreFloat64)
+				case "QUIZ":
+					rep.Quiz = fmt.Sprintf("%.3g", scoreFloat64)
+				case "MID":
+					rep.Mid = fmt.Sprintf("%.3g", scoreFloat64)
+				case "FINAL":
+					rep.Final = fmt.Sprintf("%.3g", scoreFloat64)
+				}
+			}
+		}
+		rep.Total = fmt.Sprintf("%.3g", total)
+		resp = append(resp, rep)
+	}
+
+	return resp, nil
+}
+
+// auditValue returns the audited values of the assignment
+func auditValue(a asg.Assignment) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                a.Name,
+		"status":              a.Status,
+		"description":         a.Description.String,
+		"grade_parameters_id": a.GradeParameterID,
+		"due_date":            a.DueDate,
+		"max_size":            a.MaxSize.Int64,
+		"max_file":            a.MaxFile.Int64,
+	}
+}
+
+// auditScoreBefore returns the current score of the users keyed by user id, null if not scored yet
+func auditScoreBefore(usersID []int64, scores []asg.UserScore) map[int64]interface{} {
+	value := map[int64]interface{}{}
+	for _, id := range usersID {
+		value[id] = nil
+	}
+	for _, val := range scores {
+		if _, ok := value[val.UserID]; ok && val.Score.Valid {
+			value[val.UserID] = val.Score.Float64
+		}
+	}
+	return value
+}
+
+// auditScoreAfter returns the new score of the users keyed by user id
+func auditScoreAfter(usersID []int64, scores []float32) map[int64]interface{} {
+	value := map[int64]interface{}{}
+	for i, id := range usersID {
+		if i < len(scores) {
+			value[id] = scores[i]
+		}
+	}
+	return value
+}
+
+// isGradingOpen reports whether the schedule can be scored now, a schedule without term is always open
+func isGradingOpen(scheduleID int64) (bool, error) {
+	termID, err := cs.GetTermID(scheduleID)
+	if err != nil || !termID.Valid {
+		return true, err
+	}
+
+	term, err := tm.Get(termID.Int64)
+	if err != nil {
+		return false, err
+	}
+	return term.IsGradingOpen(time.Now()), nil
+}
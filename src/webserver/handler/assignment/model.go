@@ -165,6 +165,22 @@ type getReportResponse struct {
 	Total      string `json:"total"`
 }
 
+type adviseeReportParams struct {
+	identityCode string
+}
+
+type adviseeReportArgs struct {
+	identityCode int64
+}
+
+type adviseeReportResponse struct {
+	IdentityCode int64               `json:"id"`
+	Name         string              `json:"name"`
+	Program      string              `json:"program"`
+	Cohort       uint16              `json:"cohort,omitempty"`
+	Courses      []getReportResponse `json:"courses"`
+}
+
 type getGradeResponse struct {
 	Name  string `json:"name"`
 	Type  string `json:"type"`
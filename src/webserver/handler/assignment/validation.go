@@ -186,7 +186,7 @@ func (params createParams) validate() (createArgs, error) {
 				}
 			}
 			if count == 0 {
-				return args, fmt.Errorf(fmt.Sprintf("%s Denied type", val))
+				return args, fmt.Errorf("%s Denied type", val)
 			}
 		}
 		if helper.IsEmpty(params.maxFile) {
@@ -313,7 +313,7 @@ func (params updateParams) validate() (updateArgs, error) {
 				}
 			}
 			if count == 0 {
-				return args, fmt.Errorf(fmt.Sprintf("%s Denied type", val))
+				return args, fmt.Errorf("%s Denied type", val)
 			}
 		}
 		if helper.IsEmpty(params.maxFile) {
@@ -333,7 +333,7 @@ func (params updateParams) validate() (updateArgs, error) {
 	layout := `2006-01-02 15:04:05`
 	dueDate, err := time.Parse(layout, params.dueDate)
 	if err != nil {
-		return args, fmt.Errorf(err.Error())
+		return args, err
 	}
 
 	return updateArgs{
@@ -737,3 +737,14 @@ func (params scoreParams) validate() (scoreArgs, error) {
 		ScheduleID: id,
 	}, nil
 }
+
+func (params adviseeReportParams) validate() (adviseeReportArgs, error) {
+	var args adviseeReportArgs
+	identityCode, err := helper.NormalizeIdentity(params.identityCode)
+	if err != nil {
+		return args, fmt.Errorf("Invalid request")
+	}
+	return adviseeReportArgs{
+		identityCode: identityCode,
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 	usr "github.com/melodiez14/meiko/src/module/user"
 	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -63,7 +64,7 @@ func GetSummaryHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 func ListStudentHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAttendance, rg.RoleXRead, rg.RoleRead) && !sess.IsHasRoles(rg.ModuleUser, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAttendance, 0) && !policy.Can(sess, rg.RoleRead, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -143,7 +144,7 @@ func ListStudentHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 // CreateMeetingHandler ...
 func CreateMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAttendance, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAttendance, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -167,7 +168,7 @@ func CreateMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAttendance, args.scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You are not authorized"))
@@ -245,7 +246,7 @@ func CreateMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 // UpdateMeetingHandler ...
 func UpdateMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAttendance, rg.RoleXUpdate, rg.RoleUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleAttendance, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -271,7 +272,7 @@ func UpdateMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleAttendance, args.scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You are not authorized"))
@@ -396,7 +397,7 @@ func UpdateMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 // DeleteMeetingHandler ...
 func DeleteMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAttendance, rg.RoleXDelete, rg.RoleDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleAttendance, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -424,7 +425,7 @@ func DeleteMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, meeting.ScheduleID) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleAttendance, meeting.ScheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You are not authorized"))
@@ -468,7 +469,7 @@ func DeleteMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 // ReadMeetingHandler ...
 func ReadMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAttendance, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAttendance, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -489,7 +490,7 @@ func ReadMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAttendance, args.scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You are not authorized"))
@@ -554,7 +555,7 @@ func ReadMeetingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 
 func ReadMeetingDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAttendance, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAttendance, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -581,7 +582,7 @@ func ReadMeetingDetailHandler(w http.ResponseWriter, r *http.Request, ps httprou
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, meeting.ScheduleID) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAttendance, meeting.ScheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You are not authorized"))
@@ -0,0 +1,131 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	usr "github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// ReadHandler returns the audit logs filtered by actor, action, target and time range
+func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXRead, rg.ModuleAudit, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := readParams{
+		page:     r.FormValue("pg"),
+		total:    r.FormValue("ttl"),
+		actor:    r.FormValue("actor"),
+		action:   r.FormValue("action"),
+		table:    r.FormValue("table"),
+		targetID: r.FormValue("target_id"),
+		since:    r.FormValue("since"),
+		until:    r.FormValue("until"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	filter := audit.Filter{
+		Action:      args.action,
+		TargetTable: args.table,
+		TargetID:    args.targetID,
+		Since:       args.since,
+		Until:       args.until,
+	}
+
+	res := readResponse{
+		Page: args.page,
+		Logs: []logItem{},
+	}
+
+	if args.actor > 0 {
+		u, err := usr.GetByIdentityCode(args.actor, usr.ColID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusOK).
+				SetData(res))
+			return
+		}
+		filter.ActorID = u.ID
+	}
+
+	offset := (args.page - 1) * args.total
+	logs, count, err := audit.SelectByPage(filter, args.total, offset, true)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	var actorsID []int64
+	for _, val := range logs {
+		actorsID = append(actorsID, val.ActorID)
+	}
+
+	actors := map[int64]actor{}
+	if len(actorsID) > 0 {
+		users, err := usr.SelectByID(actorsID, false, usr.ColID, usr.ColIdentityCode, usr.ColName)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		for _, val := range users {
+			actors[val.ID] = actor{
+				ID:           val.ID,
+				IdentityCode: val.IdentityCode,
+				Name:         val.Name,
+			}
+		}
+	}
+
+	for _, val := range logs {
+		a, ok := actors[val.ActorID]
+		if !ok {
+			a = actor{ID: val.ActorID}
+		}
+		item := logItem{
+			ID:          val.ID,
+			Actor:       a,
+			Action:      val.Action,
+			TargetTable: val.TargetTable,
+			TargetID:    val.TargetID,
+			CreatedAt:   val.CreatedAt.Unix(),
+		}
+		if val.Before.Valid {
+			item.Before = json.RawMessage(val.Before.String)
+		}
+		if val.After.Valid {
+			item.After = json.RawMessage(val.After.String)
+		}
+		res.Logs = append(res.Logs, item)
+	}
+
+	res.TotalPage = count / args.total
+	if count%args.total > 0 {
+		res.TotalPage++
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
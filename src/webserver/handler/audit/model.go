@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type readParams struct {
+	page     string
+	total    string
+	actor    string
+	action   string
+	table    string
+	targetID string
+	since    string
+	until    string
+}
+
+type readArgs struct {
+	page     int
+	total    int
+	actor    int64
+	action   string
+	table    string
+	targetID int64
+	since    time.Time
+	until    time.Time
+}
+
+type readResponse struct {
+	Page      int       `json:"page"`
+	TotalPage int       `json:"total_page"`
+	Logs      []logItem `json:"logs"`
+}
+
+type logItem struct {
+	ID          int64           `json:"id"`
+	Actor       actor           `json:"actor"`
+	Action      string          `json:"action"`
+	TargetTable string          `json:"target_table"`
+	TargetID    int64           `json:"target_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	CreatedAt   int64           `json:"created_at"`
+}
+
+type actor struct {
+	ID           int64  `json:"id"`
+	IdentityCode int64  `json:"identity_code"`
+	Name         string `json:"name"`
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/melodiez14/meiko/src/module/audit"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+func (params readParams) validate() (readArgs, error) {
+	var args readArgs
+	if helper.IsEmpty(params.page) || helper.IsEmpty(params.total) {
+		return args, fmt.Errorf("Invalid request")
+	}
+
+	page, err := strconv.ParseUint(params.page, 10, 64)
+	if err != nil || page < 1 {
+		return args, fmt.Errorf("Invalid request")
+	}
+
+	total, err := strconv.ParseUint(params.total, 10, 64)
+	if err != nil || total < 1 {
+		return args, fmt.Errorf("Invalid request")
+	}
+	if total > 100 {
+		return args, fmt.Errorf("Max total should be less than or equal to 100")
+	}
+
+	args = readArgs{
+		page:  int(page),
+		total: int(total),
+		table: helper.Trim(params.table),
+	}
+
+	if !helper.IsEmpty(params.actor) {
+		args.actor, err = helper.NormalizeIdentity(params.actor)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: actor should be numeric")
+		}
+	}
+
+	if !helper.IsEmpty(params.action) {
+		args.action = strings.ToUpper(helper.Trim(params.action))
+		switch args.action {
+		case audit.ActionCreate, audit.ActionUpdate, audit.ActionDelete, audit.ActionErase:
+		default:
+			return args, fmt.Errorf("Error validation: wrong action")
+		}
+	}
+
+	if len(args.table) > 45 {
+		return args, fmt.Errorf("Error validation: table maximum consist of 45 characters")
+	}
+
+	if !helper.IsEmpty(params.targetID) {
+		args.targetID, err = strconv.ParseInt(params.targetID, 10, 64)
+		if err != nil || args.targetID < 1 {
+			return args, fmt.Errorf("Error validation: target_id should be numeric")
+		}
+	}
+
+	if !helper.IsEmpty(params.since) {
+		since, err := strconv.ParseInt(params.since, 10, 64)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: since should be unix timestamp")
+		}
+		args.since = time.Unix(since, 0)
+	}
+
+	if !helper.IsEmpty(params.until) {
+		until, err := strconv.ParseInt(params.until, 10, 64)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: until should be unix timestamp")
+		}
+		args.until = time.Unix(until, 0)
+	}
+
+	if !args.since.IsZero() && !args.until.IsZero() && !args.since.Before(args.until) {
+		return args, fmt.Errorf("Error validation: since should be before until")
+	}
+
+	return args, nil
+}
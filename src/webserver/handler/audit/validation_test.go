@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_readParams_validate(t *testing.T) {
+	type fields struct {
+		page     string
+		total    string
+		actor    string
+		action   string
+		table    string
+		targetID string
+		since    string
+		until    string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    readArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			fields:  fields{},
+			want:    readArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 2",
+			fields: fields{
+				page:  "1",
+				total: "101",
+			},
+			want:    readArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 3",
+			fields: fields{
+				page:   "1",
+				total:  "10",
+				action: "READ",
+			},
+			want:    readArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 4",
+			fields: fields{
+				page:  "1",
+				total: "10",
+				actor: "Hello Moto",
+			},
+			want:    readArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 5",
+			fields: fields{
+				page:  "1",
+				total: "10",
+				since: "1500000000",
+				until: "1400000000",
+			},
+			want:    readArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 6",
+			fields: fields{
+				page:  "2",
+				total: "10",
+			},
+			want: readArgs{
+				page:  2,
+				total: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test Case 7",
+			fields: fields{
+				page:     "1",
+				total:    "25",
+				actor:    "140810140016",
+				action:   "update",
+				table:    " users ",
+				targetID: "12",
+				since:    "1400000000",
+				until:    "1500000000",
+			},
+			want: readArgs{
+				page:     1,
+				total:    25,
+				actor:    140810140016,
+				action:   "UPDATE",
+				table:    "users",
+				targetID: 12,
+				since:    time.Unix(1400000000, 0),
+				until:    time.Unix(1500000000, 0),
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := readParams{
+				page:     tt.fields.page,
+				total:    tt.fields.total,
+				actor:    tt.fields.actor,
+				action:   tt.fields.action,
+				table:    tt.fields.table,
+				targetID: tt.fields.targetID,
+				since:    tt.fields.since,
+				until:    tt.fields.until,
+			}
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("readParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/ical"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// CreateHandler handles the http request for creating the url of the calendar feed of the user.
+// The previous url stops working, so it is also used when the url has been shared by mistake
+/*
+	@params:
+	@example:
+	@return
+		url = https://meiko.example/api/v1/calendar/X3k9aQ2b...ics
+*/
+func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	token, hash, err := auth.NewCalendarToken()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = user.UpdateCalendarToken(sess.ID, sql.NullString{String: hash, Valid: true})
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(createResponse{
+			URL: feedURL(r, token),
+		}))
+	return
+}
+
+// DeleteHandler handles the http request for revoking the calendar feed of the user
+/*
+	@params:
+	@example:
+	@return
+*/
+func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	err := user.UpdateCalendarToken(sess.ID, sql.NullString{})
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Calendar feed has been revoked"))
+	return
+}
+
+// FeedHandler handles the http request of calendar apps for the iCalendar feed of the user.
+// It doesn't need the session, the token in the url is the credential
+/*
+	@params:
+		token	= required, the token of the url created by CreateHandler
+	@example:
+		token	= X3k9aQ2b...ics
+	@return
+		text/calendar
+*/
+func FeedHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	params := feedParams{
+		token: ps.ByName("token"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound))
+		return
+	}
+
+	userID, err := user.GetIDByCalendarToken(auth.HashCalendarToken(args.token))
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound))
+		return
+	}
+
+	c, err := handleFeed(userID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	ical.Write(w, c)
+	return
+}
@@ -0,0 +1,167 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	ag "github.com/melodiez14/meiko/src/module/assignment"
+	att "github.com/melodiez14/meiko/src/module/attendance"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	tm "github.com/melodiez14/meiko/src/module/term"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/ical"
+)
+
+// feedURL returns the absolute url of the feed, calendar apps can't subscribe to a relative one
+func feedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s%s%s%s", scheme, r.Host, feedPath, token, feedExtension)
+}
+
+// handleFeed returns the timetable of the user, it contains the active schedules which the user
+// studies or assists with their meetings and assignment due dates
+func handleFeed(userID int64) (ical.Calendar, error) {
+	c := ical.Calendar{
+		ProductID: productID,
+		Events:    []ical.Event{},
+	}
+
+	users, err := user.SelectByID([]int64{userID}, false, user.ColID, user.ColName)
+	if err != nil || len(users) < 1 {
+		return c, err
+	}
+	c.Name = users[0].Name
+
+	var schedulesID []int64
+	for _, status := range []int8{cs.PStatusStudent, cs.PStatusAssistant} {
+		id, err := cs.SelectScheduleIDByUserID(userID, status)
+		if err != nil {
+			return c, err
+		}
+		schedulesID = append(schedulesID, id...)
+	}
+	if len(schedulesID) < 1 {
+		return c, nil
+	}
+
+	courses, err := cs.SelectByScheduleID(schedulesID, cs.StatusScheduleActive)
+	if err != nil || len(courses) < 1 {
+		return c, err
+	}
+
+	schedules := map[int64]cs.CourseSchedule{}
+	terms := map[int64]tm.Term{}
+	var activeID []int64
+	for _, val := range courses {
+		schedules[val.Schedule.ID] = val
+		activeID = append(activeID, val.Schedule.ID)
+
+		termID, err := cs.GetTermID(val.Schedule.ID)
+		if err != nil {
+			return c, err
+		}
+		// a schedule without term has no dates to repeat in
+		if !termID.Valid {
+			continue
+		}
+		term, ok := terms[termID.Int64]
+		if !ok {
+			term, err = tm.Get(termID.Int64)
+			if err != nil {
+				return c, err
+			}
+			terms[termID.Int64] = term
+		}
+		if e, ok := scheduleEvent(val, term); ok {
+			c.Events = append(c.Events, e)
+		}
+	}
+
+	meetings, err := att.SelectMeetingBySchedule(activeID)
+	if err != nil {
+		return c, err
+	}
+	for _, val := range meetings {
+		c.Events = append(c.Events, meetingEvent(val, schedules[val.ScheduleID]))
+	}
+
+	gps, err := cs.SelectGPBySchedule(activeID)
+	if err != nil || len(gps) < 1 {
+		return c, err
+	}
+	gpSchedule := map[int64]int64{}
+	var gpsID []int64
+	for _, val := range gps {
+		gpSchedule[val.ID] = val.ScheduleID
+		gpsID = append(gpsID, val.ID)
+	}
+
+	assignments, err := ag.SelectByGP(gpsID, false)
+	if err != nil {
+		return c, err
+	}
+	for _, val := range assignments {
+		c.Events = append(c.Events, assignmentEvent(val, schedules[gpSchedule[val.GradeParameterID]]))
+	}
+
+	return c, nil
+}
+
+// scheduleEvent returns the weekly event of the schedule from its first day in the term until the term ends.
+// It is false if the day of the schedule doesn't occur in the term
+func scheduleEvent(course cs.CourseSchedule, term tm.Term) (ical.Event, bool) {
+	offset := (int(course.Schedule.Day) - int(term.StartDate.Weekday()) + 7) % 7
+	first := startOfDay(term.StartDate).AddDate(0, 0, offset)
+	until := startOfDay(term.EndDate).AddDate(0, 0, 1).Add(-time.Second)
+	if first.After(until) {
+		return ical.Event{}, false
+	}
+
+	return ical.Event{
+		UID:         fmt.Sprintf("schedule-%d@%s", course.Schedule.ID, uidDomain),
+		Summary:     fmt.Sprintf("%s %s", course.Course.Name, course.Schedule.Class),
+		Description: course.Course.Description.String,
+		Location:    course.Schedule.PlaceID,
+		Start:       first.Add(time.Duration(course.Schedule.StartTime) * time.Minute),
+		End:         first.Add(time.Duration(course.Schedule.EndTime) * time.Minute),
+		Until:       until,
+	}, true
+}
+
+// meetingEvent returns the event of the meeting, a meeting without time takes the time of its schedule
+func meetingEvent(meeting att.Meeting, course cs.CourseSchedule) ical.Event {
+	start := meeting.Date
+	day := startOfDay(meeting.Date)
+	if start.Equal(day) {
+		start = day.Add(time.Duration(course.Schedule.StartTime) * time.Minute)
+	}
+	duration := time.Duration(int(course.Schedule.EndTime)-int(course.Schedule.StartTime)) * time.Minute
+
+	return ical.Event{
+		UID:         fmt.Sprintf("meeting-%d@%s", meeting.ID, uidDomain),
+		Summary:     fmt.Sprintf("%s %s: Meeting %d %s", course.Course.Name, course.Schedule.Class, meeting.Number, meeting.Subject),
+		Description: meeting.Description.String,
+		Location:    course.Schedule.PlaceID,
+		Start:       start,
+		End:         start.Add(duration),
+	}
+}
+
+// assignmentEvent returns the event at the due date of the assignment
+func assignmentEvent(assignment ag.Assignment, course cs.CourseSchedule) ical.Event {
+	return ical.Event{
+		UID:         fmt.Sprintf("assignment-%d@%s", assignment.ID, uidDomain),
+		Summary:     fmt.Sprintf("%s %s: %s is due", course.Course.Name, course.Schedule.Class, assignment.Name),
+		Description: assignment.Description.String,
+		Start:       assignment.DueDate,
+		End:         assignment.DueDate,
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
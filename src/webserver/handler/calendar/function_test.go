@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	att "github.com/melodiez14/meiko/src/module/attendance"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	tm "github.com/melodiez14/meiko/src/module/term"
+)
+
+func TestScheduleEvent(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*60*60)
+	term := tm.Term{
+		StartDate: time.Date(2018, 8, 27, 0, 0, 0, 0, loc), // monday
+		EndDate:   time.Date(2018, 12, 21, 0, 0, 0, 0, loc),
+	}
+	course := cs.CourseSchedule{
+		Course:   cs.Course{Name: "Algoritma"},
+		Schedule: cs.Schedule{ID: 100192, Day: 3, StartTime: 600, EndTime: 700, Class: "A", PlaceID: "UDJT-102"},
+	}
+
+	e, ok := scheduleEvent(course, term)
+	if !ok {
+		t.Fatalf("scheduleEvent() ok = false")
+	}
+	if want := time.Date(2018, 8, 29, 10, 0, 0, 0, loc); !e.Start.Equal(want) {
+		t.Errorf("scheduleEvent() start = %v, want %v", e.Start, want)
+	}
+	if want := time.Date(2018, 8, 29, 11, 40, 0, 0, loc); !e.End.Equal(want) {
+		t.Errorf("scheduleEvent() end = %v, want %v", e.End, want)
+	}
+	if want := time.Date(2018, 12, 21, 23, 59, 59, 0, loc); !e.Until.Equal(want) {
+		t.Errorf("scheduleEvent() until = %v, want %v", e.Until, want)
+	}
+	if e.UID != "schedule-100192@meiko" || e.Location != "UDJT-102" || e.Summary != "Algoritma A" {
+		t.Errorf("scheduleEvent() = %+v", e)
+	}
+
+	// the day doesn't occur in a term of two days
+	term.EndDate = time.Date(2018, 8, 28, 0, 0, 0, 0, loc)
+	if _, ok := scheduleEvent(course, term); ok {
+		t.Errorf("scheduleEvent() ok = true, want false")
+	}
+
+	meeting := att.Meeting{ID: 1, Number: 2, Subject: "Sorting", Date: time.Date(2018, 9, 5, 0, 0, 0, 0, loc)}
+	e = meetingEvent(meeting, course)
+	if want := time.Date(2018, 9, 5, 10, 0, 0, 0, loc); !e.Start.Equal(want) || !e.End.Equal(want.Add(100*time.Minute)) {
+		t.Errorf("meetingEvent() = %v - %v", e.Start, e.End)
+	}
+}
@@ -0,0 +1,20 @@
+package calendar
+
+const (
+	feedPath      = "/api/v1/calendar/"
+	feedExtension = ".ics"
+	productID     = "-//Meiko//Timetable//EN"
+	uidDomain     = "meiko"
+)
+
+type feedParams struct {
+	token string
+}
+
+type feedArgs struct {
+	token string
+}
+
+type createResponse struct {
+	URL string `json:"url"`
+}
@@ -0,0 +1,27 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+func (params feedParams) validate() (feedArgs, error) {
+	var args feedArgs
+
+	// calendar apps prefer the url which ends with the extension
+	token := strings.TrimSuffix(params.token, feedExtension)
+	if helper.IsEmpty(token) || len(token) > 64 {
+		return args, fmt.Errorf("Invalid token")
+	}
+	for _, c := range token {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return args, fmt.Errorf("Invalid token")
+		}
+	}
+
+	return feedArgs{
+		token: token,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package calendar
+
+import (
+	"testing"
+)
+
+func Test_feedParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "With extension", token: "X3k9aQ2b-_Zz.ics", want: "X3k9aQ2b-_Zz"},
+		{name: "Without extension", token: "X3k9aQ2b", want: "X3k9aQ2b"},
+		{name: "Empty", token: ".ics", wantErr: true},
+		{name: "Invalid character", token: "X3k9/aQ2b.ics", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := feedParams{token: tt.token}.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("feedParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.token != tt.want {
+				t.Errorf("feedParams.validate() = %v, want %v", got.token, tt.want)
+			}
+		})
+	}
+}
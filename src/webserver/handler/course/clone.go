@@ -0,0 +1,152 @@
+package course
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// CloneHandler handles the http request for copying the grade parameters, tutorials with their files, assignments
+// and information of the schedule into other classes of the same course. The due dates of the assignments are shifted
+// by the offset days, and the submissions and students are not copied. A target which has its own grade parameters keeps
+// them, and the content whose name already exists in the target is skipped. The preview only reports what will be copied,
+// otherwise every target is copied in one transaction or none of them. Accessing this handler needs XUPDATE course ability
+/*
+	@params:
+		target	= required, comma separated schedule ids, maximum 10 schedules
+		offset	= optional, days to shift the due dates, -365 until 365
+		preview	= optional, value=true or false
+	@example:
+		target	= 100193,100194
+		offset	= 182
+		preview	= true
+	@return
+		is_preview	= true
+		targets		= [{schedule_id: 100193, grade_parameters: [ASSIGNMENT], tutorials: [Modul 1], assignments: [{name: Tugas 1, due_date: 2019-03-11 23:59:59}], informations: [], files: 1, skipped: [], errors: []}]
+*/
+func CloneHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXUpdate, rg.ModuleCourse, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := cloneParams{
+		scheduleID: ps.ByName("schedule_id"),
+		target:     r.FormValue("target"),
+		offset:     r.FormValue("offset"),
+		preview:    r.FormValue("preview"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	schedule, err := cs.GetByScheduleID(args.scheduleID)
+	if err != nil || schedule.Schedule.Status == cs.StatusScheduleDeleted {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	src, err := handleCloneSource(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := cloneResponse{
+		IsPreview: args.isPreview,
+		Targets:   []cloneTargetResponse{},
+	}
+	var plans []clonePlan
+	isValid := true
+	for _, target := range args.targets {
+		plan, err := handleClonePlan(src, schedule.Course.ID, target, args.offset)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		if len(plan.response.Errors) > 0 {
+			isValid = false
+		}
+		plans = append(plans, plan)
+		res.Targets = append(res.Targets, plan.response)
+	}
+
+	if args.isPreview {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetData(res))
+		return
+	}
+
+	if !isValid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Some targets can't be cloned").
+			SetData(res))
+		return
+	}
+
+	var files []cloneFile
+	tx := conn.DB.MustBegin()
+	for _, plan := range plans {
+		copies, fs, err := handleCloneApply(sess.ID, src, plan, args.offset, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		files = append(files, fs...)
+
+		// the cloning is logged on the target schedule
+		err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, plan.scheduleID, nil, copies, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	// the files are copied last, so a failed copy can still roll back the metadata
+	err = copyCloneFile(files)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		removeCloneFile(files)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
@@ -14,12 +14,15 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	ag "github.com/melodiez14/meiko/src/module/assignment"
+	"github.com/melodiez14/meiko/src/module/audit"
 	cs "github.com/melodiez14/meiko/src/module/course"
 	fl "github.com/melodiez14/meiko/src/module/file"
 	pl "github.com/melodiez14/meiko/src/module/place"
 	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	tm "github.com/melodiez14/meiko/src/module/term"
 	"github.com/melodiez14/meiko/src/module/user"
 	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -54,7 +57,7 @@ import (
 func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleSchedule, rg.RoleCreate, rg.RoleXCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleSchedule, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -93,13 +96,48 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 			AddError("Schedule already exists"))
 		return
 	}
+	// the place can not be used by another schedule at the same time
+	slot := cs.TimeSlot{
+		Semester:  args.Semester,
+		Year:      args.Year,
+		Day:       args.Day,
+		StartTime: args.StartTime,
+		EndTime:   args.EndTime,
+	}
+	conflicts, err := handleConflict(slot, args.PlaceID, 0, nil, nil)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	if len(conflicts) > 0 {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusConflict).
+			AddError("Schedule conflicts with other schedules").
+			SetData(conflicts))
+		return
+	}
+
+	// the schedule belongs to the term of its semester and year
+	var termID sql.NullInt64
+	term, err := tm.GetBySemester(args.Semester, args.Year)
+	if err == nil {
+		if term.Status == tm.StatusClosed {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("Term has been closed"))
+			return
+		}
+		termID = sql.NullInt64{Int64: term.ID, Valid: true}
+	}
+
 	csExist := cs.IsExist(args.ID)
 	plExist := pl.IsExistID(args.PlaceID)
 
 	tx := conn.DB.MustBegin()
 
 	// insert new course and check create or xcreate roles
-	if !csExist && sess.IsHasRoles(rg.ModuleCourse, rg.RoleCreate, rg.RoleXCreate) {
+	if !csExist && policy.Can(sess, rg.RoleCreate, rg.ModuleCourse, 0) {
 		err = cs.Insert(args.ID, args.Name, args.Description, args.UCU, tx)
 		if err != nil {
 			tx.Rollback()
@@ -108,7 +146,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 			return
 		}
 		// update course and check update or xupdate roles
-	} else if csExist && args.IsUpdate && sess.IsHasRoles(rg.ModuleCourse, rg.RoleUpdate, rg.RoleXUpdate) {
+	} else if csExist && args.IsUpdate && policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, 0) {
 		err = cs.Update(args.ID, args.Name, args.Description, args.UCU, tx)
 		if err != nil {
 			tx.Rollback()
@@ -155,6 +193,14 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	err = cs.UpdateScheduleTerm(scheduleID, termID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	// set grade parameter
 	if len(args.GradeParameter) > 0 {
 		for _, val := range args.GradeParameter {
@@ -168,6 +214,28 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		}
 	}
 
+	err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, scheduleID, nil, auditSchedule{
+		CourseID:       args.ID,
+		Name:           args.Name,
+		Description:    args.Description.String,
+		UCU:            args.UCU,
+		Status:         cs.StatusScheduleActive,
+		Semester:       args.Semester,
+		Year:           args.Year,
+		StartTime:      args.StartTime,
+		EndTime:        args.EndTime,
+		Class:          args.Class,
+		Day:            args.Day,
+		PlaceID:        args.PlaceID,
+		GradeParameter: args.GradeParameter,
+	}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -195,7 +263,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleCourse, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -279,7 +347,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func SearchHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleCourse, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -351,7 +419,7 @@ func SearchHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 */
 func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleCourse, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -370,6 +438,13 @@ func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, args.ScheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
 	course, err := cs.GetByScheduleID(args.ScheduleID)
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -407,7 +482,7 @@ func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleSchedule, rg.RoleUpdate, rg.RoleXUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleSchedule, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -440,6 +515,13 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleSchedule, args.ScheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
 	// check if semester, year, id, class already used by another schedule
 	if cs.IsExistSchedule(args.Semester, args.Year, args.ID, args.Class, args.ScheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -505,19 +587,66 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	}
 
 	// is exist course and place
-	scExist := cs.IsExistScheduleID(args.ScheduleID)
-	if !scExist {
+	before, err := getAuditSchedule(args.ScheduleID)
+	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusNotFound))
 		return
 	}
+	// the place, the assistants and the students can not be in another schedule at the same time
+	assistantsID, err := cs.SelectAssistantID(args.ScheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	studentsID, err := cs.SelectEnrolledStudentID(args.ScheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	slot := cs.TimeSlot{
+		Semester:  args.Semester,
+		Year:      args.Year,
+		Day:       args.Day,
+		StartTime: args.StartTime,
+		EndTime:   args.EndTime,
+	}
+	conflicts, err := handleConflict(slot, args.PlaceID, args.ScheduleID, assistantsID, studentsID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	if len(conflicts) > 0 {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusConflict).
+			AddError("Schedule conflicts with other schedules").
+			SetData(conflicts))
+		return
+	}
+
+	// the schedule belongs to the term of its semester and year
+	var termID sql.NullInt64
+	term, err := tm.GetBySemester(args.Semester, args.Year)
+	if err == nil {
+		if term.Status == tm.StatusClosed {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("Term has been closed"))
+			return
+		}
+		termID = sql.NullInt64{Int64: term.ID, Valid: true}
+	}
+
 	csExist := cs.IsExist(args.ID)
 	plExist := pl.IsExistID(args.PlaceID)
 
 	tx := conn.DB.MustBegin()
 
 	// insert new course and check create or xcreate roles
-	if !csExist && sess.IsHasRoles(rg.ModuleCourse, rg.RoleCreate, rg.RoleXCreate) {
+	if !csExist && policy.Can(sess, rg.RoleCreate, rg.ModuleCourse, 0) {
 		err = cs.Insert(args.ID, args.Name, args.Description, args.UCU, tx)
 		if err != nil {
 			tx.Rollback()
@@ -526,7 +655,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 			return
 		}
 		// update course and check update or xupdate roles
-	} else if csExist && args.IsUpdate && sess.IsHasRoles(rg.ModuleCourse, rg.RoleUpdate, rg.RoleXUpdate) {
+	} else if csExist && args.IsUpdate && policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, 0) {
 		err = cs.Update(args.ID, args.Name, args.Description, args.UCU, tx)
 		if err != nil {
 			tx.Rollback()
@@ -563,6 +692,14 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	err = cs.UpdateScheduleTerm(args.ScheduleID, termID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	// delete old grade parameter
 	for _, val := range gpsDelete {
 		err := cs.DeleteGradeParameter(val.ID, tx)
@@ -596,6 +733,29 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		}
 	}
 
+	after := auditSchedule{
+		CourseID:       args.ID,
+		Name:           args.Name,
+		Description:    args.Description.String,
+		UCU:            args.UCU,
+		Status:         args.Status,
+		Semester:       args.Semester,
+		Year:           args.Year,
+		StartTime:      args.StartTime,
+		EndTime:        args.EndTime,
+		Class:          args.Class,
+		Day:            args.Day,
+		PlaceID:        args.PlaceID,
+		GradeParameter: args.GradeParameter,
+	}
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, args.ScheduleID, before, after, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -747,8 +907,7 @@ func GetAssistantHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	isHasAccess := false
 	switch args.payload {
 	case "assistant":
-		isHasAccess = cs.IsAssistant(sess.ID, args.scheduleID) &&
-			sess.IsHasRoles(rg.ModuleCourse, rg.RoleXRead, rg.RoleRead)
+		isHasAccess = policy.Can(sess, rg.RoleRead, rg.ModuleCourse, args.scheduleID)
 	case "student":
 		isHasAccess = cs.IsEnrolled(sess.ID, args.scheduleID)
 	}
@@ -831,7 +990,7 @@ func GetAssistantHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 func DeleteScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleSchedule, rg.RoleDelete, rg.RoleXDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleSchedule, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -850,14 +1009,39 @@ func DeleteScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter
 		return
 	}
 
-	if !cs.IsExistScheduleID(args.ScheduleID) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleSchedule, args.ScheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	before, err := getAuditSchedule(args.ScheduleID)
+	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusNotFound).
 			AddError("Not Found"))
 		return
 	}
 
-	err = cs.DeleteSchedule(args.ScheduleID)
+	tx := conn.DB.MustBegin()
+	err = cs.DeleteSchedule(args.ScheduleID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionDelete, auditTable, args.ScheduleID, before, nil, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
@@ -880,7 +1064,7 @@ func DeleteScheduleHandler(w http.ResponseWriter, r *http.Request, ps httprouter
 */
 func ListParameterHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleSchedule, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleSchedule, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -926,7 +1110,7 @@ func ListParameterHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 */
 func ReadScheduleParameterHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleSchedule, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleSchedule, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -945,6 +1129,13 @@ func ReadScheduleParameterHandler(w http.ResponseWriter, r *http.Request, ps htt
 		return
 	}
 
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleSchedule, args.ScheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
 	gps, err := cs.SelectGPBySchedule([]int64{args.ScheduleID})
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -968,7 +1159,7 @@ func ReadScheduleParameterHandler(w http.ResponseWriter, r *http.Request, ps htt
 
 func ListEnrolledHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleSchedule, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleSchedule, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -987,6 +1178,13 @@ func ListEnrolledHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleSchedule, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
 	studentIDs, err := cs.SelectEnrolledStudentID(args.scheduleID)
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
@@ -1025,7 +1223,7 @@ func ListEnrolledHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 func AddAssistantHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleCourse, rg.RoleXCreate, rg.RoleCreate, rg.RoleXUpdate, rg.RoleUpdate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleCourse, 0) && !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1047,13 +1245,12 @@ func AddAssistantHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 	}
 
 	// check if creator or assistant of specific schedule id
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
-		if !cs.IsCreator(sess.ID, args.scheduleID) {
-			template.RenderJSONResponse(w, new(template.Response).
-				SetCode(http.StatusForbidden).
-				AddError("You are not authorized"))
-			return
-		}
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleCourse, args.scheduleID) &&
+		!policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
 	}
 
 	newAssistant := []int64{}
@@ -1095,6 +1292,30 @@ func AddAssistantHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		}
 	}
 
+	// the new assistants can not attend another schedule at the same time
+	if len(insert) > 0 {
+		schedule, err := cs.GetByScheduleID(args.scheduleID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusNotFound).
+				AddError("Schedule does not exist"))
+			return
+		}
+		conflicts, err := handleConflict(scheduleSlot(schedule.Schedule), "", args.scheduleID, insert, nil)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		if len(conflicts) > 0 {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusConflict).
+				AddError("Assistants attend other schedules at the same time").
+				SetData(conflicts))
+			return
+		}
+	}
+
 	tx, err := conn.DB.Beginx()
 	if err != nil {
 		tx.Rollback()
@@ -1123,6 +1344,16 @@ func AddAssistantHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		}
 	}
 
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, args.scheduleID,
+		map[string][]int64{"assistants_id": oldAssistant},
+		map[string][]int64{"assistants_id": newAssistant}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	tx.Commit()
 
 	template.RenderJSONResponse(w, new(template.Response).
@@ -1203,28 +1434,127 @@ func EnrollRequestHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 	}
 
 	isUnapproved := cs.IsUnapproved(sess.ID, args.scheduleID)
+	isWaitlisted := cs.IsWaitlisted(sess.ID, args.scheduleID)
+	message := "Success"
 	switch args.payload {
 	case "enroll":
-		if isUnapproved {
+		if isUnapproved || isWaitlisted {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("Invalid Request"))
 			return
 		}
-		err = cs.InsertUnapproved(sess.ID, args.scheduleID)
+
+		// the schedule of a term can only be enrolled in its registration window
+		isOpen, err := isRegistrationOpen(args.scheduleID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		if !isOpen {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("The registration of the term is closed"))
+			return
+		}
+
+		courseID, err := cs.GetCourseID(args.scheduleID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+
+		unpassed, err := handleUnpassedPrerequisite(sess.ID, args.scheduleID, courseID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		if len(unpassed) > 0 {
+			var names []string
+			for _, val := range unpassed {
+				names = append(names, fmt.Sprintf("%s (%s) with minimum grade %.2f", val.Name, val.PrerequisiteID, val.MinGrade))
+			}
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError(fmt.Sprintf("You have to pass %s", strings.Join(names, ", "))))
+			return
+		}
+
+		// the schedule row is locked while the seats are counted, so the concurrent requests can't take the same seat
+		tx := conn.DB.MustBegin()
+		capacity, err := cs.LockCapacity(args.scheduleID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		seat, err := cs.CountSeat(args.scheduleID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+
+		// the schedule is full, so the request waits for a seat
+		if capacity.Valid && int64(seat) >= capacity.Int64 {
+			err = cs.InsertWaitlist(sess.ID, args.scheduleID, tx)
+			message = "The schedule is full, you have been added to the waitlist"
+		} else {
+			err = cs.InsertUnapproved(sess.ID, args.scheduleID, tx)
+		}
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+
+		err = tx.Commit()
 		if err != nil {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusInternalServerError))
 			return
 		}
 	case "cancel":
+		if isWaitlisted {
+			err = cs.DeleteWaitlist(sess.ID, args.scheduleID)
+			if err != nil {
+				template.RenderJSONResponse(w, new(template.Response).
+					SetCode(http.StatusInternalServerError))
+				return
+			}
+			break
+		}
+
 		if !isUnapproved {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("Invalid Request"))
 			return
 		}
-		err = cs.DeleteUserRelation(sess.ID, args.scheduleID)
+
+		// the canceled request frees a seat for the waitlist
+		tx := conn.DB.MustBegin()
+		err = cs.DeleteUserRelation(sess.ID, args.scheduleID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		_, err = handlePromoteWaitlist(args.scheduleID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		err = tx.Commit()
 		if err != nil {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusInternalServerError))
@@ -1239,7 +1569,7 @@ func EnrollRequestHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
-		SetMessage("Success"))
+		SetMessage(message))
 	return
 }
 
@@ -1262,13 +1592,11 @@ func AddInvolvedHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 	}
 
 	// check if creator or assistant of specific schedule id
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
-		if !cs.IsCreator(sess.ID, args.scheduleID) {
-			template.RenderJSONResponse(w, new(template.Response).
-				SetCode(http.StatusForbidden).
-				AddError("You are not authorized"))
-			return
-		}
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
 	}
 
 	user, err := user.GetByIdentityCode(args.identityCode, user.ColID)
@@ -1279,10 +1607,53 @@ func AddInvolvedHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
+	// the user can not attend another schedule at the same time
+	schedule, err := cs.GetByScheduleID(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+	var assistantsID, studentsID []int64
+	if args.role == cs.PStatusAssistant {
+		assistantsID = []int64{user.ID}
+	} else {
+		studentsID = []int64{user.ID}
+	}
+	conflicts, err := handleConflict(scheduleSlot(schedule.Schedule), "", args.scheduleID, assistantsID, studentsID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	if len(conflicts) > 0 {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusConflict).
+			AddError("User attends other schedules at the same time").
+			SetData(conflicts))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	action := audit.ActionCreate
+	status := args.role
 	if args.status == "add" {
+		// the user who is added directly leaves the waitlist
+		if cs.IsWaitlisted(user.ID, args.scheduleID) {
+			err = cs.DeleteWaitlist(user.ID, args.scheduleID, tx)
+			if err != nil {
+				tx.Rollback()
+				template.RenderJSONResponse(w, new(template.Response).
+					SetCode(http.StatusInternalServerError))
+				return
+			}
+		}
+
 		// if error, then it exists in table
-		err = cs.InsertInvolved(user.ID, args.scheduleID, args.role, nil)
+		err = cs.InsertInvolved(user.ID, args.scheduleID, args.role, tx)
 		if err != nil {
+			tx.Rollback()
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("Invalid Request"))
@@ -1290,13 +1661,126 @@ func AddInvolvedHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		}
 	} else {
 		// if error, then it doenst exist in table
-		err = cs.ActivateStudent(user.ID, args.scheduleID, nil)
+		err = cs.ActivateStudent(user.ID, args.scheduleID, tx)
 		if err != nil {
+			tx.Rollback()
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("Invalid Request"))
 			return
 		}
+		action = audit.ActionUpdate
+		status = cs.PStatusStudent
+	}
+
+	// the involvement is logged on its schedule
+	err = audit.Insert(sess.ID, action, auditTable, args.scheduleID, nil,
+		map[string]int64{"involved_users_id": user.ID, "involved_status": int64(status)}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Success"))
+	return
+}
+
+// RemoveInvolvedHandler handles the http request for rejecting the enrollment request or removing the student
+// from the schedule. The free seat is given to the earliest users in the waitlist
+/*
+	@params:
+		user_id	= required, identity code of the student
+	@example:
+		user_id=140810140060
+	@return
+*/
+func RemoveInvolvedHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := removeInvolvedParams{
+		identityCode: r.FormValue("user_id"),
+		scheduleID:   ps.ByName("schedule_id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
+		return
+	}
+
+	// check if creator or assistant of specific schedule id
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	u, err := user.GetByIdentityCode(args.identityCode, user.ColID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
+		return
+	}
+
+	status := int64(cs.PStatusStudent)
+	if cs.IsUnapproved(u.ID, args.scheduleID) {
+		status = cs.PStatusUnapproved
+	} else if !cs.IsEnrolled(u.ID, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("User is not a student of the schedule"))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = cs.DeleteUserRelation(u.ID, args.scheduleID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	promoted, err := handlePromoteWaitlist(args.scheduleID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	// the removal is logged on its schedule
+	err = audit.Insert(sess.ID, audit.ActionDelete, auditTable, args.scheduleID,
+		map[string]int64{"involved_users_id": u.ID, "involved_status": status},
+		map[string][]int64{"promoted_users_id": promoted}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
 	}
 
 	template.RenderJSONResponse(w, new(template.Response).
@@ -1321,13 +1805,11 @@ func GetInvolvedHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 	}
 
 	// check if creator or assistant of specific schedule id
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
-		if !cs.IsCreator(sess.ID, args.scheduleID) {
-			template.RenderJSONResponse(w, new(template.Response).
-				SetCode(http.StatusForbidden).
-				AddError("You are not authorized"))
-			return
-		}
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
 	}
 
 	resp := []getInvolvedResponse{}
@@ -1420,13 +1902,11 @@ func SearchUninvolvedHandler(w http.ResponseWriter, r *http.Request, ps httprout
 	}
 
 	// check if creator or assistant of specific schedule id
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
-		if !cs.IsCreator(sess.ID, args.scheduleID) {
-			template.RenderJSONResponse(w, new(template.Response).
-				SetCode(http.StatusForbidden).
-				AddError("You are not authorized"))
-			return
-		}
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
 	}
 
 	resp := []searchUninvolvedResponse{}
@@ -2,8 +2,21 @@ package course
 
 import (
 	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/jmoiron/sqlx"
+	ag "github.com/melodiez14/meiko/src/module/assignment"
 	cs "github.com/melodiez14/meiko/src/module/course"
+	fl "github.com/melodiez14/meiko/src/module/file"
+	inf "github.com/melodiez14/meiko/src/module/information"
+	tm "github.com/melodiez14/meiko/src/module/term"
+	tt "github.com/melodiez14/meiko/src/module/tutorial"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/alias"
 	"github.com/melodiez14/meiko/src/util/helper"
 )
 
@@ -148,3 +161,640 @@ func getAll(userID int64) ([]getResponse, error) {
 // 		return courses, err
 // 	}
 // }
+
+const auditTable = "schedules"
+
+// getAuditSchedule returns the audited values of the schedule and its grade parameters
+func getAuditSchedule(scheduleID int64) (auditSchedule, error) {
+	var value auditSchedule
+	c, err := cs.GetByScheduleID(scheduleID)
+	if err != nil {
+		return value, err
+	}
+
+	gps, err := cs.SelectGPBySchedule([]int64{scheduleID})
+	if err != nil {
+		return value, err
+	}
+
+	value = auditSchedule{
+		CourseID:       c.Course.ID,
+		Name:           c.Course.Name,
+		Description:    c.Course.Description.String,
+		UCU:            c.Course.UCU,
+		Status:         c.Schedule.Status,
+		Semester:       c.Schedule.Semester,
+		Year:           c.Schedule.Year,
+		StartTime:      int16(c.Schedule.StartTime),
+		EndTime:        int16(c.Schedule.EndTime),
+		Class:          c.Schedule.Class,
+		Day:            c.Schedule.Day,
+		PlaceID:        c.Schedule.PlaceID,
+		GradeParameter: []gradeParameter{},
+	}
+	for _, val := range gps {
+		value.GradeParameter = append(value.GradeParameter, gradeParameter{
+			Type:       val.Type,
+			Percentage: val.Percentage,
+		})
+	}
+	return value, nil
+}
+
+// handleGrade returns the total score of the user on each schedule which has a grade parameter.
+// It is computed the same way as the grade report of the assignment
+func handleGrade(userID int64, schedulesID []int64) (map[int64]float64, error) {
+	grades := map[int64]float64{}
+	if len(schedulesID) < 1 {
+		return grades, nil
+	}
+
+	gps, err := cs.SelectGPBySchedule(schedulesID)
+	if err != nil || len(gps) < 1 {
+		return grades, err
+	}
+
+	var gpsID []int64
+	for _, gp := range gps {
+		gpsID = append(gpsID, gp.ID)
+	}
+
+	assignments, err := ag.SelectByGP(gpsID, false)
+	if err != nil {
+		return grades, err
+	}
+
+	var asgID []int64
+	gpAsg := map[int64][]ag.Assignment{}
+	for _, val := range assignments {
+		asgID = append(asgID, val.ID)
+		gpAsg[val.GradeParameterID] = append(gpAsg[val.GradeParameterID], val)
+	}
+
+	asgSubmit := map[int64]ag.UserAssignment{}
+	if len(asgID) > 0 {
+		submitted, err := ag.SelectSubmittedByUser(asgID, userID)
+		if err != nil {
+			return grades, err
+		}
+		for _, val := range submitted {
+			asgSubmit[val.AssignmentID] = val
+		}
+	}
+
+	for _, gp := range gps {
+		if gp.Type == cs.GradeParameterAttendance {
+			continue
+		}
+		score := ag.Score(gpAsg[gp.ID], asgSubmit)
+		grades[gp.ScheduleID] += score * float64(gp.Percentage) / 100
+	}
+
+	return grades, nil
+}
+
+// handleUnpassedPrerequisite returns the prerequisites of the course which haven't been passed by the user.
+// A prerequisite is passed by studying any other schedule of it with a grade of at least its minimum grade
+func handleUnpassedPrerequisite(userID, scheduleID int64, courseID string) ([]cs.Prerequisite, error) {
+	var unpassed []cs.Prerequisite
+	prerequisites, err := cs.SelectPrerequisite(courseID)
+	if err != nil || len(prerequisites) < 1 {
+		return unpassed, err
+	}
+
+	var coursesID []string
+	for _, val := range prerequisites {
+		coursesID = append(coursesID, val.PrerequisiteID)
+	}
+
+	studied, err := cs.SelectStudiedSchedule(userID, coursesID, scheduleID)
+	if err != nil {
+		return unpassed, err
+	}
+
+	var schedulesID []int64
+	for _, val := range studied {
+		schedulesID = append(schedulesID, val.ScheduleID)
+	}
+
+	grades, err := handleGrade(userID, schedulesID)
+	if err != nil {
+		return unpassed, err
+	}
+
+	// the best grade of every studied prerequisite
+	best := map[string]float64{}
+	for _, val := range studied {
+		if grade, ok := best[val.CourseID]; !ok || grades[val.ScheduleID] > grade {
+			best[val.CourseID] = grades[val.ScheduleID]
+		}
+	}
+
+	for _, val := range prerequisites {
+		grade, ok := best[val.PrerequisiteID]
+		if !ok || grade < val.MinGrade {
+			unpassed = append(unpassed, val)
+		}
+	}
+	return unpassed, nil
+}
+
+// handlePromoteWaitlist turns the earliest waitlisted users into enrollment requests while the schedule
+// has a free seat, it returns the promoted users. The schedule row is locked like the enrollment
+func handlePromoteWaitlist(scheduleID int64, tx *sqlx.Tx) ([]int64, error) {
+	var promoted []int64
+	capacity, err := cs.LockCapacity(scheduleID, tx)
+	if err != nil {
+		return promoted, err
+	}
+
+	waitlist, err := cs.SelectWaitlist(scheduleID, tx)
+	if err != nil || len(waitlist) < 1 {
+		return promoted, err
+	}
+
+	seat, err := cs.CountSeat(scheduleID, tx)
+	if err != nil {
+		return promoted, err
+	}
+
+	for _, userID := range waitlist {
+		if capacity.Valid && int64(seat) >= capacity.Int64 {
+			break
+		}
+		err = cs.DeleteWaitlist(userID, scheduleID, tx)
+		if err != nil {
+			return promoted, err
+		}
+		err = cs.InsertInvolved(userID, scheduleID, cs.PStatusUnapproved, tx)
+		if err != nil {
+			return promoted, err
+		}
+		seat++
+		promoted = append(promoted, userID)
+	}
+	return promoted, nil
+}
+
+// handleConflict returns the schedules which overlap the slot, either by using the same place or by being attended
+// by the assistants or the students at the same time. An empty place skips the place check.
+// The user of the conflict is shown by the identity code
+func handleConflict(slot cs.TimeSlot, placeID string, scheduleID int64, assistantsID, studentsID []int64) ([]conflictResponse, error) {
+	resp := []conflictResponse{}
+
+	if len(placeID) > 0 {
+		rooms, err := cs.SelectPlaceConflict(placeID, slot, scheduleID)
+		if err != nil {
+			return resp, err
+		}
+		for _, val := range rooms {
+			resp = append(resp, newConflictResponse(conflictRoom, val, 0))
+		}
+	}
+
+	var usersID []int64
+	usersID = append(usersID, assistantsID...)
+	usersID = append(usersID, studentsID...)
+	conflicts, err := cs.SelectUserConflict(usersID, slot, scheduleID)
+	if err != nil || len(conflicts) < 1 {
+		return resp, err
+	}
+
+	users, err := user.SelectByID(usersID, false, user.ColID, user.ColIdentityCode)
+	if err != nil {
+		return resp, err
+	}
+	identityCodes := map[int64]int64{}
+	for _, val := range users {
+		identityCodes[val.ID] = val.IdentityCode
+	}
+
+	for _, val := range conflicts {
+		typ := conflictStudent
+		if helper.Int64InSlice(val.UserID.Int64, assistantsID) {
+			typ = conflictAssistant
+		}
+		resp = append(resp, newConflictResponse(typ, val, identityCodes[val.UserID.Int64]))
+	}
+	return resp, nil
+}
+
+func newConflictResponse(typ string, conflict cs.Conflict, identityCode int64) conflictResponse {
+	return conflictResponse{
+		Type:         typ,
+		ScheduleID:   conflict.ScheduleID,
+		CourseID:     conflict.CourseID,
+		Name:         conflict.Name,
+		Class:        conflict.Class,
+		Day:          helper.IntDayToString(conflict.Day),
+		StartTime:    conflict.StartTime,
+		EndTime:      conflict.EndTime,
+		PlaceID:      conflict.PlaceID,
+		IdentityCode: identityCode,
+	}
+}
+
+// scheduleSlot returns the time slot of the schedule
+func scheduleSlot(schedule cs.Schedule) cs.TimeSlot {
+	return cs.TimeSlot{
+		Semester:  schedule.Semester,
+		Year:      schedule.Year,
+		Day:       schedule.Day,
+		StartTime: int16(schedule.StartTime),
+		EndTime:   int16(schedule.EndTime),
+	}
+}
+
+// isRegistrationOpen reports whether the schedule can be enrolled now, a schedule without term is always open
+func isRegistrationOpen(scheduleID int64) (bool, error) {
+	termID, err := cs.GetTermID(scheduleID)
+	if err != nil || !termID.Valid {
+		return true, err
+	}
+
+	term, err := tm.Get(termID.Int64)
+	if err != nil {
+		return false, err
+	}
+	return term.Status != tm.StatusClosed && term.IsRegistrationOpen(time.Now()), nil
+}
+
+// handleRosterInvolvement returns the user id of each registered identity code and the relation of the users with the schedule
+func handleRosterInvolvement(scheduleID int64, identityCodes []int64) (map[int64]int64, map[int64]int8, error) {
+	usersID := map[int64]int64{}
+	involvements := map[int64]int8{}
+
+	users, err := user.SelectByIdentityCode(identityCodes, user.ColID, user.ColIdentityCode)
+	if err != nil {
+		return usersID, involvements, err
+	}
+
+	var ids []int64
+	for _, val := range users {
+		usersID[val.IdentityCode] = val.ID
+		ids = append(ids, val.ID)
+	}
+
+	relations, err := cs.SelectInvolvement(scheduleID, ids)
+	if err != nil {
+		return usersID, involvements, err
+	}
+	for _, val := range relations {
+		involvements[val.UserID] = val.Status
+	}
+	return usersID, involvements, nil
+}
+
+// handleRosterConflict fails the students who attend other schedules at the same time and returns the rest of them.
+// index is the position of the student in the results
+func handleRosterConflict(schedule cs.Schedule, scheduleID int64, usersID []int64, index map[int64]int, results []rosterResult) ([]int64, error) {
+	conflicts, err := handleConflict(scheduleSlot(schedule), "", scheduleID, nil, usersID)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := map[int64]string{}
+	for _, val := range conflicts {
+		if _, ok := reasons[val.IdentityCode]; !ok {
+			reasons[val.IdentityCode] = fmt.Sprintf("User attends %s %s at the same time", val.CourseID, val.Class)
+		}
+	}
+
+	var valid []int64
+	for _, id := range usersID {
+		i := index[id]
+		if reason, ok := reasons[results[i].IdentityCode]; ok {
+			results[i].Error = reason
+			continue
+		}
+		valid = append(valid, id)
+	}
+	return valid, nil
+}
+
+func newRosterResponse(results []rosterResult) rosterResponse {
+	resp := rosterResponse{
+		Total:   len(results),
+		Results: results,
+	}
+	for _, val := range results {
+		if val.Status == rosterResultFailed {
+			resp.Failed++
+			continue
+		}
+		resp.Succeeded++
+	}
+	return resp
+}
+
+// handleCloneSource loads the grade parameters, tutorials, assignments and information of the schedule with their files
+func handleCloneSource(scheduleID int64) (cloneSource, error) {
+	src := cloneSource{
+		scheduleID:      scheduleID,
+		tutorialFiles:   map[int64][]fl.File{},
+		assignmentFiles: map[int64][]fl.File{},
+		assignmentTypes: map[int64][]string{},
+	}
+
+	var err error
+	src.gradeParameters, err = cs.SelectGPBySchedule([]int64{scheduleID})
+	if err != nil {
+		return src, err
+	}
+
+	src.tutorials, err = tt.SelectByScheduleID(scheduleID)
+	if err != nil {
+		return src, err
+	}
+	var tutorialsID []string
+	for _, val := range src.tutorials {
+		tutorialsID = append(tutorialsID, strconv.FormatInt(val.ID, 10))
+	}
+	src.tutorialFiles, err = handleCloneSourceFile(fl.TypTutorial, tutorialsID)
+	if err != nil {
+		return src, err
+	}
+
+	var gpsID []int64
+	for _, val := range src.gradeParameters {
+		gpsID = append(gpsID, val.ID)
+	}
+	src.assignments, err = ag.SelectTemplateByGP(gpsID)
+	if err != nil {
+		return src, err
+	}
+	var assignmentsID []string
+	for _, val := range src.assignments {
+		assignmentsID = append(assignmentsID, strconv.FormatInt(val.ID, 10))
+		types, err := fl.SelectTypeByID(val.ID)
+		if err != nil {
+			return src, err
+		}
+		src.assignmentTypes[val.ID] = types
+	}
+	src.assignmentFiles, err = handleCloneSourceFile(fl.TypAssignment, assignmentsID)
+	if err != nil {
+		return src, err
+	}
+
+	src.informations, err = inf.SelectAllByScheduleID(scheduleID)
+	if err != nil {
+		return src, err
+	}
+	return src, nil
+}
+
+// handleCloneSourceFile returns the existing files of the type keyed by the id of their owner
+func handleCloneSourceFile(typ string, tablesID []string) (map[int64][]fl.File, error) {
+	files := map[int64][]fl.File{}
+	fs, err := fl.SelectByRelation(typ, tablesID, nil)
+	if err != nil {
+		return files, err
+	}
+	for _, val := range fs {
+		id, err := strconv.ParseInt(val.TableID.String, 10, 64)
+		if err != nil {
+			continue
+		}
+		files[id] = append(files[id], val)
+	}
+	return files, nil
+}
+
+// handleClonePlan decides what is copied into the target schedule. The tutorials, assignments and information
+// whose name already exists in the target are skipped, and the problems which prevent the cloning are put in the errors
+func handleClonePlan(src cloneSource, courseID string, targetID int64, offset int) (clonePlan, error) {
+	plan := clonePlan{
+		scheduleID:       targetID,
+		gradeParameterID: map[int64]int64{},
+		response: cloneTargetResponse{
+			ScheduleID:      targetID,
+			GradeParameters: []string{},
+			Tutorials:       []string{},
+			Assignments:     []cloneAssignmentResponse{},
+			Informations:    []string{},
+			Skipped:         []string{},
+			Errors:          []string{},
+		},
+	}
+
+	target, err := cs.GetByScheduleID(targetID)
+	if err != nil || target.Schedule.Status == cs.StatusScheduleDeleted {
+		plan.response.Errors = append(plan.response.Errors, "Schedule does not exist")
+		return plan, nil
+	}
+	if target.Course.ID != courseID {
+		plan.response.Errors = append(plan.response.Errors, fmt.Sprintf("Schedule is not a class of %s", courseID))
+		return plan, nil
+	}
+
+	// the target which has its own grade parameters keeps them
+	gps, err := cs.SelectGPBySchedule([]int64{targetID})
+	if err != nil {
+		return plan, err
+	}
+	types := map[string]int64{}
+	var gpsID []int64
+	for _, val := range gps {
+		types[val.Type] = val.ID
+		gpsID = append(gpsID, val.ID)
+	}
+	if len(gps) < 1 {
+		plan.gradeParameters = src.gradeParameters
+		for _, val := range src.gradeParameters {
+			plan.response.GradeParameters = append(plan.response.GradeParameters, val.Type)
+		}
+	}
+	gpType := map[int64]string{}
+	for _, val := range src.gradeParameters {
+		gpType[val.ID] = val.Type
+		if id, ok := types[val.Type]; ok {
+			plan.gradeParameterID[val.ID] = id
+		}
+	}
+
+	tutorials, err := tt.SelectByScheduleID(targetID)
+	if err != nil {
+		return plan, err
+	}
+	names := map[string]bool{}
+	for _, val := range tutorials {
+		names[val.Name] = true
+	}
+	for _, val := range src.tutorials {
+		if names[val.Name] {
+			plan.response.Skipped = append(plan.response.Skipped, fmt.Sprintf("Tutorial %s already exists", val.Name))
+			continue
+		}
+		plan.tutorials = append(plan.tutorials, val)
+		plan.response.Tutorials = append(plan.response.Tutorials, val.Name)
+		plan.response.Files += len(src.tutorialFiles[val.ID])
+	}
+
+	assignments, err := ag.SelectTemplateByGP(gpsID)
+	if err != nil {
+		return plan, err
+	}
+	names = map[string]bool{}
+	for _, val := range assignments {
+		names[val.Name] = true
+	}
+	for _, val := range src.assignments {
+		if names[val.Name] {
+			plan.response.Skipped = append(plan.response.Skipped, fmt.Sprintf("Assignment %s already exists", val.Name))
+			continue
+		}
+		if _, ok := plan.gradeParameterID[val.GradeParameterID]; len(gps) > 0 && !ok {
+			plan.response.Errors = append(plan.response.Errors,
+				fmt.Sprintf("Schedule has no %s grade parameter for assignment %s", gpType[val.GradeParameterID], val.Name))
+			continue
+		}
+		plan.assignments = append(plan.assignments, val)
+		plan.response.Assignments = append(plan.response.Assignments, cloneAssignmentResponse{
+			Name:    val.Name,
+			DueDate: val.DueDate.AddDate(0, 0, offset).Format(cloneDueDateLayout),
+		})
+		plan.response.Files += len(src.assignmentFiles[val.ID])
+	}
+
+	informations, err := inf.SelectAllByScheduleID(targetID)
+	if err != nil {
+		return plan, err
+	}
+	names = map[string]bool{}
+	for _, val := range informations {
+		names[val.Title] = true
+	}
+	for _, val := range src.informations {
+		if names[val.Title] {
+			plan.response.Skipped = append(plan.response.Skipped, fmt.Sprintf("Information %s already exists", val.Title))
+			continue
+		}
+		plan.informations = append(plan.informations, val)
+		plan.response.Informations = append(plan.response.Informations, val.Title)
+	}
+
+	return plan, nil
+}
+
+// handleCloneApply copies the plan into its schedule and returns the ids of the copies for the audit
+// and the files which have to be copied on the disk
+func handleCloneApply(userID int64, src cloneSource, plan clonePlan, offset int, tx *sqlx.Tx) (map[string]interface{}, []cloneFile, error) {
+	var files []cloneFile
+	var gpsID, tutorialsID, assignmentsID, informationsID []int64
+	copies := map[string]interface{}{"cloned_schedules_id": src.scheduleID}
+
+	for _, val := range plan.gradeParameters {
+		id, err := cs.CloneGradeParameter(val, plan.scheduleID, tx)
+		if err != nil {
+			return copies, files, err
+		}
+		plan.gradeParameterID[val.ID] = id
+		gpsID = append(gpsID, id)
+	}
+
+	for _, val := range plan.tutorials {
+		id, err := tt.Insert(val.Name, val.Description, plan.scheduleID, tx)
+		if err != nil {
+			return copies, files, err
+		}
+		fs, err := handleCloneFile(userID, src.tutorialFiles[val.ID], "tutorial", id, tx)
+		if err != nil {
+			return copies, files, err
+		}
+		files = append(files, fs...)
+		tutorialsID = append(tutorialsID, id)
+	}
+
+	for _, val := range plan.assignments {
+		id, err := ag.Clone(val, plan.gradeParameterID[val.GradeParameterID], val.DueDate.AddDate(0, 0, offset), tx)
+		if err != nil {
+			return copies, files, err
+		}
+		if types := src.assignmentTypes[val.ID]; len(types) > 0 {
+			err = fl.InsertType(types, id, tx)
+			if err != nil {
+				return copies, files, err
+			}
+		}
+		fs, err := handleCloneFile(userID, src.assignmentFiles[val.ID], "assignment", id, tx)
+		if err != nil {
+			return copies, files, err
+		}
+		files = append(files, fs...)
+		assignmentsID = append(assignmentsID, id)
+	}
+
+	for _, val := range plan.informations {
+		id, err := inf.Clone(val, plan.scheduleID, tx)
+		if err != nil {
+			return copies, files, err
+		}
+		informationsID = append(informationsID, id)
+	}
+
+	copies["grade_parameters_id"] = gpsID
+	copies["tutorials_id"] = tutorialsID
+	copies["assignments_id"] = assignmentsID
+	copies["informations_id"] = informationsID
+	return copies, files, nil
+}
+
+// handleCloneFile inserts the metadata of the copies of the files which belong to tableID.
+// payload is the directory of the files in the data directory
+func handleCloneFile(userID int64, files []fl.File, payload string, tableID int64, tx *sqlx.Tx) ([]cloneFile, error) {
+	var copies []cloneFile
+	for _, val := range files {
+		file := val
+		file.ID = fmt.Sprintf("%d.%06d", time.Now().UnixNano(), rand.Intn(999999))
+		err := fl.Clone(file, userID, strconv.FormatInt(tableID, 10), tx)
+		if err != nil {
+			return copies, err
+		}
+		copies = append(copies, cloneFile{
+			src: fmt.Sprintf("%s/%s/%s.%s", alias.Dir["data"], payload, val.ID, val.Extension),
+			dst: fmt.Sprintf("%s/%s/%s.%s", alias.Dir["data"], payload, file.ID, file.Extension),
+		})
+	}
+	return copies, nil
+}
+
+// copyCloneFile copies the files on the disk, the copied files are removed when one of them fails
+func copyCloneFile(files []cloneFile) error {
+	for i, val := range files {
+		err := copyFile(val.src, val.dst)
+		if err != nil {
+			removeCloneFile(files[:i])
+			return err
+		}
+	}
+	return nil
+}
+
+// removeCloneFile removes the copied files of the failed cloning
+func removeCloneFile(files []cloneFile) {
+	for _, val := range files {
+		os.Remove(val.dst)
+	}
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+	return out.Close()
+}
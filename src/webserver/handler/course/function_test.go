@@ -0,0 +1,41 @@
+package course
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyCloneFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "1.pdf")
+	if err := ioutil.WriteFile(src, []byte("modul"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []cloneFile{{src: src, dst: filepath.Join(dir, "2.pdf")}}
+	if err := copyCloneFile(files); err != nil {
+		t.Fatalf("copyCloneFile() error = %v", err)
+	}
+	if data, _ := ioutil.ReadFile(filepath.Join(dir, "2.pdf")); string(data) != "modul" {
+		t.Errorf("copyCloneFile() copied %q", data)
+	}
+
+	// the copies are removed when a file is missing
+	files = []cloneFile{
+		{src: src, dst: filepath.Join(dir, "3.pdf")},
+		{src: filepath.Join(dir, "missing.pdf"), dst: filepath.Join(dir, "4.pdf")},
+	}
+	if err := copyCloneFile(files); err == nil {
+		t.Errorf("copyCloneFile() should fail on a missing file")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "3.pdf")); !os.IsNotExist(err) {
+		t.Errorf("copyCloneFile() should remove the copied files")
+	}
+}
@@ -2,11 +2,43 @@ package course
 
 import (
 	"database/sql"
+
+	ag "github.com/melodiez14/meiko/src/module/assignment"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	fl "github.com/melodiez14/meiko/src/module/file"
+	inf "github.com/melodiez14/meiko/src/module/information"
+	tt "github.com/melodiez14/meiko/src/module/tutorial"
 )
 
 const (
 	SheduleStatusAssistant = 2
 	SheduleStatusPraktikan = 1
+
+	conflictRoom      = "room"
+	conflictAssistant = "assistant"
+	conflictStudent   = "student"
+
+	rosterApprove = "approve"
+	rosterReject  = "reject"
+	rosterRemove  = "remove"
+
+	rosterResultApproved = "approved"
+	rosterResultRejected = "rejected"
+	rosterResultRemoved  = "removed"
+	rosterResultEnrolled = "enrolled"
+	rosterResultFailed   = "failed"
+
+	// rosterFileSizeMax is the maximum size of the roster file
+	rosterFileSizeMax = 2 << 20
+	// rosterMax is the maximum number of students changed at once
+	rosterMax = 500
+
+	// cloneTargetMax is the maximum number of schedules cloned at once
+	cloneTargetMax = 10
+	// cloneOffsetMax is the maximum days the due dates are shifted
+	cloneOffsetMax = 365
+	// cloneDueDateLayout is the layout of the due date in the clone response
+	cloneDueDateLayout = "2006-01-02 15:04:05"
 )
 
 type readParams struct {
@@ -322,6 +354,16 @@ type addInvolvedArgs struct {
 	status       string
 }
 
+type removeInvolvedParams struct {
+	identityCode string
+	scheduleID   string
+}
+
+type removeInvolvedArgs struct {
+	identityCode int64
+	scheduleID   int64
+}
+
 type getInvolvedParams struct {
 	role       string
 	scheduleID string
@@ -352,3 +394,201 @@ type searchUninvolvedResponse struct {
 	IdentityCode int64  `json:"id"`
 	Name         string `json:"name"`
 }
+
+type auditSchedule struct {
+	CourseID       string           `json:"courses_id"`
+	Name           string           `json:"name"`
+	Description    string           `json:"description"`
+	UCU            int8             `json:"ucu"`
+	Status         int8             `json:"status"`
+	Semester       int8             `json:"semester"`
+	Year           int16            `json:"year"`
+	StartTime      int16            `json:"start_time"`
+	EndTime        int16            `json:"end_time"`
+	Class          string           `json:"class"`
+	Day            int8             `json:"day"`
+	PlaceID        string           `json:"places_id"`
+	GradeParameter []gradeParameter `json:"grade_parameters"`
+}
+
+type readCapacityParams struct {
+	scheduleID string
+}
+
+type readCapacityArgs struct {
+	scheduleID int64
+}
+
+type readCapacityResponse struct {
+	Capacity int64              `json:"capacity,omitempty"`
+	Seat     int                `json:"seat"`
+	Waitlist []waitlistResponse `json:"waitlist"`
+}
+
+type waitlistResponse struct {
+	IdentityCode int64  `json:"id"`
+	Name         string `json:"name"`
+}
+
+type updateCapacityParams struct {
+	scheduleID string
+	capacity   string
+}
+
+type updateCapacityArgs struct {
+	scheduleID int64
+	capacity   sql.NullInt64
+}
+
+type prerequisite struct {
+	CourseID string  `json:"course_id"`
+	MinGrade float64 `json:"min_grade"`
+}
+
+type readPrerequisiteParams struct {
+	scheduleID string
+}
+
+type readPrerequisiteArgs struct {
+	scheduleID int64
+}
+
+type readPrerequisiteResponse struct {
+	CourseID string  `json:"course_id"`
+	Name     string  `json:"name"`
+	MinGrade float64 `json:"min_grade"`
+}
+
+type updatePrerequisiteParams struct {
+	scheduleID   string
+	prerequisite string
+}
+
+type updatePrerequisiteArgs struct {
+	scheduleID   int64
+	prerequisite []prerequisite
+}
+
+type conflictResponse struct {
+	Type         string `json:"type"`
+	ScheduleID   int64  `json:"schedule_id"`
+	CourseID     string `json:"course_id"`
+	Name         string `json:"name"`
+	Class        string `json:"class"`
+	Day          string `json:"day"`
+	StartTime    uint16 `json:"start_time"`
+	EndTime      uint16 `json:"end_time"`
+	PlaceID      string `json:"place"`
+	IdentityCode int64  `json:"user_id,omitempty"`
+}
+
+type updateRosterParams struct {
+	scheduleID string
+	action     string
+	userID     string
+}
+
+type updateRosterArgs struct {
+	scheduleID    int64
+	action        string
+	identityCodes []int64
+}
+
+type importRosterParams struct {
+	scheduleID string
+}
+
+type importRosterArgs struct {
+	scheduleID int64
+	rows       []rosterRow
+}
+
+type rosterFileParams struct {
+	rows [][]string
+}
+
+// rosterRow is a row of the roster file, IdentityCode is empty when the row is invalid
+type rosterRow struct {
+	Row          int
+	IdentityCode int64
+	Error        string
+}
+
+type rosterResponse struct {
+	Total     int            `json:"total"`
+	Succeeded int            `json:"succeeded"`
+	Failed    int            `json:"failed"`
+	Results   []rosterResult `json:"results"`
+}
+
+type rosterResult struct {
+	Row          int    `json:"row,omitempty"`
+	IdentityCode int64  `json:"id,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+}
+
+type cloneParams struct {
+	scheduleID string
+	target     string
+	offset     string
+	preview    string
+}
+
+type cloneArgs struct {
+	scheduleID int64
+	targets    []int64
+	offset     int
+	isPreview  bool
+}
+
+type cloneResponse struct {
+	IsPreview bool                  `json:"is_preview"`
+	Targets   []cloneTargetResponse `json:"targets"`
+}
+
+type cloneTargetResponse struct {
+	ScheduleID      int64                     `json:"schedule_id"`
+	GradeParameters []string                  `json:"grade_parameters"`
+	Tutorials       []string                  `json:"tutorials"`
+	Assignments     []cloneAssignmentResponse `json:"assignments"`
+	Informations    []string                  `json:"informations"`
+	Files           int                       `json:"files"`
+	Skipped         []string                  `json:"skipped"`
+	Errors          []string                  `json:"errors"`
+}
+
+type cloneAssignmentResponse struct {
+	Name    string `json:"name"`
+	DueDate string `json:"due_date"`
+}
+
+// cloneSource is the content of the cloned schedule, the files and the allowed types are keyed by the id of their owner
+type cloneSource struct {
+	scheduleID      int64
+	gradeParameters []cs.GradeParameter
+	tutorials       []tt.Tutorial
+	tutorialFiles   map[int64][]fl.File
+	assignments     []ag.Assignment
+	assignmentFiles map[int64][]fl.File
+	assignmentTypes map[int64][]string
+	informations    []inf.Information
+}
+
+// clonePlan is the content which is copied into the target. gradeParameterID maps the grade parameters of the source
+// to the existing ones of the target, the grade parameters are copied only into the target which has none
+type clonePlan struct {
+	scheduleID       int64
+	gradeParameters  []cs.GradeParameter
+	gradeParameterID map[int64]int64
+	tutorials        []tt.Tutorial
+	assignments      []ag.Assignment
+	informations     []inf.Information
+	response         cloneTargetResponse
+}
+
+// cloneFile is a file which is copied on the disk after its metadata is inserted
+type cloneFile struct {
+	src string
+	dst string
+}
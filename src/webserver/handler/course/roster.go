@@ -0,0 +1,410 @@
+package course
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/util/spreadsheet"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// UpdateRosterHandler handles the http request for approving or rejecting the enrollment requests,
+// or removing the students of the schedule at once. The students who can't be changed are reported
+// in the results, the rest of them are changed in one transaction. The free seats are given to the waitlist
+/*
+	@params:
+		action	= required, value=approve, reject or remove
+		user_id	= required, comma separated identity codes, maximum 500 users
+	@example:
+		action	= approve
+		user_id	= 140810140016,140810140060
+	@return
+		total		= 2
+		succeeded	= 1
+		failed		= 1
+		results		= [{id: 140810140016, status: approved}, {id: 140810140060, status: failed, error: User has no enrollment request}]
+*/
+func UpdateRosterHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := updateRosterParams{
+		scheduleID: ps.ByName("schedule_id"),
+		action:     r.FormValue("action"),
+		userID:     r.FormValue("user_id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	// check if creator or assistant of specific schedule id
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	schedule, err := cs.GetByScheduleID(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	usersID, involvements, err := handleRosterInvolvement(args.scheduleID, args.identityCodes)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	results := make([]rosterResult, len(args.identityCodes))
+	index := map[int64]int{}
+	var candidates []int64
+	for i, identityCode := range args.identityCodes {
+		results[i] = rosterResult{
+			IdentityCode: identityCode,
+			Status:       rosterResultFailed,
+		}
+
+		id, ok := usersID[identityCode]
+		if !ok {
+			results[i].Error = "User does not exist"
+			continue
+		}
+
+		status, involved := involvements[id]
+		if args.action == rosterRemove && (!involved || status != cs.PStatusStudent) {
+			results[i].Error = "User is not a student of the schedule"
+			continue
+		}
+		if args.action != rosterRemove && (!involved || status != cs.PStatusUnapproved) {
+			results[i].Error = "User has no enrollment request"
+			continue
+		}
+
+		index[id] = i
+		candidates = append(candidates, id)
+	}
+
+	// the approved students can not attend another schedule at the same time
+	if args.action == rosterApprove && len(candidates) > 0 {
+		candidates, err = handleRosterConflict(schedule.Schedule, args.scheduleID, candidates, index, results)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	if len(candidates) < 1 {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetData(newRosterResponse(results)))
+		return
+	}
+
+	status := map[string]string{
+		rosterApprove: rosterResultApproved,
+		rosterReject:  rosterResultRejected,
+		rosterRemove:  rosterResultRemoved,
+	}[args.action]
+
+	tx := conn.DB.MustBegin()
+	for _, id := range candidates {
+		if args.action == rosterApprove {
+			err = cs.ActivateStudent(id, args.scheduleID, tx)
+		} else {
+			err = cs.DeleteUserRelation(id, args.scheduleID, tx)
+		}
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	action := audit.ActionUpdate
+	before := map[string]interface{}{"involved_users_id": candidates, "involved_status": cs.PStatusUnapproved}
+	after := map[string]interface{}{"involved_users_id": candidates, "involved_status": cs.PStatusStudent}
+	if args.action != rosterApprove {
+		promoted, err := handlePromoteWaitlist(args.scheduleID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+
+		action = audit.ActionDelete
+		if args.action == rosterRemove {
+			before["involved_status"] = cs.PStatusStudent
+		}
+		after = map[string]interface{}{"promoted_users_id": promoted}
+	}
+
+	// the roster change is logged on its schedule
+	err = audit.Insert(sess.ID, action, auditTable, args.scheduleID, before, after, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	for _, id := range candidates {
+		results[index[id]].Status = status
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(newRosterResponse(results)))
+	return
+}
+
+// ImportRosterHandler handles the http request for enrolling the students of the CSV or XLSX roster directly.
+// The roster has an id column, or only the identity codes without header. The enrollment requests are approved,
+// the waitlisted students leave the waitlist, and the rows which can't be enrolled are reported in the results.
+// The rest of them are enrolled in one transaction
+/*
+	@params:
+		file	= required, csv or xlsx, size<=2MB, maximum 500 users
+	@example:
+		file	= roster.csv
+	@return
+		total		= 2
+		succeeded	= 1
+		failed		= 1
+		results		= [{row: 2, id: 140810140016, status: enrolled}, {row: 3, id: 140810140060, status: failed, error: User has been enrolled}]
+*/
+func ImportRosterHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := importRosterParams{
+		scheduleID: ps.ByName("schedule_id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	// check if creator or assistant of specific schedule id before reading the file
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	r.ParseMultipartForm(rosterFileSizeMax)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("File is not exist"))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > rosterFileSizeMax {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("File is too large"))
+		return
+	}
+
+	_, ext, err := helper.ExtractExtension(header.Filename)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("File doesn't have an extension"))
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(file, rosterFileSizeMax))
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Failed to read the file"))
+		return
+	}
+
+	rows, err := spreadsheet.Read(data, ext, rosterMax+1)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	fileParams := rosterFileParams{
+		rows: rows,
+	}
+
+	args.rows, err = fileParams.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	schedule, err := cs.GetByScheduleID(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	var identityCodes []int64
+	for _, row := range args.rows {
+		if row.IdentityCode != 0 {
+			identityCodes = append(identityCodes, row.IdentityCode)
+		}
+	}
+
+	usersID, involvements, err := handleRosterInvolvement(args.scheduleID, identityCodes)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	waitlist, err := cs.SelectWaitlist(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	results := make([]rosterResult, len(args.rows))
+	index := map[int64]int{}
+	var candidates []int64
+	for i, row := range args.rows {
+		results[i] = rosterResult{
+			Row:          row.Row,
+			IdentityCode: row.IdentityCode,
+			Status:       rosterResultFailed,
+			Error:        row.Error,
+		}
+		if row.IdentityCode == 0 {
+			continue
+		}
+
+		id, ok := usersID[row.IdentityCode]
+		if !ok {
+			results[i].Error = "User does not exist"
+			continue
+		}
+
+		if status, involved := involvements[id]; involved {
+			switch status {
+			case cs.PStatusStudent:
+				results[i].Error = "User has been enrolled"
+				continue
+			case cs.PStatusAssistant:
+				results[i].Error = "User is an assistant of the schedule"
+				continue
+			}
+		}
+
+		index[id] = i
+		candidates = append(candidates, id)
+	}
+
+	// the enrolled students can not attend another schedule at the same time
+	if len(candidates) > 0 {
+		candidates, err = handleRosterConflict(schedule.Schedule, args.scheduleID, candidates, index, results)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	if len(candidates) < 1 {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetData(newRosterResponse(results)))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	for _, id := range candidates {
+		if _, involved := involvements[id]; involved {
+			err = cs.ActivateStudent(id, args.scheduleID, tx)
+		} else {
+			// the student who is enrolled directly leaves the waitlist
+			if helper.Int64InSlice(id, waitlist) {
+				err = cs.DeleteWaitlist(id, args.scheduleID, tx)
+				if err != nil {
+					tx.Rollback()
+					template.RenderJSONResponse(w, new(template.Response).
+						SetCode(http.StatusInternalServerError))
+					return
+				}
+			}
+			err = cs.InsertInvolved(id, args.scheduleID, cs.PStatusStudent, tx)
+		}
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	// the roster import is logged on its schedule
+	err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, args.scheduleID, nil,
+		map[string]interface{}{"involved_users_id": candidates, "involved_status": cs.PStatusStudent}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	for _, id := range candidates {
+		results[index[id]].Status = rosterResultEnrolled
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(newRosterResponse(results)))
+	return
+}
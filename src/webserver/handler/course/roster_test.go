@@ -0,0 +1,87 @@
+package course
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/util/auth"
+)
+
+// newRosterRequest returns the roster import request of the xlsx whose first sheet is the rows
+func newRosterRequest(t *testing.T, rows string) *http.Request {
+	files := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Roster" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml":   `<worksheet><sheetData>` + rows + `</sheetData></worksheet>`,
+	}
+	var xlsx bytes.Buffer
+	z := zip.NewWriter(&xlsx)
+	for name, content := range files {
+		f, err := z.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	z.Close()
+
+	var body bytes.Buffer
+	m := multipart.NewWriter(&body)
+	f, err := m.CreateFormFile("file", "roster.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(xlsx.Bytes())
+	m.Close()
+
+	r := httptest.NewRequest("POST", "/api/admin/v1/course/100192/roster", &body)
+	r.Header.Set("Content-Type", m.FormDataContentType())
+	sess := &auth.User{ID: 1, Roles: map[string][]string{rg.ModuleCourse: {rg.RoleXUpdate}}}
+	return r.WithContext(context.WithValue(r.Context(), "User", sess))
+}
+
+func TestImportRosterHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		rows string
+		want string
+	}{
+		{
+			name: "Row beyond the sheet",
+			rows: `<row r="20000000"><c r="A20000000" t="inlineStr"><is><t>140810140016</t></is></c></row>`,
+			want: "Invalid xlsx file",
+		},
+		{
+			name: "Column beyond the sheet",
+			rows: `<row r="1"><c r="AAAAA1" t="inlineStr"><is><t>140810140016</t></is></c></row>`,
+			want: "Invalid xlsx file",
+		},
+		{
+			name: "Distant row",
+			rows: `<row r="1000000"><c r="A1000000" t="inlineStr"><is><t>140810140016</t></is></c></row>`,
+			want: "Roster doesn't have any user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ps := httprouter.Params{{Key: "schedule_id", Value: "100192"}}
+			ImportRosterHandler(w, newRosterRequest(t, tt.rows), ps)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("ImportRosterHandler() code = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("ImportRosterHandler() body = %s, want %s", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
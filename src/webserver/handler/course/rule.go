@@ -0,0 +1,352 @@
+package course
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// ReadCapacityHandler handles the http request for reading the capacity, the taken seats and the waitlist of the schedule
+/*
+	@params:
+	@example:
+	@return
+		capacity	= 40, it is omitted on unlimited schedule
+		seat		= 38
+		waitlist	= [{id: 140810140060, name: Risal Falah}]
+*/
+func ReadCapacityHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := readCapacityParams{
+		scheduleID: ps.ByName("schedule_id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
+		return
+	}
+
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	capacity, err := cs.GetCapacity(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	seat, err := cs.CountSeat(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	waitlist, err := cs.SelectWaitlist(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	users := map[int64]user.User{}
+	if len(waitlist) > 0 {
+		u, err := user.SelectByID(waitlist, false, user.ColID, user.ColIdentityCode, user.ColName)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		for _, val := range u {
+			users[val.ID] = val
+		}
+	}
+
+	// keep the order of the waitlist
+	res := readCapacityResponse{
+		Capacity: capacity.Int64,
+		Seat:     seat,
+		Waitlist: []waitlistResponse{},
+	}
+	for _, id := range waitlist {
+		u, ok := users[id]
+		if !ok {
+			continue
+		}
+		res.Waitlist = append(res.Waitlist, waitlistResponse{
+			IdentityCode: u.IdentityCode,
+			Name:         u.Name,
+		})
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// UpdateCapacityHandler handles the http request for changing the capacity of the schedule.
+// The new free seats are given to the earliest users in the waitlist
+/*
+	@params:
+		capacity	= optional, numeric, 1 until 65535, empty makes it unlimited
+	@example:
+		capacity=40
+	@return
+*/
+func UpdateCapacityHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := updateCapacityParams{
+		scheduleID: ps.ByName("schedule_id"),
+		capacity:   r.FormValue("capacity"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	before, err := cs.GetCapacity(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	if before == args.capacity {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetMessage("Success"))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = cs.UpdateCapacity(args.scheduleID, args.capacity, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	promoted, err := handlePromoteWaitlist(args.scheduleID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, args.scheduleID,
+		map[string]interface{}{"capacity": before},
+		map[string]interface{}{"capacity": args.capacity, "promoted_users_id": promoted}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Success"))
+	return
+}
+
+// ReadPrerequisiteHandler handles the http request for reading the prerequisites of the course of the schedule
+/*
+	@params:
+	@example:
+	@return
+		[{course_id: D10K-7D01, name: Algoritma, min_grade: 60}]
+*/
+func ReadPrerequisiteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := readPrerequisiteParams{
+		scheduleID: ps.ByName("schedule_id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
+		return
+	}
+
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleCourse, args.scheduleID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	courseID, err := cs.GetCourseID(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	prerequisites, err := cs.SelectPrerequisite(courseID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := []readPrerequisiteResponse{}
+	for _, val := range prerequisites {
+		res = append(res, readPrerequisiteResponse{
+			CourseID: val.PrerequisiteID,
+			Name:     val.Name,
+			MinGrade: val.MinGrade,
+		})
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// UpdatePrerequisiteHandler handles the http request for replacing the prerequisites of the course of the schedule.
+// The prerequisites belong to the course, so every schedule of the course is affected
+/*
+	@params:
+		prerequisite	= optional, json array of course_id and min_grade, empty removes all of them
+	@example:
+		prerequisite=[{"course_id":"D10K-7D01","min_grade":60}]
+	@return
+*/
+func UpdatePrerequisiteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := updatePrerequisiteParams{
+		scheduleID:   ps.ByName("schedule_id"),
+		prerequisite: r.FormValue("prerequisite"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	// the course is shared by every schedule, so only who can update any course may change it
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleCourse, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You are not authorized"))
+		return
+	}
+
+	courseID, err := cs.GetCourseID(args.scheduleID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Schedule does not exist"))
+		return
+	}
+
+	var prerequisites []cs.Prerequisite
+	for _, val := range args.prerequisite {
+		if val.CourseID == courseID {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("A course can not be its own prerequisite"))
+			return
+		}
+		if !cs.IsExist(val.CourseID) {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError(fmt.Sprintf("Course %s does not exist", val.CourseID)))
+			return
+		}
+		prerequisites = append(prerequisites, cs.Prerequisite{
+			CourseID:       courseID,
+			PrerequisiteID: val.CourseID,
+			MinGrade:       val.MinGrade,
+		})
+	}
+
+	before, err := cs.SelectPrerequisite(courseID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = cs.ReplacePrerequisite(courseID, prerequisites, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, args.scheduleID,
+		map[string]interface{}{"courses_id": courseID, "prerequisites": before},
+		map[string]interface{}{"courses_id": courseID, "prerequisites": prerequisites}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Success"))
+	return
+}
@@ -7,6 +7,7 @@ import (
 	"html"
 	"strconv"
 	"strings"
+	"time"
 
 	cs "github.com/melodiez14/meiko/src/module/course"
 	"github.com/melodiez14/meiko/src/util/helper"
@@ -112,7 +113,7 @@ func (params createParams) validate() (createArgs, error) {
 	if err != nil {
 		return args, fmt.Errorf("Year must be numeric")
 	}
-	if year < 2017 || year > 2020 {
+	if year < 2017 || year > int64(time.Now().Year()+1) {
 		return args, fmt.Errorf("Invalid year")
 	}
 
@@ -328,7 +329,7 @@ func (params updateParams) validate() (updateArgs, error) {
 	if err != nil {
 		return args, fmt.Errorf("Year must be numeric")
 	}
-	if year < 2017 || year > 2020 {
+	if year < 2017 || year > int64(time.Now().Year()+1) {
 		return args, fmt.Errorf("Invalid year")
 	}
 
@@ -643,6 +644,25 @@ func (params addInvolvedParams) validate() (addInvolvedArgs, error) {
 	}, nil
 }
 
+func (params removeInvolvedParams) validate() (removeInvolvedArgs, error) {
+	var args removeInvolvedArgs
+
+	identityCode, err := strconv.ParseInt(params.identityCode, 10, 64)
+	if err != nil {
+		return args, err
+	}
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, err
+	}
+
+	return removeInvolvedArgs{
+		identityCode: identityCode,
+		scheduleID:   scheduleID,
+	}, nil
+}
+
 func (params getInvolvedParams) validate() (getInvolvedArgs, error) {
 	var args getInvolvedArgs
 
@@ -672,3 +692,264 @@ func (params searchUninvolvedParams) validate() (searchUninvolvedArgs, error) {
 		text:       text,
 	}, nil
 }
+
+func (params readCapacityParams) validate() (readCapacityArgs, error) {
+	var args readCapacityArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, err
+	}
+
+	return readCapacityArgs{
+		scheduleID: scheduleID,
+	}, nil
+}
+
+func (params updateCapacityParams) validate() (updateCapacityArgs, error) {
+	var args updateCapacityArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, err
+	}
+
+	// an empty capacity makes the schedule unlimited
+	var capacity sql.NullInt64
+	params.capacity = helper.Trim(params.capacity)
+	if !helper.IsEmpty(params.capacity) {
+		c, err := strconv.ParseUint(params.capacity, 10, 16)
+		if err != nil || c < 1 {
+			return args, fmt.Errorf("Invalid capacity")
+		}
+		capacity = sql.NullInt64{Valid: true, Int64: int64(c)}
+	}
+
+	return updateCapacityArgs{
+		scheduleID: scheduleID,
+		capacity:   capacity,
+	}, nil
+}
+
+func (params readPrerequisiteParams) validate() (readPrerequisiteArgs, error) {
+	var args readPrerequisiteArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, err
+	}
+
+	return readPrerequisiteArgs{
+		scheduleID: scheduleID,
+	}, nil
+}
+
+func (params updatePrerequisiteParams) validate() (updatePrerequisiteArgs, error) {
+	var args updatePrerequisiteArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, err
+	}
+
+	// an empty prerequisite removes all of them
+	prerequisites := []prerequisite{}
+	if !helper.IsEmpty(params.prerequisite) {
+		var ps []prerequisite
+		err = json.Unmarshal([]byte(params.prerequisite), &ps)
+		if err != nil {
+			return args, fmt.Errorf("Invalid prerequisite")
+		}
+
+		var coursesID []string
+		for _, val := range ps {
+			val.CourseID = html.EscapeString(helper.Trim(val.CourseID))
+			if helper.IsEmpty(val.CourseID) || len(val.CourseID) > cs.MaximumID {
+				return args, fmt.Errorf("Invalid prerequisite course")
+			}
+			if helper.IsStringInSlice(val.CourseID, coursesID) {
+				return args, fmt.Errorf("Duplicate prerequisite %s", val.CourseID)
+			}
+			if val.MinGrade < 0 || val.MinGrade > 100 {
+				return args, fmt.Errorf("Minimum grade must be between 0 and 100")
+			}
+			coursesID = append(coursesID, val.CourseID)
+			prerequisites = append(prerequisites, val)
+		}
+	}
+
+	return updatePrerequisiteArgs{
+		scheduleID:   scheduleID,
+		prerequisite: prerequisites,
+	}, nil
+}
+
+func (params updateRosterParams) validate() (updateRosterArgs, error) {
+	var args updateRosterArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, fmt.Errorf("Invalid schedule")
+	}
+
+	action := strings.ToLower(helper.Trim(params.action))
+	if action != rosterApprove && action != rosterReject && action != rosterRemove {
+		return args, fmt.Errorf("Action must be approve, reject or remove")
+	}
+
+	// the duplicated identity codes are changed once
+	var identityCodes []int64
+	for _, val := range strings.Split(params.userID, ",") {
+		val = helper.Trim(val)
+		if helper.IsEmpty(val) {
+			continue
+		}
+		identityCode, err := helper.NormalizeIdentity(val)
+		if err != nil {
+			return args, fmt.Errorf("Invalid user %s", val)
+		}
+		if helper.Int64InSlice(identityCode, identityCodes) {
+			continue
+		}
+		identityCodes = append(identityCodes, identityCode)
+	}
+	if len(identityCodes) < 1 {
+		return args, fmt.Errorf("User can't be empty")
+	}
+	if len(identityCodes) > rosterMax {
+		return args, fmt.Errorf("Can't change more than %d users at once", rosterMax)
+	}
+
+	return updateRosterArgs{
+		scheduleID:    scheduleID,
+		action:        action,
+		identityCodes: identityCodes,
+	}, nil
+}
+
+func (params importRosterParams) validate() (importRosterArgs, error) {
+	var args importRosterArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, fmt.Errorf("Invalid schedule")
+	}
+
+	return importRosterArgs{
+		scheduleID: scheduleID,
+	}, nil
+}
+
+func (params rosterFileParams) validate() ([]rosterRow, error) {
+	rows := []rosterRow{}
+	if len(params.rows) < 1 {
+		return rows, fmt.Errorf("Roster doesn't have any user")
+	}
+
+	// the roster has an id column, or only the identity codes without header
+	column, start := -1, 1
+	for i, v := range params.rows[0] {
+		name := strings.ToLower(helper.Trim(v))
+		if name == "id" || name == "identity" || name == "identity_code" {
+			column = i
+			break
+		}
+	}
+	if column < 0 {
+		if len(params.rows[0]) < 1 {
+			return rows, fmt.Errorf("Roster doesn't have id column")
+		}
+		if _, err := helper.NormalizeIdentity(helper.Trim(params.rows[0][0])); err != nil {
+			return rows, fmt.Errorf("Roster doesn't have id column")
+		}
+		column, start = 0, 0
+	}
+
+	if len(params.rows)-start < 1 {
+		return rows, fmt.Errorf("Roster doesn't have any user")
+	}
+	if len(params.rows)-start > rosterMax {
+		return rows, fmt.Errorf("Roster can't have more than %d users", rosterMax)
+	}
+
+	identities := map[int64]int{}
+	for i, v := range params.rows[start:] {
+		row := rosterRow{Row: i + start + 1}
+		var cell string
+		if column < len(v) {
+			cell = helper.Trim(v[column])
+		}
+
+		identityCode, err := helper.NormalizeIdentity(cell)
+		if err != nil {
+			row.Error = err.Error()
+		} else if dup, ok := identities[identityCode]; ok {
+			row.Error = fmt.Sprintf("%d is duplicated with row %d", identityCode, dup)
+		} else {
+			row.IdentityCode = identityCode
+			identities[identityCode] = row.Row
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (params cloneParams) validate() (cloneArgs, error) {
+	var args cloneArgs
+
+	scheduleID, err := strconv.ParseInt(params.scheduleID, 10, 64)
+	if err != nil {
+		return args, fmt.Errorf("Invalid schedule")
+	}
+
+	// the duplicated targets are cloned once
+	var targets []int64
+	for _, val := range strings.Split(params.target, ",") {
+		val = helper.Trim(val)
+		if helper.IsEmpty(val) {
+			continue
+		}
+		target, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return args, fmt.Errorf("Invalid target %s", val)
+		}
+		if target == scheduleID {
+			return args, fmt.Errorf("Target can't be the cloned schedule")
+		}
+		if helper.Int64InSlice(target, targets) {
+			continue
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) < 1 {
+		return args, fmt.Errorf("Target can't be empty")
+	}
+	if len(targets) > cloneTargetMax {
+		return args, fmt.Errorf("Can't clone more than %d targets at once", cloneTargetMax)
+	}
+
+	var offset int
+	params.offset = helper.Trim(params.offset)
+	if !helper.IsEmpty(params.offset) {
+		offset, err = strconv.Atoi(params.offset)
+		if err != nil {
+			return args, fmt.Errorf("Offset must be numeric")
+		}
+		if offset < -cloneOffsetMax || offset > cloneOffsetMax {
+			return args, fmt.Errorf("Offset must be between -%d and %d days", cloneOffsetMax, cloneOffsetMax)
+		}
+	}
+
+	preview := helper.Trim(params.preview)
+	if !helper.IsEmpty(preview) && preview != "true" && preview != "false" {
+		return args, fmt.Errorf("Preview should be true or false")
+	}
+
+	return cloneArgs{
+		scheduleID: scheduleID,
+		targets:    targets,
+		offset:     offset,
+		isPreview:  preview == "true",
+	}, nil
+}
@@ -582,3 +582,314 @@ func Test_createParams_validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_updateCapacityParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  updateCapacityParams
+		want    updateCapacityArgs
+		wantErr bool
+	}{
+		{
+			name:    "Invalid schedule",
+			params:  updateCapacityParams{scheduleID: "abc", capacity: "40"},
+			wantErr: true,
+		},
+		{
+			name:   "Empty capacity is unlimited",
+			params: updateCapacityParams{scheduleID: "100192", capacity: " "},
+			want:   updateCapacityArgs{scheduleID: 100192},
+		},
+		{
+			name:   "Correct capacity",
+			params: updateCapacityParams{scheduleID: "100192", capacity: "40"},
+			want:   updateCapacityArgs{scheduleID: 100192, capacity: sql.NullInt64{Valid: true, Int64: 40}},
+		},
+		{
+			name:    "Zero capacity",
+			params:  updateCapacityParams{scheduleID: "100192", capacity: "0"},
+			wantErr: true,
+		},
+		{
+			name:    "Overflow capacity",
+			params:  updateCapacityParams{scheduleID: "100192", capacity: "65536"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateCapacityParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updateCapacityParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_updatePrerequisiteParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  updatePrerequisiteParams
+		want    updatePrerequisiteArgs
+		wantErr bool
+	}{
+		{
+			name:   "Empty removes all",
+			params: updatePrerequisiteParams{scheduleID: "100192"},
+			want:   updatePrerequisiteArgs{scheduleID: 100192, prerequisite: []prerequisite{}},
+		},
+		{
+			name:   "Correct prerequisite",
+			params: updatePrerequisiteParams{scheduleID: "100192", prerequisite: `[{"course_id":" D10K-7D01 ","min_grade":60}]`},
+			want:   updatePrerequisiteArgs{scheduleID: 100192, prerequisite: []prerequisite{{CourseID: "D10K-7D01", MinGrade: 60}}},
+		},
+		{
+			name:    "Invalid json",
+			params:  updatePrerequisiteParams{scheduleID: "100192", prerequisite: `{"course_id":"D10K-7D01"}`},
+			wantErr: true,
+		},
+		{
+			name:    "Empty course",
+			params:  updatePrerequisiteParams{scheduleID: "100192", prerequisite: `[{"course_id":"","min_grade":60}]`},
+			wantErr: true,
+		},
+		{
+			name:    "Duplicate course",
+			params:  updatePrerequisiteParams{scheduleID: "100192", prerequisite: `[{"course_id":"D10K-7D01"},{"course_id":"D10K-7D01"}]`},
+			wantErr: true,
+		},
+		{
+			name:    "Minimum grade out of range",
+			params:  updatePrerequisiteParams{scheduleID: "100192", prerequisite: `[{"course_id":"D10K-7D01","min_grade":101}]`},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updatePrerequisiteParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updatePrerequisiteParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRosterParamsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  updateRosterParams
+		want    updateRosterArgs
+		wantErr bool
+	}{
+		{
+			name:   "Correct approval",
+			params: updateRosterParams{scheduleID: "100192", action: " Approve ", userID: "140810140016, 140810140060,140810140016,"},
+			want:   updateRosterArgs{scheduleID: 100192, action: rosterApprove, identityCodes: []int64{140810140016, 140810140060}},
+		},
+		{
+			name:   "Correct removal",
+			params: updateRosterParams{scheduleID: "100192", action: "remove", userID: "140810140060"},
+			want:   updateRosterArgs{scheduleID: 100192, action: rosterRemove, identityCodes: []int64{140810140060}},
+		},
+		{
+			name:    "Invalid schedule",
+			params:  updateRosterParams{scheduleID: "abc", action: "reject", userID: "140810140060"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid action",
+			params:  updateRosterParams{scheduleID: "100192", action: "delete", userID: "140810140060"},
+			wantErr: true,
+		},
+		{
+			name:    "Empty user",
+			params:  updateRosterParams{scheduleID: "100192", action: "reject", userID: " , "},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid user",
+			params:  updateRosterParams{scheduleID: "100192", action: "reject", userID: "140810140060,risal"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("updateRosterParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("updateRosterParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportRosterParamsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  importRosterParams
+		want    importRosterArgs
+		wantErr bool
+	}{
+		{
+			name:   "Valid schedule",
+			params: importRosterParams{scheduleID: "100192"},
+			want:   importRosterArgs{scheduleID: 100192},
+		},
+		{
+			name:    "Invalid schedule",
+			params:  importRosterParams{scheduleID: "abc"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("importRosterParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importRosterParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRosterFileParamsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  rosterFileParams
+		want    []rosterRow
+		wantErr bool
+	}{
+		{
+			name: "Roster with header",
+			params: rosterFileParams{rows: [][]string{
+				{"Name", "Identity_Code"},
+				{"Risal Falah", "140810140060"},
+				{"Khairil Azmi", "1408"},
+				{"Risal", "140810140060"},
+				{"Nobody"},
+			}},
+			want: []rosterRow{
+				{Row: 2, IdentityCode: 140810140060},
+				{Row: 3, Error: "invalid npm, nidn, nip, ktp, or sim number"},
+				{Row: 4, Error: "140810140060 is duplicated with row 2"},
+				{Row: 5, Error: "identity can't be empty"},
+			},
+		},
+		{
+			name:   "Roster without header",
+			params: rosterFileParams{rows: [][]string{{"140810140016"}, {" 140810140060 "}}},
+			want: []rosterRow{
+				{Row: 1, IdentityCode: 140810140016},
+				{Row: 2, IdentityCode: 140810140060},
+			},
+		},
+		{
+			name:    "Without id column",
+			params:  rosterFileParams{rows: [][]string{{"name"}, {"Risal Falah"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Header only",
+			params:  rosterFileParams{rows: [][]string{{"id"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Empty roster",
+			params:  rosterFileParams{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rosterFileParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rosterFileParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloneParamsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  cloneParams
+		want    cloneArgs
+		wantErr bool
+	}{
+		{
+			name:   "Correct preview",
+			params: cloneParams{scheduleID: "100192", target: "100193, 100194,100193,", offset: " -7 ", preview: "true"},
+			want:   cloneArgs{scheduleID: 100192, targets: []int64{100193, 100194}, offset: -7, isPreview: true},
+		},
+		{
+			name:   "Without offset",
+			params: cloneParams{scheduleID: "100192", target: "100193"},
+			want:   cloneArgs{scheduleID: 100192, targets: []int64{100193}},
+		},
+		{
+			name:    "Invalid schedule",
+			params:  cloneParams{scheduleID: "abc", target: "100193"},
+			wantErr: true,
+		},
+		{
+			name:    "Empty target",
+			params:  cloneParams{scheduleID: "100192", target: " , "},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid target",
+			params:  cloneParams{scheduleID: "100192", target: "100193,A"},
+			wantErr: true,
+		},
+		{
+			name:    "Target is the cloned schedule",
+			params:  cloneParams{scheduleID: "100192", target: "100193,100192"},
+			wantErr: true,
+		},
+		{
+			name:    "Too many targets",
+			params:  cloneParams{scheduleID: "100192", target: "1,2,3,4,5,6,7,8,9,10,11"},
+			wantErr: true,
+		},
+		{
+			name:    "Offset out of range",
+			params:  cloneParams{scheduleID: "100192", target: "100193", offset: "366"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid preview",
+			params:  cloneParams{scheduleID: "100192", target: "100193", preview: "yes"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("cloneParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cloneParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	tt "github.com/melodiez14/meiko/src/module/tutorial"
 	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -215,7 +216,8 @@ func UploadFileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 	case "assignment":
 		if args.role == "assistant" {
 			typ = fl.TypAssignment
-			isHasAccess = sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXCreate, rg.RoleCreate, rg.RoleXUpdate, rg.RoleUpdate)
+			isHasAccess = policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, 0) ||
+				policy.Can(sess, rg.RoleUpdate, rg.ModuleAssignment, 0)
 		} else if args.role == "student" {
 			// please verify file size
 			typ = fl.TypAssignmentUpload
@@ -228,7 +230,8 @@ func UploadFileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 	case "tutorial":
 		if args.role == "assistant" {
 			typ = fl.TypTutorial
-			isHasAccess = sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXCreate, rg.RoleCreate, rg.RoleXUpdate, rg.RoleUpdate)
+			isHasAccess = policy.Can(sess, rg.RoleCreate, rg.ModuleTutorial, 0) ||
+				policy.Can(sess, rg.RoleUpdate, rg.ModuleTutorial, 0)
 		}
 	}
 
@@ -296,7 +299,7 @@ func RouterFileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		}
 		switch args.role {
 		case "assistant":
-			if !sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXRead, rg.RoleRead) || !cs.IsAssistant(sess.ID, tutorial.ScheduleID) {
+			if !policy.Can(sess, rg.RoleRead, rg.ModuleTutorial, tutorial.ScheduleID) {
 				http.Redirect(w, r, fl.NotFoundURL, http.StatusSeeOther)
 				return
 			}
@@ -310,8 +313,8 @@ func RouterFileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		typ = fl.TypTutorial
 	case "assignment":
 		if args.role == "assistant" {
-			if sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXRead, rg.RoleRead) {
-				err = handleUserAssignment(sess.ID, args.id, w)
+			if policy.Can(sess, rg.RoleRead, rg.ModuleAssignment, 0) {
+				err = handleUserAssignment(sess, args.id, w)
 				if err != nil {
 					http.Redirect(w, r, fl.NotFoundURL, http.StatusSeeOther)
 				}
@@ -533,7 +536,7 @@ func IndexHandler(w http.ResponseWriter, r *http.Request) {
 // AvailableTypes ..
 func AvailableTypes(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleAssignment, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleAssignment, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -14,9 +14,12 @@ import (
 	asg "github.com/melodiez14/meiko/src/module/assignment"
 	cs "github.com/melodiez14/meiko/src/module/course"
 	fl "github.com/melodiez14/meiko/src/module/file"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
 	usr "github.com/melodiez14/meiko/src/module/user"
 	"github.com/melodiez14/meiko/src/util/alias"
+	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
 )
 
 func handleSingleWithMeta(payload, filename string, w http.ResponseWriter) error {
@@ -99,7 +102,7 @@ func handleSingleWithoutMeta(payload, filename string, w http.ResponseWriter) er
 	return nil
 }
 
-func handleUserAssignment(userID, assignmentID int64, w http.ResponseWriter) error {
+func handleUserAssignment(sess *auth.User, assignmentID int64, w http.ResponseWriter) error {
 
 	assignment, err := asg.GetByID(assignmentID)
 	if err != nil {
@@ -111,7 +114,7 @@ func handleUserAssignment(userID, assignmentID int64, w http.ResponseWriter) err
 		return err
 	}
 
-	if !cs.IsAssistant(userID, scheduleID) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleAssignment, scheduleID) {
 		return fmt.Errorf("You are not authorized")
 	}
 
@@ -12,6 +12,7 @@ import (
 	inf "github.com/melodiez14/meiko/src/module/information"
 	rg "github.com/melodiez14/meiko/src/module/rolegroup"
 	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/policy"
 )
 
 // GetHandler ...
@@ -122,7 +123,7 @@ func GetHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 // CreateHandler func ...
 func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleInformation, rg.RoleCreate, rg.RoleXCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleInformation, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -141,7 +142,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 	if args.ScheduleID != 0 {
-		if !cs.IsAssistant(sess.ID, args.ScheduleID) {
+		if !policy.Can(sess, rg.RoleCreate, rg.ModuleInformation, args.ScheduleID) {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("You do not have privilage for this course!"))
@@ -166,7 +167,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // UpdateHandler func ...
 func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleInformation, rg.RoleUpdate, rg.RoleXUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleInformation, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -187,7 +188,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 	if args.ScheduleID != 0 {
-		if !cs.IsAssistant(sess.ID, args.ScheduleID) {
+		if !policy.Can(sess, rg.RoleUpdate, rg.ModuleInformation, args.ScheduleID) {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("Schedule ID forbidden!"))
@@ -225,7 +226,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // AvailableCourseInformation func
 func AvailableCourseInformation(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleInformation, rg.RoleCreate, rg.RoleXCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleInformation, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -261,7 +262,7 @@ func AvailableCourseInformation(w http.ResponseWriter, r *http.Request, ps httpr
 // GetDetailByAdminHandler func ...
 func GetDetailByAdminHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleInformation, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleInformation, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -289,7 +290,7 @@ func GetDetailByAdminHandler(w http.ResponseWriter, r *http.Request, ps httprout
 	desc := "-"
 	courseName := ""
 	if id != 0 {
-		if !cs.IsAssistant(sess.ID, id) {
+		if !policy.Can(sess, rg.RoleRead, rg.ModuleInformation, id) {
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("You does not have permission"))
@@ -409,7 +410,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 // DeleteHandler func ...
 func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleInformation, rg.RoleDelete, rg.RoleXDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleInformation, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -433,7 +434,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 	scheduleID := inf.GetScheduleIDByID(args.ID)
-	if scheduleID != 0 && !cs.IsAssistant(sess.ID, scheduleID) {
+	if scheduleID != 0 && !policy.Can(sess, rg.RoleDelete, rg.ModuleInformation, scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusBadRequest).
 			AddError("You do not privilage for this informations"))
@@ -6,13 +6,15 @@ type getPrivilegeResponse struct {
 }
 
 type createParams struct {
-	name    string
-	modules string
+	name                string
+	modules             string
+	isTwoFactorRequired string
 }
 
 type createArgs struct {
-	name    string
-	modules map[string][]string
+	name                string
+	modules             map[string][]string
+	isTwoFactorRequired bool
 }
 
 type readParams struct {
@@ -47,9 +49,10 @@ type readDetailArgs struct {
 }
 
 type readDetailResponse struct {
-	ID      int64               `json:"id"`
-	Name    string              `json:"name"`
-	Modules map[string][]string `json:"modules"`
+	ID                  int64               `json:"id"`
+	Name                string              `json:"name"`
+	Modules             map[string][]string `json:"modules"`
+	IsTwoFactorRequired bool                `json:"is_2fa_required"`
 }
 
 type deleteParams struct {
@@ -61,15 +64,17 @@ type deleteArgs struct {
 }
 
 type updateParams struct {
-	id      string
-	name    string
-	modules string
+	id                  string
+	name                string
+	modules             string
+	isTwoFactorRequired string
 }
 
 type updateArgs struct {
-	id      int64
-	name    string
-	modules map[string][]string
+	id                  int64
+	name                string
+	modules             map[string][]string
+	isTwoFactorRequired bool
 }
 
 type searchParams struct {
@@ -5,11 +5,13 @@ import (
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
 	rg "github.com/melodiez14/meiko/src/module/rolegroup"
 	usr "github.com/melodiez14/meiko/src/module/user"
 	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/conn"
 	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -71,7 +73,7 @@ func GetPrivilege(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -79,8 +81,9 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	}
 
 	params := createParams{
-		name:    r.FormValue("name"),
-		modules: r.FormValue("modules"),
+		name:                r.FormValue("name"),
+		modules:             r.FormValue("modules"),
+		isTwoFactorRequired: r.FormValue("is_2fa_required"),
 	}
 
 	args, err := params.validate()
@@ -100,7 +103,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 
 	tx := conn.DB.MustBegin()
 
-	rolegroupID, err := rg.Insert(args.name, tx)
+	rolegroupID, err := rg.Insert(args.name, args.isTwoFactorRequired, tx)
 	if err != nil {
 		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
@@ -118,6 +121,15 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		}
 	}
 
+	err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, rolegroupID, nil,
+		auditValue(args.name, args.isTwoFactorRequired, args.modules), tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	tx.Commit()
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
@@ -129,7 +141,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -207,7 +219,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -247,9 +259,10 @@ func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 	}
 
 	resp := readDetailResponse{
-		ID:      role.ID,
-		Name:    role.Name,
-		Modules: moduleAccess,
+		ID:                  role.ID,
+		Name:                role.Name,
+		Modules:             moduleAccess,
+		IsTwoFactorRequired: role.IsTwoFactorRequired,
 	}
 
 	template.RenderJSONResponse(w, new(template.Response).
@@ -262,7 +275,7 @@ func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXDelete, rg.RoleDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -281,7 +294,8 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !rg.IsExist(args.id) {
+	role, err := rg.GetByID(args.id)
+	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusNoContent))
 		return
@@ -294,6 +308,13 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	modules, err := rg.SelectModuleAccess(args.id)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	tx := conn.DB.MustBegin()
 	err = rg.DeleteModuleAccess(args.id, tx)
 	if err != nil {
@@ -311,6 +332,15 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	err = audit.Insert(sess.ID, audit.ActionDelete, auditTable, args.id,
+		auditValue(role.Name, role.IsTwoFactorRequired, modules), nil, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	tx.Commit()
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
@@ -322,7 +352,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXUpdate, rg.RoleUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -330,9 +360,10 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	}
 
 	params := updateParams{
-		id:      ps.ByName("rolegroup_id"),
-		name:    r.FormValue("name"),
-		modules: r.FormValue("modules"),
+		id:                  ps.ByName("rolegroup_id"),
+		name:                r.FormValue("name"),
+		modules:             r.FormValue("modules"),
+		isTwoFactorRequired: r.FormValue("is_2fa_required"),
 	}
 
 	args, err := params.validate()
@@ -343,14 +374,22 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !rg.IsExist(args.id) {
+	role, err := rg.GetByID(args.id)
+	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusNoContent))
 		return
 	}
 
+	modules, err := rg.SelectModuleAccess(args.id)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	tx := conn.DB.MustBegin()
-	err = rg.Update(args.id, args.name, tx)
+	err = rg.Update(args.id, args.name, args.isTwoFactorRequired, tx)
 	if err != nil {
 		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
@@ -376,8 +415,26 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		}
 	}
 
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, args.id,
+		auditValue(role.Name, role.IsTwoFactorRequired, modules),
+		auditValue(args.name, args.isTwoFactorRequired, args.modules), tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	tx.Commit()
 
+	// signed in users of the rolegroup must not keep the old privileges
+	err = refreshSession(args.id)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
 		SetMessage("Rolegroup has been updated"))
@@ -387,7 +444,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // SearchHandler ..
 func SearchHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -425,13 +482,13 @@ func SearchHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // ListHandler ..
 func ListHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
 		return
 	}
-	res := [8]string{rg.ModuleUser, rg.ModuleCourse, rg.ModuleRole, rg.ModuleAttendance, rg.ModuleSchedule, rg.ModuleAssignment, rg.ModuleInformation, rg.ModuleTutorial}
+	res := [9]string{rg.ModuleUser, rg.ModuleCourse, rg.ModuleRole, rg.ModuleAttendance, rg.ModuleSchedule, rg.ModuleAssignment, rg.ModuleInformation, rg.ModuleTutorial, rg.ModuleAudit}
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
 		SetData(res))
@@ -441,7 +498,7 @@ func ListHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 // ListAbilitiesHandler ..
 func ListAbilitiesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleRole, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleRole, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -453,3 +510,29 @@ func ListAbilitiesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 		SetData(res))
 	return
 }
+
+// refreshSession replaces the roles on the sessions of all users of the rolegroup
+func refreshSession(rolegroupID int64) error {
+	usersID, err := usr.SelectIDByRolegroupID(rolegroupID)
+	if err != nil || len(usersID) < 1 {
+		return err
+	}
+
+	roles, err := rg.SelectModuleAccess(rolegroupID)
+	if err != nil {
+		return err
+	}
+
+	return auth.RefreshRoles(roles, usersID...)
+}
+
+const auditTable = "rolegroups"
+
+// auditValue returns the audited values of the rolegroup
+func auditValue(name string, isTwoFactorRequired bool, modules map[string][]string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            name,
+		"is_2fa_required": isTwoFactorRequired,
+		"modules":         modules,
+	}
+}
@@ -15,8 +15,9 @@ func (params createParams) validate() (createArgs, error) {
 
 	var args createArgs
 	params = createParams{
-		name:    html.EscapeString(params.name),
-		modules: params.modules,
+		name:                html.EscapeString(params.name),
+		modules:             params.modules,
+		isTwoFactorRequired: helper.Trim(params.isTwoFactorRequired),
 	}
 
 	if helper.IsEmpty(params.name) {
@@ -30,12 +31,17 @@ func (params createParams) validate() (createArgs, error) {
 	name := helper.Trim(params.name)
 	name = strings.Title(name)
 
+	isTwoFactorRequired, err := parseTwoFactorRequired(params.isTwoFactorRequired)
+	if err != nil {
+		return args, err
+	}
+
 	if helper.IsEmpty(params.modules) {
-		return createArgs{name: name, modules: map[string][]string{}}, nil
+		return createArgs{name: name, modules: map[string][]string{}, isTwoFactorRequired: isTwoFactorRequired}, nil
 	}
 
 	modules := map[string][]string{}
-	err := json.Unmarshal([]byte(params.modules), &modules)
+	err = json.Unmarshal([]byte(params.modules), &modules)
 	if err != nil {
 		return args, nil
 	}
@@ -54,7 +60,7 @@ func (params createParams) validate() (createArgs, error) {
 		}
 	}
 
-	return createArgs{name: name, modules: modules}, nil
+	return createArgs{name: name, modules: modules, isTwoFactorRequired: isTwoFactorRequired}, nil
 }
 
 func (params readParams) validate() (readArgs, error) {
@@ -107,9 +113,10 @@ func (params updateParams) validate() (updateArgs, error) {
 
 	var args updateArgs
 	params = updateParams{
-		id:      params.id,
-		name:    html.EscapeString(params.name),
-		modules: params.modules,
+		id:                  params.id,
+		name:                html.EscapeString(params.name),
+		modules:             params.modules,
+		isTwoFactorRequired: helper.Trim(params.isTwoFactorRequired),
 	}
 
 	if helper.IsEmpty(params.id) {
@@ -132,8 +139,13 @@ func (params updateParams) validate() (updateArgs, error) {
 	name := helper.Trim(params.name)
 	name = strings.Title(name)
 
+	isTwoFactorRequired, err := parseTwoFactorRequired(params.isTwoFactorRequired)
+	if err != nil {
+		return args, err
+	}
+
 	if helper.IsEmpty(params.modules) {
-		return updateArgs{id: id, name: name, modules: map[string][]string{}}, nil
+		return updateArgs{id: id, name: name, modules: map[string][]string{}, isTwoFactorRequired: isTwoFactorRequired}, nil
 	}
 
 	modules := map[string][]string{}
@@ -157,7 +169,7 @@ func (params updateParams) validate() (updateArgs, error) {
 		}
 	}
 
-	return updateArgs{id: id, name: name, modules: modules}, nil
+	return updateArgs{id: id, name: name, modules: modules, isTwoFactorRequired: isTwoFactorRequired}, nil
 }
 
 func (params searchParams) validate() (searchArgs, error) {
@@ -169,3 +181,14 @@ func (params searchParams) validate() (searchArgs, error) {
 		Text: text,
 	}, nil
 }
+
+// parseTwoFactorRequired accepts true, false or empty which means false
+func parseTwoFactorRequired(value string) (bool, error) {
+	switch value {
+	case "", "false":
+		return false, nil
+	case "true":
+		return true, nil
+	}
+	return false, fmt.Errorf("Invalid two factor authentication setting")
+}
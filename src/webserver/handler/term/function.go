@@ -0,0 +1,108 @@
+package term
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	tm "github.com/melodiez14/meiko/src/module/term"
+)
+
+func statusToString(status int8) string {
+	switch status {
+	case tm.StatusActive:
+		return "active"
+	case tm.StatusClosed:
+		return "closed"
+	default:
+		return "upcoming"
+	}
+}
+
+func newReadResponse(t tm.Term) readResponse {
+	return readResponse{
+		ID:                    t.ID,
+		Semester:              t.Semester,
+		Year:                  t.Year,
+		Status:                statusToString(t.Status),
+		StartDate:             t.StartDate.Format(dateLayout),
+		EndDate:               t.EndDate.Format(dateLayout),
+		RegistrationStartDate: t.RegistrationStartDate.Format(dateLayout),
+		RegistrationEndDate:   t.RegistrationEndDate.Format(dateLayout),
+		GradingStartDate:      t.GradingStartDate.Format(dateLayout),
+		GradingEndDate:        t.GradingEndDate.Format(dateLayout),
+	}
+}
+
+// handleClone copies the schedules into the target term with their capacity, grade parameters and assistants.
+// The students are not copied, a schedule whose course and class already exist in the target is skipped
+func handleClone(userID int64, schedules []cs.Schedule, target tm.Term, tx *sqlx.Tx) (rolloverResponse, error) {
+	resp := rolloverResponse{
+		Cloned:  []int64{},
+		Skipped: []string{},
+	}
+
+	for _, val := range schedules {
+		if cs.IsExistSchedule(target.Semester, target.Year, val.CourseID, val.Class) {
+			resp.Skipped = append(resp.Skipped, val.CourseID+" "+val.Class)
+			continue
+		}
+
+		capacity, err := cs.GetCapacity(val.ID, tx)
+		if err != nil {
+			return resp, err
+		}
+		gps, err := cs.SelectGPBySchedule([]int64{val.ID}, tx)
+		if err != nil {
+			return resp, err
+		}
+		assistantsID, err := cs.SelectAssistantID(val.ID, tx)
+		if err != nil {
+			return resp, err
+		}
+
+		scheduleID, err := cs.InsertSchedule(userID,
+			int16(val.StartTime),
+			int16(val.EndTime),
+			target.Year,
+			target.Semester,
+			val.Day,
+			cs.StatusScheduleActive,
+			val.Class,
+			val.CourseID,
+			val.PlaceID,
+			tx)
+		if err != nil {
+			return resp, err
+		}
+
+		err = cs.UpdateScheduleTerm(scheduleID, sql.NullInt64{Int64: target.ID, Valid: true}, tx)
+		if err != nil {
+			return resp, err
+		}
+
+		if capacity.Valid {
+			err = cs.UpdateCapacity(scheduleID, capacity, tx)
+			if err != nil {
+				return resp, err
+			}
+		}
+
+		for _, gp := range gps {
+			err = cs.InsertGradeParameter(gp.Type, gp.Percentage, scheduleID, tx)
+			if err != nil {
+				return resp, err
+			}
+		}
+
+		if len(assistantsID) > 0 {
+			err = cs.InsertAssistant(assistantsID, scheduleID, tx)
+			if err != nil {
+				return resp, err
+			}
+		}
+
+		resp.Cloned = append(resp.Cloned, scheduleID)
+	}
+	return resp, nil
+}
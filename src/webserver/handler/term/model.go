@@ -0,0 +1,64 @@
+package term
+
+import (
+	tm "github.com/melodiez14/meiko/src/module/term"
+)
+
+const (
+	auditTable = "terms"
+	dateLayout = "2006-01-02"
+)
+
+type createParams struct {
+	semester              string
+	year                  string
+	startDate             string
+	endDate               string
+	registrationStartDate string
+	registrationEndDate   string
+	gradingStartDate      string
+	gradingEndDate        string
+}
+
+type createArgs struct {
+	term tm.Term
+}
+
+type updateParams struct {
+	id string
+	createParams
+}
+
+type updateArgs struct {
+	term tm.Term
+}
+
+type rolloverParams struct {
+	id     string
+	target string
+	clone  string
+}
+
+type rolloverArgs struct {
+	id     int64
+	target int64
+	clone  bool
+}
+
+type readResponse struct {
+	ID                    int64  `json:"id"`
+	Semester              int8   `json:"semester"`
+	Year                  int16  `json:"year"`
+	Status                string `json:"status"`
+	StartDate             string `json:"start_date"`
+	EndDate               string `json:"end_date"`
+	RegistrationStartDate string `json:"registration_start_date"`
+	RegistrationEndDate   string `json:"registration_end_date"`
+	GradingStartDate      string `json:"grading_start_date"`
+	GradingEndDate        string `json:"grading_end_date"`
+}
+
+type rolloverResponse struct {
+	Cloned  []int64  `json:"cloned"`
+	Skipped []string `json:"skipped"`
+}
@@ -0,0 +1,390 @@
+package term
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	tm "github.com/melodiez14/meiko/src/module/term"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// ReadHandler handles the http request for listing the academic terms, the latest is the first
+/*
+	@params:
+	@example:
+	@return
+		[]{id, semester, year, status, start_date, end_date, registration_start_date, ...}
+*/
+func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	terms, err := tm.SelectAll()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := []readResponse{}
+	for _, val := range terms {
+		res = append(res, newReadResponse(val))
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// CreateHandler handles the http request for creating an upcoming academic term.
+// The existing schedules of the semester and the year are attached to it. Accessing this handler needs XCREATE schedule ability
+/*
+	@params:
+		semester				= required, positive numeric
+		year					= required, positive numeric
+		start_date				= required, YYYY-MM-DD
+		end_date				= required, YYYY-MM-DD
+		registration_start_date	= required, YYYY-MM-DD
+		registration_end_date	= required, YYYY-MM-DD
+		grading_start_date		= required, YYYY-MM-DD
+		grading_end_date		= required, YYYY-MM-DD
+	@example:
+		semester				= 1
+		year					= 2018
+		start_date				= 2018-08-27
+		end_date				= 2018-12-21
+		registration_start_date	= 2018-08-13
+		registration_end_date	= 2018-09-07
+		grading_start_date		= 2018-12-03
+		grading_end_date		= 2019-01-11
+	@return
+		id
+*/
+func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXCreate, rg.ModuleSchedule, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := createParams{
+		semester:              r.FormValue("semester"),
+		year:                  r.FormValue("year"),
+		startDate:             r.FormValue("start_date"),
+		endDate:               r.FormValue("end_date"),
+		registrationStartDate: r.FormValue("registration_start_date"),
+		registrationEndDate:   r.FormValue("registration_end_date"),
+		gradingStartDate:      r.FormValue("grading_start_date"),
+		gradingEndDate:        r.FormValue("grading_end_date"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	if tm.IsExist(args.term.Semester, args.term.Year) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusConflict).
+			AddError("Term already exists"))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	id, err := tm.Insert(args.term, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	args.term.ID = id
+
+	err = cs.AttachTerm(id, args.term.Semester, args.term.Year, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, id, nil, newReadResponse(args.term), tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(id))
+	return
+}
+
+// UpdateHandler handles the http request for changing the dates of the academic term.
+// Accessing this handler needs XUPDATE schedule ability
+/*
+	@params:
+		start_date				= required, YYYY-MM-DD
+		end_date				= required, YYYY-MM-DD
+		registration_start_date	= required, YYYY-MM-DD
+		registration_end_date	= required, YYYY-MM-DD
+		grading_start_date		= required, YYYY-MM-DD
+		grading_end_date		= required, YYYY-MM-DD
+	@example:
+		start_date				= 2018-08-27
+		end_date				= 2018-12-21
+		registration_start_date	= 2018-08-13
+		registration_end_date	= 2018-09-14
+		grading_start_date		= 2018-12-03
+		grading_end_date		= 2019-01-11
+	@return
+*/
+func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXUpdate, rg.ModuleSchedule, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := updateParams{
+		id: ps.ByName("id"),
+		createParams: createParams{
+			startDate:             r.FormValue("start_date"),
+			endDate:               r.FormValue("end_date"),
+			registrationStartDate: r.FormValue("registration_start_date"),
+			registrationEndDate:   r.FormValue("registration_end_date"),
+			gradingStartDate:      r.FormValue("grading_start_date"),
+			gradingEndDate:        r.FormValue("grading_end_date"),
+		},
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	before, err := tm.Get(args.term.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Term does not exist"))
+		return
+	}
+
+	if before.Status == tm.StatusClosed {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Term has been closed"))
+		return
+	}
+
+	after := args.term
+	after.Semester = before.Semester
+	after.Year = before.Year
+	after.Status = before.Status
+
+	if newReadResponse(before) == newReadResponse(after) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetMessage("Success"))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = tm.Update(after, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, before.ID,
+		newReadResponse(before), newReadResponse(after), tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Success"))
+	return
+}
+
+// RolloverHandler handles the http request for moving from the term to the target term. The schedules of the term
+// are deactivated and it is closed, then the target term and its schedules become active. The schedules can be cloned
+// into the target term with their capacity, grade parameters and assistants. Accessing this handler needs XUPDATE schedule ability
+/*
+	@params:
+		target	= required, id of the target term
+		clone	= optional, true
+	@example:
+		target	= 2
+		clone	= true
+	@return
+		cloned	= [100193, 100194]
+		skipped	= [D10K-7D02 A]
+*/
+func RolloverHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXUpdate, rg.ModuleSchedule, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := rolloverParams{
+		id:     ps.ByName("id"),
+		target: r.FormValue("target"),
+		clone:  r.FormValue("clone"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	term, err := tm.Get(args.id)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Term does not exist"))
+		return
+	}
+
+	target, err := tm.Get(args.target)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Target term does not exist"))
+		return
+	}
+
+	if term.Status == tm.StatusClosed || target.Status == tm.StatusClosed {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Term has been closed"))
+		return
+	}
+
+	schedules, err := cs.SelectScheduleByTerm(term.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = cs.UpdateStatusByTerm(term.ID, cs.StatusScheduleInactive, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tm.UpdateStatus(term.ID, tm.StatusClosed, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	// the schedules of the target which were created before the rollover become active too
+	err = cs.UpdateStatusByTerm(target.ID, cs.StatusScheduleActive, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	if target.Status != tm.StatusActive {
+		err = tm.UpdateStatus(target.ID, tm.StatusActive, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	res := rolloverResponse{
+		Cloned:  []int64{},
+		Skipped: []string{},
+	}
+	if args.clone {
+		res, err = handleClone(sess.ID, schedules, target, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, term.ID,
+		map[string]interface{}{"status": statusToString(term.Status), "target_status": statusToString(target.Status)},
+		map[string]interface{}{"status": statusToString(tm.StatusClosed), "target_status": statusToString(tm.StatusActive),
+			"target_id": target.ID, "cloned_schedules_id": res.Cloned}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
@@ -0,0 +1,130 @@
+package term
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tm "github.com/melodiez14/meiko/src/module/term"
+	"github.com/melodiez14/meiko/src/util/helper"
+)
+
+func (params createParams) validate() (createArgs, error) {
+	var args createArgs
+
+	// Semester validation
+	if helper.IsEmpty(params.semester) {
+		return args, fmt.Errorf("Semester can't be empty")
+	}
+	semester, err := strconv.ParseInt(params.semester, 10, 16)
+	if err != nil {
+		return args, fmt.Errorf("Semester must be numeric")
+	}
+	if semester < 1 || semester > 7 {
+		return args, fmt.Errorf("Invalid semester")
+	}
+
+	// Year validation
+	if helper.IsEmpty(params.year) {
+		return args, fmt.Errorf("Year can't be empty")
+	}
+	year, err := strconv.ParseInt(params.year, 10, 16)
+	if err != nil {
+		return args, fmt.Errorf("Year must be numeric")
+	}
+	if year < 2017 || year > int64(time.Now().Year()+1) {
+		return args, fmt.Errorf("Invalid year")
+	}
+
+	t, err := params.dates()
+	if err != nil {
+		return args, err
+	}
+	t.Semester = int8(semester)
+	t.Year = int16(year)
+
+	return createArgs{
+		term: t,
+	}, nil
+}
+
+func (params updateParams) validate() (updateArgs, error) {
+	var args updateArgs
+
+	id, err := strconv.ParseInt(params.id, 10, 64)
+	if err != nil {
+		return args, fmt.Errorf("Invalid term")
+	}
+
+	t, err := params.dates()
+	if err != nil {
+		return args, err
+	}
+	t.ID = id
+
+	return updateArgs{
+		term: t,
+	}, nil
+}
+
+// dates parses the dates of the term, the registration and the grading have to be inside the term
+func (params createParams) dates() (tm.Term, error) {
+	var t tm.Term
+	dates := []struct {
+		name  string
+		value string
+		dest  *time.Time
+	}{
+		{"Start date", params.startDate, &t.StartDate},
+		{"End date", params.endDate, &t.EndDate},
+		{"Registration start date", params.registrationStartDate, &t.RegistrationStartDate},
+		{"Registration end date", params.registrationEndDate, &t.RegistrationEndDate},
+		{"Grading start date", params.gradingStartDate, &t.GradingStartDate},
+		{"Grading end date", params.gradingEndDate, &t.GradingEndDate},
+	}
+	for _, val := range dates {
+		value := helper.Trim(val.value)
+		if helper.IsEmpty(value) {
+			return t, fmt.Errorf("%s can't be empty", val.name)
+		}
+		d, err := time.ParseInLocation(dateLayout, value, time.Local)
+		if err != nil {
+			return t, fmt.Errorf("%s must be formatted as YYYY-MM-DD", val.name)
+		}
+		*val.dest = d
+	}
+
+	if t.EndDate.Before(t.StartDate) {
+		return t, fmt.Errorf("End date must not be before start date")
+	}
+	if t.RegistrationEndDate.Before(t.RegistrationStartDate) || t.RegistrationEndDate.After(t.EndDate) {
+		return t, fmt.Errorf("Registration must end after it starts and before the term ends")
+	}
+	if t.GradingEndDate.Before(t.GradingStartDate) || t.GradingStartDate.Before(t.StartDate) {
+		return t, fmt.Errorf("Grading must end after it starts and not start before the term")
+	}
+	return t, nil
+}
+
+func (params rolloverParams) validate() (rolloverArgs, error) {
+	var args rolloverArgs
+
+	id, err := strconv.ParseInt(params.id, 10, 64)
+	if err != nil {
+		return args, fmt.Errorf("Invalid term")
+	}
+
+	target, err := strconv.ParseInt(params.target, 10, 64)
+	if err != nil {
+		return args, fmt.Errorf("Invalid target term")
+	}
+	if id == target {
+		return args, fmt.Errorf("Target term must be another term")
+	}
+
+	return rolloverArgs{
+		id:     id,
+		target: target,
+		clone:  params.clone == "true",
+	}, nil
+}
@@ -0,0 +1,78 @@
+package term
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_createParams_validate(t *testing.T) {
+	valid := createParams{
+		semester:              "1",
+		year:                  "2018",
+		startDate:             "2018-08-27",
+		endDate:               "2018-12-21",
+		registrationStartDate: "2018-08-13",
+		registrationEndDate:   "2018-09-07",
+		gradingStartDate:      "2018-12-03",
+		gradingEndDate:        "2019-01-11",
+	}
+	tests := []struct {
+		name    string
+		modify  func(p *createParams)
+		wantErr bool
+	}{
+		{name: "Correct term", modify: func(p *createParams) {}},
+		{name: "Empty semester", modify: func(p *createParams) { p.semester = "" }, wantErr: true},
+		{name: "Invalid year", modify: func(p *createParams) { p.year = "2016" }, wantErr: true},
+		{name: "Invalid date", modify: func(p *createParams) { p.startDate = "27-08-2018" }, wantErr: true},
+		{name: "Term ends before it starts", modify: func(p *createParams) { p.endDate = "2018-08-26" }, wantErr: true},
+		{name: "Registration ends after the term", modify: func(p *createParams) { p.registrationEndDate = "2018-12-22" }, wantErr: true},
+		{name: "Grading starts before the term", modify: func(p *createParams) { p.gradingStartDate = "2018-08-26" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := valid
+			tt.modify(&params)
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("createParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.term.Semester != 1 || got.term.Year != 2018 || got.term.EndDate.Format(dateLayout) != "2018-12-21" {
+				t.Errorf("createParams.validate() = %v", got.term)
+			}
+			if got.term.StartDate.Location() != time.Local {
+				t.Errorf("createParams.validate() location = %v, want Local", got.term.StartDate.Location())
+			}
+		})
+	}
+}
+
+func Test_rolloverParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  rolloverParams
+		want    rolloverArgs
+		wantErr bool
+	}{
+		{name: "Correct", params: rolloverParams{id: "1", target: "2", clone: "true"}, want: rolloverArgs{id: 1, target: 2, clone: true}},
+		{name: "Without clone", params: rolloverParams{id: "1", target: "2"}, want: rolloverArgs{id: 1, target: 2}},
+		{name: "Same term", params: rolloverParams{id: "1", target: "1"}, wantErr: true},
+		{name: "Invalid target", params: rolloverParams{id: "1", target: "a"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rolloverParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("rolloverParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
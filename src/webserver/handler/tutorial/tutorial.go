@@ -14,6 +14,7 @@ import (
 	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/conn"
 	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -47,8 +48,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	isHasAccess := false
 	switch args.payload {
 	case "assistant":
-		isHasAccess = cs.IsAssistant(sess.ID, args.scheduleID) &&
-			sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXRead, rg.RoleRead)
+		isHasAccess = policy.Can(sess, rg.RoleRead, rg.ModuleTutorial, args.scheduleID)
 	case "student":
 		isHasAccess = cs.IsEnrolled(sess.ID, args.scheduleID)
 	}
@@ -126,7 +126,7 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXRead, rg.RoleRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleTutorial, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -157,7 +157,7 @@ func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, tutorial.ScheduleID) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleTutorial, tutorial.ScheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("Invalid request"))
@@ -182,7 +182,7 @@ func ReadDetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXCreate, rg.RoleCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleTutorial, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -204,7 +204,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, args.scheduleID) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleTutorial, args.scheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You are not authorized"))
@@ -255,7 +255,7 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXDelete, rg.RoleDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleTutorial, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -282,7 +282,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, tutorial.ScheduleID) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleTutorial, tutorial.ScheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("Invalid Request"))
@@ -320,7 +320,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleTutorial, rg.RoleXDelete, rg.RoleDelete) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleTutorial, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -358,7 +358,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	if !cs.IsAssistant(sess.ID, tutorial.ScheduleID) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleTutorial, tutorial.ScheduleID) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("Invalid Request"))
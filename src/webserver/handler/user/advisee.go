@@ -0,0 +1,45 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// ReadAdviseeHandler handles the http request for listing the students advised by the user
+/*
+	@params:
+	@example:
+	@return
+		[]{id, name, email, program, cohort}
+*/
+func ReadAdviseeHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	advisees, err := user.SelectByAdvisorID(sess.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := []adviseeResponse{}
+	for _, val := range advisees {
+		res = append(res, adviseeResponse{
+			IdentityCode: val.IdentityCode,
+			Name:         val.Name,
+			Email:        val.Email,
+			Program:      val.Program.String,
+			Cohort:       uint16(val.Cohort.Int64),
+		})
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
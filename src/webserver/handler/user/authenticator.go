@@ -0,0 +1,164 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/ldap"
+)
+
+// errInvalidCredentials is returned by the authenticator which doesn't know the email or the password
+var errInvalidCredentials = fmt.Errorf("Invalid email or password")
+
+// the directory is reached through these variables, so the sign in can be tested without a directory server
+var (
+	ldapIsEnabled    = ldap.IsEnabled
+	ldapAuthenticate = ldap.Authenticate
+)
+
+// authenticator is a backend which checks the email and the password of the sign in
+// and returns the local user, errInvalidCredentials lets the next backend try
+type authenticator interface {
+	authenticate(args signInArgs) (user.User, error)
+}
+
+// authenticators returns the enabled backends in the order they are tried
+func authenticators() []authenticator {
+	backends := []authenticator{localAuthenticator{}}
+	if ldapIsEnabled() {
+		backends = append(backends, ldapAuthenticator{})
+	}
+	return backends
+}
+
+// authenticate tries every backend until one of them accepts the credentials
+func authenticate(args signInArgs) (user.User, error) {
+	var u user.User
+	err := errInvalidCredentials
+	for _, backend := range authenticators() {
+		u, err = backend.authenticate(args)
+		if err != errInvalidCredentials {
+			return u, err
+		}
+	}
+	return u, err
+}
+
+// localAuthenticator checks the hashed password against the one which is stored in the database
+type localAuthenticator struct{}
+
+func (localAuthenticator) authenticate(args signInArgs) (user.User, error) {
+	u, err := user.SignIn(args.Email, args.Password)
+	if err == sql.ErrNoRows {
+		return u, errInvalidCredentials
+	}
+	return u, err
+}
+
+// ldapAuthenticator binds to the directory as the user. The user is created from
+// the directory attributes on the first sign in and the rolegroup follows the directory groups
+type ldapAuthenticator struct{}
+
+func (ldapAuthenticator) authenticate(args signInArgs) (user.User, error) {
+	email := args.Email
+	entry, err := ldapAuthenticate(email, args.RawPassword)
+	if err != nil {
+		// the sign in is rejected like a wrong password while the directory is unreachable
+		if err != ldap.ErrInvalidCredentials {
+			log.Printf("LDAP authentication failed: %s", err.Error())
+		}
+		return user.User{}, errInvalidCredentials
+	}
+
+	if len(entry.Email) > 0 {
+		email, err = helper.NormalizeEmail(entry.Email)
+		if err != nil {
+			return user.User{}, err
+		}
+	}
+
+	rolegroupID := sql.NullInt64{}
+	rolegroupID.Int64, rolegroupID.Valid = ldap.Rolegroup(entry)
+	if rolegroupID.Valid && !rg.IsExist(rolegroupID.Int64) {
+		rolegroupID.Valid = false
+	}
+
+	u, err := user.GetByEmail(email)
+	if err == sql.ErrNoRows {
+		return provisionLDAP(entry, email, rolegroupID)
+	}
+	if err != nil {
+		return u, err
+	}
+
+	// the user without the mapped group keeps the rolegroup given by the admin
+	if !rolegroupID.Valid || (u.RoleGroupsID.Valid && u.RoleGroupsID.Int64 == rolegroupID.Int64) {
+		return u, nil
+	}
+
+	err = user.UpdateRolegroup(u.IdentityCode, rolegroupID)
+	if err != nil {
+		return u, err
+	}
+	u.RoleGroupsID = rolegroupID
+
+	roles, err := rg.SelectModuleAccess(rolegroupID.Int64)
+	if err != nil {
+		return u, err
+	}
+	return u, auth.RefreshRoles(roles, u.ID)
+}
+
+// provisionLDAP creates the activated user from the directory attributes, the user has no local password
+func provisionLDAP(entry ldap.Entry, email string, rolegroupID sql.NullInt64) (user.User, error) {
+	var u user.User
+
+	identityCode, err := helper.NormalizeIdentity(entry.IdentityCode)
+	if err != nil {
+		return u, err
+	}
+
+	name, err := helper.NormalizeName(entry.Name)
+	if err != nil {
+		return u, err
+	}
+
+	if user.IsUserExist(identityCode) {
+		return u, fmt.Errorf("%d has been registered", identityCode)
+	}
+
+	tx := conn.DB.MustBegin()
+	id, err := user.Create(identityCode, name, email, tx)
+	if err != nil {
+		tx.Rollback()
+		return u, err
+	}
+
+	if rolegroupID.Valid {
+		err = user.UpdateRolegroup(identityCode, rolegroupID, tx)
+		if err != nil {
+			tx.Rollback()
+			return u, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return u, err
+	}
+
+	return user.User{
+		ID:           id,
+		Name:         name,
+		Email:        email,
+		IdentityCode: identityCode,
+		Status:       user.StatusActivated,
+		RoleGroupsID: rolegroupID,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/ldap"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestAuthenticate(t *testing.T) {
+	querySignIn := `^\s*SELECT.*password\s*FROM\s*users\s*WHERE\s*email\s*=\s*\(\?\)\s*LIMIT\s*1;$`
+	queryGetByEmail := `^\s*SELECT.*FROM\s*users\s*WHERE\s*email\s*=\s*\(\?\)\s*LIMIT\s*1;$`
+
+	params := signInParams{Email: "risal@live.com", Password: "Qwerty<123>"}
+	args, err := params.validate()
+	if err != nil {
+		t.Fatalf("signInParams.validate() error = %v", err)
+	}
+
+	var directoryPassword string
+	ldapIsEnabled = func() bool { return true }
+	ldapAuthenticate = func(login, password string) (ldap.Entry, error) {
+		directoryPassword = password
+		if password != "Qwerty<123>" {
+			return ldap.Entry{}, ldap.ErrInvalidCredentials
+		}
+		return ldap.Entry{Email: login}, nil
+	}
+	defer func() {
+		ldapIsEnabled = ldap.IsEnabled
+		ldapAuthenticate = ldap.Authenticate
+	}()
+
+	// the local user signs in with the hashed password without reaching the directory
+	hash, _ := helper.HashPassword(args.Password)
+	db, _ := conn.InitDBMock()
+	db.ExpectQuery(querySignIn).
+		WithArgs("risal@live.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password"}).AddRow(1, "risal@live.com", hash))
+
+	u, err := authenticate(args)
+	if err != nil || u.ID != 1 {
+		t.Fatalf("authenticate() local = %v, %v", u, err)
+	}
+	if len(directoryPassword) > 0 {
+		t.Errorf("authenticate() local should not reach the directory")
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("authenticate() local %s", err.Error())
+	}
+
+	// the user without the local password falls back to the directory with the password as it is typed
+	db, _ = conn.InitDBMock()
+	db.ExpectQuery(querySignIn).
+		WithArgs("risal@live.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password"}))
+	db.ExpectQuery(queryGetByEmail).
+		WithArgs("risal@live.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(2, "risal@live.com"))
+
+	u, err = authenticate(args)
+	if err != nil || u.ID != 2 {
+		t.Fatalf("authenticate() directory = %v, %v", u, err)
+	}
+	if directoryPassword != "Qwerty<123>" {
+		t.Errorf("authenticate() directory password = %q, want the raw password", directoryPassword)
+	}
+	if err := db.ExpectationsWereMet(); err != nil {
+		t.Errorf("authenticate() directory %s", err.Error())
+	}
+
+	// the wrong password is rejected by both of them
+	args.Password = helper.StringToMD5("Wrong123")
+	args.RawPassword = "Wrong123"
+	db, _ = conn.InitDBMock()
+	db.ExpectQuery(querySignIn).
+		WithArgs("risal@live.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "password"}).AddRow(1, "risal@live.com", hash))
+
+	_, err = authenticate(args)
+	if err != errInvalidCredentials {
+		t.Errorf("authenticate() wrong password error = %v, want %v", err, errInvalidCredentials)
+	}
+	if directoryPassword != "Wrong123" {
+		t.Errorf("authenticate() directory password = %q, want the raw password", directoryPassword)
+	}
+}
@@ -0,0 +1,179 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/email"
+	"github.com/melodiez14/meiko/src/module/audit"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// RequestEmailChangeHandler handles the http request for changing the email of user.
+// The code is sent to the new email, the email is changed only after the code is confirmed
+/*
+	@params:
+		email		= required, email format, 0<characters<45
+		password	= required, the current password
+	@example:
+		email=risal.falah@gmail.com
+		password=Qwerty123
+	@return
+*/
+func RequestEmailChangeHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := emailChangeParams{
+		Email:    r.FormValue("email"),
+		Password: r.FormValue("password"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	if args.Email == sess.Email {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("The new email is the same as the current email"))
+		return
+	}
+
+	if !isAttemptAllowed(w, r, auth.ScopeSignIn, sess.Email) {
+		return
+	}
+
+	_, err = user.SignIn(sess.Email, args.Password)
+	if err != nil {
+		auth.FailAttempt(auth.ScopeSignIn, r, sess.Email)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("Invalid password"))
+		return
+	}
+
+	_, err = user.GetByEmail(args.Email, user.ColID)
+	if err != sql.ErrNoRows {
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(fmt.Sprintf("%s has been registered", args.Email)))
+		return
+	}
+
+	verification, err := user.GenerateEmailChange(sess.ID, args.Email)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	go email.SendEmailChange(sess.Name, args.Email, verification.Code)
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage(fmt.Sprintf("The confirmation code has been sent to %s", args.Email)))
+	return
+}
+
+// ConfirmEmailChangeHandler handles the http request for confirming the change of email.
+// The old email is notified and every session of the user is signed out
+/*
+	@params:
+		code	= required, numeric, characters=4
+	@example:
+		code=1408
+	@return
+*/
+func ConfirmEmailChangeHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := confirmEmailChangeParams{
+		Code: r.FormValue("code"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	change, err := user.GetEmailChange(sess.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("There is no email change or it has expired"))
+		return
+	}
+
+	if !user.IsValidEmailChangeCode(change, args.Code) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid code"))
+		return
+	}
+
+	// the new email may have been registered since the change was requested
+	_, err = user.GetByEmail(change.Email.String, user.ColID)
+	if err != sql.ErrNoRows {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(fmt.Sprintf("%s has been registered", change.Email.String)))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = user.UpdateEmail(sess.ID, change.Email.String, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, sess.ID,
+		map[string]string{"email": sess.Email},
+		map[string]string{"email": change.Email.String}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	// the sessions carry the old email, so the user signs in again using the new email
+	cookie, err := sess.DestroySession(r)
+	if err == nil {
+		http.SetCookie(w, cookie)
+	}
+	auth.DestroyAllSession(sess.ID)
+
+	go email.SendEmailChanged(sess.Name, sess.Email, change.Email.String)
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Email has been changed, please sign in using the new email"))
+	return
+}
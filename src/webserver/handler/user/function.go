@@ -1,14 +1,60 @@
 package user
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"html"
 	"net/http"
 
 	"github.com/julienschmidt/httprouter"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
 	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/alias"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/helper"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
+const auditTable = "users"
+
+// auditValue returns the audited columns of the user
+func auditValue(u user.User) map[string]interface{} {
+	value := map[string]interface{}{
+		"identity_code": u.IdentityCode,
+		"name":          u.Name,
+		"email":         u.Email,
+		"gender":        u.Gender,
+		"note":          u.Note,
+		"status":        u.Status,
+		"phone":         nil,
+		"line_id":       nil,
+		"rolegroups_id": nil,
+		"program":       nil,
+		"cohort":        nil,
+		"advisor_id":    nil,
+	}
+	if u.Phone.Valid {
+		value["phone"] = u.Phone.String
+	}
+	if u.LineID.Valid {
+		value["line_id"] = u.LineID.String
+	}
+	if u.RoleGroupsID.Valid {
+		value["rolegroups_id"] = u.RoleGroupsID.Int64
+	}
+	if u.Program.Valid {
+		value["program"] = u.Program.String
+	}
+	if u.Cohort.Valid {
+		value["cohort"] = u.Cohort.Int64
+	}
+	if u.AdvisorID.Valid {
+		value["advisor_id"] = u.AdvisorID.Int64
+	}
+	return value
+}
+
 func search(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	search := html.EscapeString(r.FormValue("q"))
@@ -33,3 +79,203 @@ func search(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		SetData(response))
 	return
 }
+
+// isAttemptAllowed responds 429 with Retry-After header when the email or the IP address
+// is still waiting for the backoff or locked out
+func isAttemptAllowed(w http.ResponseWriter, r *http.Request, scope, email string) bool {
+	wait, err := auth.CheckAttempt(scope, r, email)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return false
+	}
+	if wait <= 0 {
+		return true
+	}
+
+	w.Header().Set("Retry-After", fmt.Sprintf("%.0f", wait.Seconds()))
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusTooManyRequests).
+		AddError(fmt.Sprintf("Too many attempts, try again in %.0f seconds", wait.Seconds())))
+	return false
+}
+
+// signIn creates a new session of the user who has passed every authentication step
+// and sets the session and the rotated csrf cookie
+func signIn(w http.ResponseWriter, r *http.Request, u user.User) (signInResponse, error) {
+	var res signInResponse
+
+	// read before the roles, a role change made meanwhile makes the new session stale
+	version, err := auth.SessionVersion(u.ID)
+	if err != nil {
+		return res, err
+	}
+
+	roles := make(map[string][]string)
+	if u.RoleGroupsID.Valid {
+		roles, err = rg.SelectModuleAccess(u.RoleGroupsID.Int64)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	sess := &auth.User{
+		ID:            u.ID,
+		Name:          u.Name,
+		Email:         u.Email,
+		Gender:        u.Gender,
+		Note:          u.Note,
+		Status:        u.Status,
+		IdentityCode:  u.IdentityCode,
+		LineID:        u.LineID.String,
+		Phone:         u.Phone.String,
+		PhoneVerified: u.PhoneVerified,
+		Program:       u.Program.String,
+		Cohort:        uint16(u.Cohort.Int64),
+		AdvisorID:     u.AdvisorID.Int64,
+		Roles:         roles,
+		Version:       version,
+	}
+
+	cookie, err := sess.SetSession(r)
+	if err != nil {
+		return res, err
+	}
+
+	// rotate csrf token on every sign in
+	csrf, err := auth.CSRFCookie()
+	if err != nil {
+		return res, err
+	}
+
+	http.SetCookie(w, cookie)
+	http.SetCookie(w, csrf)
+
+	// set response data
+	var role string
+	roles = map[string][]string{}
+	for i, val := range sess.Roles {
+		for _, v := range val {
+			switch v {
+			case rg.RoleCreate, rg.RoleXCreate:
+				role = "CREATE"
+			case rg.RoleRead, rg.RoleXRead:
+				role = "READ"
+			case rg.RoleUpdate, rg.RoleXUpdate:
+				role = "UPDATE"
+			case rg.RoleDelete, rg.RoleXDelete:
+				role = "DELETE"
+			}
+			if !helper.IsStringInSlice(role, roles[i]) {
+				roles[i] = append(roles[i], role)
+			}
+		}
+	}
+
+	res = signInResponse{
+		IsLoggedIn: true,
+		Modules:    roles,
+	}
+	return res, nil
+}
+
+// completeSignIn continues the sign in of the authenticated user by checking the status and
+// the second factor before creating the session
+func completeSignIn(w http.ResponseWriter, r *http.Request, u user.User) {
+
+	// check whether user activated
+	switch u.Status {
+	case alias.UserStatusUnverified:
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			SetMessage("Email unactivated"))
+		return
+	case alias.UserStatusVerified:
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("Waiting for admin approval"))
+		return
+	case alias.UserStatusActivated:
+		break
+	default:
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError).
+			AddError("Internal server error"))
+		return
+	}
+
+	tf, err := user.GetTwoFactor(u.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	// the password is correct but the second factor is still needed
+	isRequired := u.RoleGroupsID.Valid && rg.IsTwoFactorRequired(u.RoleGroupsID.Int64)
+	if tf.EnabledAt.Valid || isRequired {
+		token, err := auth.SetPendingSession(u.ID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetData(signInResponse{
+				IsTwoFactorRequired: true,
+				IsTwoFactorEnrolled: tf.EnabledAt.Valid,
+				Token:               token,
+			}))
+		return
+	}
+
+	res, err := signIn(w, r, u)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError).
+			SetMessage("Internal server error"))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// encodeSearchCursor encodes the cursor into an opaque string for the client
+func encodeSearchCursor(c searchCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeSearchCursor decodes the cursor given by the client
+func decodeSearchCursor(s string) (searchCursor, error) {
+	var c searchCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(b, &c)
+	if err != nil {
+		return c, err
+	}
+	if c.ID < 1 {
+		return c, fmt.Errorf("Invalid cursor")
+	}
+	return c, nil
+}
+
+// verificationLink returns the signed link which carries the verification code, it is empty if the link is disabled
+func verificationLink(purpose, email string, v user.Verification) string {
+	if !auth.IsLinkEnabled() {
+		return ""
+	}
+	token, err := auth.SignLink(purpose, email, v.Code, v.ExpireDate)
+	if err != nil {
+		return ""
+	}
+	return auth.LinkURL(purpose, token)
+}
@@ -0,0 +1,225 @@
+package user
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/email"
+	"github.com/melodiez14/meiko/src/module/audit"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/util/spreadsheet"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// ImportHandler handles the http request for creating the users from the CSV or XLSX roster.
+// The roster has id, name, and email columns. The dry run only reports the invalid rows,
+// otherwise all users are created at once or none of them when a row is invalid
+/*
+	@params:
+		file	= required, csv or xlsx, size<=2MB
+		dry_run	= optional, value=true or false
+	@example:
+		file	= roster.xlsx
+		dry_run	= true
+	@return
+		importResponse
+*/
+func ImportHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleUser, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	r.ParseMultipartForm(importFileSizeMax)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("File is not exist"))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > importFileSizeMax {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("File is too large"))
+		return
+	}
+
+	_, ext, err := helper.ExtractExtension(header.Filename)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("File doesn't have an extension"))
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(file, importFileSizeMax))
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Failed to read the file"))
+		return
+	}
+
+	rows, err := spreadsheet.Read(data, ext, importRowMax+1)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	params := importParams{
+		IsDryRun: r.FormValue("dry_run"),
+		Rows:     rows,
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	err = checkRegistered(args.Rows)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := importResponse{
+		IsDryRun: args.IsDryRun,
+		Total:    len(args.Rows),
+		Errors:   []importErrorResponse{},
+	}
+	for _, row := range args.Rows {
+		if len(row.Errors) > 0 {
+			res.Errors = append(res.Errors, importErrorResponse{
+				Row:    row.Row,
+				Errors: row.Errors,
+			})
+			continue
+		}
+		res.Valid++
+	}
+
+	if args.IsDryRun {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusOK).
+			SetData(res))
+		return
+	}
+
+	if len(res.Errors) > 0 {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Roster has invalid rows").
+			SetData(res))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	for _, row := range args.Rows {
+		u := row.User
+		id, err := user.Create(u.IdentityCode, u.Name, u.Email, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+
+		err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, id, nil, auditValue(user.User{
+			ID:           id,
+			Name:         u.Name,
+			Email:        u.Email,
+			Status:       user.StatusActivated,
+			IdentityCode: u.IdentityCode,
+		}), tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	res.Created = len(args.Rows)
+
+	// the emails are sent one by one to avoid flooding the mail server
+	go func(rows []importRow) {
+		for _, row := range rows {
+			verification, err := user.GenerateVerification(row.User.IdentityCode)
+			if err != nil {
+				continue
+			}
+			email.SendAccountCreated(row.User.Name, row.User.Email, verification.Code)
+		}
+	}(args.Rows)
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Users successfully created").
+		SetData(res))
+	return
+}
+
+// checkRegistered adds an error to the row of which the identity code or the email has been registered
+func checkRegistered(rows []importRow) error {
+	var identities []int64
+	var emails []string
+	for _, row := range rows {
+		if row.User.IdentityCode == 0 {
+			continue
+		}
+		identities = append(identities, row.User.IdentityCode)
+		emails = append(emails, row.User.Email)
+	}
+
+	registeredIdentity, err := user.SelectRegisteredIdentityCode(identities)
+	if err != nil {
+		return err
+	}
+	registeredEmail, err := user.SelectRegisteredEmail(emails)
+	if err != nil {
+		return err
+	}
+	for i, v := range registeredEmail {
+		registeredEmail[i] = strings.ToLower(v)
+	}
+
+	for i, row := range rows {
+		if row.User.IdentityCode == 0 {
+			continue
+		}
+		if helper.Int64InSlice(row.User.IdentityCode, registeredIdentity) {
+			rows[i].Errors = append(rows[i].Errors, fmt.Sprintf("%d has been registered", row.User.IdentityCode))
+		}
+		if helper.IsStringInSlice(row.User.Email, registeredEmail) {
+			rows[i].Errors = append(rows[i].Errors, fmt.Sprintf("%s has been registered", row.User.Email))
+		}
+	}
+	return nil
+}
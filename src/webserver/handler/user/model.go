@@ -2,6 +2,8 @@ package user
 
 import (
 	"database/sql"
+
+	"github.com/melodiez14/meiko/src/module/user"
 )
 
 // signUpParams Parameter that needed in sign up
@@ -52,16 +54,19 @@ type signUpArgs struct {
 		Email			= string
 		IsResendCode	= string
 		Code			= string
+		Token			= string
 	@example:
 		Email			= khairil_azmi_ashari@yahoo.com
 		IsresendCode	= true
 		Code			= 123456
+		Token			= eyJwIjoidmVyaWZ5In0.c2lnbmF0dXJl
 	@return
 */
 type emailVerificationParams struct {
 	Email        string
 	IsResendCode string
 	Code         string
+	Token        string
 }
 
 // emailVerificationArgs Parameter that will use to doing email verification
@@ -97,34 +102,51 @@ type signInParams struct {
 	Password string
 }
 
-// signInArgs Parameter that will use to sign in
+// signInArgs Parameter that will use to sign in. Password is the hash for the local user,
+// RawPassword is the password as it is typed which is only given to the directory
 /*
 	@params:
 		Email		= string
 		Password	= string
+		RawPassword	= string
 	@example:
 		Email		= khairil_azmi_ashari@yahoo.com
-		Password	= Khairil14001
+		Password	= 5f4b8a3c6f0d4c1bd6c0f9e1d2c3b4a5
+		RawPassword	= Khairil14001
 	@return
 */
 type signInArgs struct {
-	Email    string
-	Password string
+	Email       string
+	Password    string
+	RawPassword string
 }
 
-// signInResponse Variable that will be send to server when sign in
+// signInResponse Variable that will be send to server when sign in.
+// Token is given instead of the session when the two factor authentication is required
 /*
 	@params:
-		IsLoggedIn	= bool
-		Modules		= string
+		IsLoggedIn			= bool
+		Modules				= string
+		IsTwoFactorRequired	= bool
+		IsTwoFactorEnrolled	= bool
+		Token				= string
+		RecoveryCodes		= []string
 	@example:
-		IsLoggedIn	= true
-		Modules		= assignment
+		IsLoggedIn			= true
+		Modules				= assignment
+		IsTwoFactorRequired	= false
+		IsTwoFactorEnrolled	= false
+		Token				=
+		RecoveryCodes		= []
 	@return
 */
 type signInResponse struct {
-	IsLoggedIn bool                `json:"is_logged_in"`
-	Modules    map[string][]string `json:"modules"`
+	IsLoggedIn          bool                `json:"is_logged_in"`
+	Modules             map[string][]string `json:"modules"`
+	IsTwoFactorRequired bool                `json:"is_2fa_required,omitempty"`
+	IsTwoFactorEnrolled bool                `json:"is_2fa_enrolled,omitempty"`
+	Token               string              `json:"token,omitempty"`
+	RecoveryCodes       []string            `json:"recovery_codes,omitempty"`
 }
 
 // forgotResponse Variable that will be send to server when forgot password clicked
@@ -152,11 +174,13 @@ type forgotResponse struct {
 		IsSendCode		= string
 		Password		= string
 		Code			= string
+		Token			= string
 	@example:
 		Email			= khairil_azmi_ashari@yahoo.com
 		ExpireDuration	= true
 		Password		= Khairil14001
 		Code			= 123456
+		Token			= eyJwIjoiZm9yZ290In0.c2lnbmF0dXJl
 	@return
 */
 type forgotParams struct {
@@ -164,6 +188,7 @@ type forgotParams struct {
 	IsSendCode string
 	Password   string
 	Code       string
+	Token      string
 }
 
 // forgotArgs Parameter that will be use to forgot password. to generate new password to email
@@ -192,14 +217,23 @@ type forgotArgs struct {
 	@params:
 		Page			= string
 		Total			= string
+		Program			= string
+		Cohort			= string
+		Advisor			= string
 	@example:
 		Page			= 35
 		Total			= 60
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		Advisor			= 140810140001
 	@return
 */
 type getVerifiedParams struct {
-	Page  string
-	Total string
+	Page    string
+	Total   string
+	Program string
+	Cohort  string
+	Advisor string
 }
 
 // getVeriviedArgs Parameter that will be use to get verified item to show in list.
@@ -207,14 +241,23 @@ type getVerifiedParams struct {
 	@params:
 		Page			= uint16
 		Total			= uint16
+		Program			= string
+		Cohort			= uint16
+		Advisor			= int64
 	@example:
 		Page			= 35
 		Total			= 60
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		Advisor			= 140810140001
 	@return
 */
 type getVerifiedArgs struct {
-	Page  int
-	Total int
+	Page    int
+	Total   int
+	Program string
+	Cohort  uint16
+	Advisor int64
 }
 
 // getVerifiedUser is a struct of user which is used for getVerifiedResponse as an slice
@@ -224,11 +267,15 @@ type getVerifiedArgs struct {
 		Name			= string
 		Email			= string
 		Status			= string
+		Program			= string
+		Cohort			= uint16
 	@example:
 		IdentityCode	= 140810140060
 		Name			= khairil azmi ashari
 		Email			= khairilazmiashari@gmail.com
 		Status			= 1
+		Program			= Teknik Informatika
+		Cohort			= 2014
 	@return
 */
 type getVerifiedUser struct {
@@ -236,6 +283,8 @@ type getVerifiedUser struct {
 	Name         string `json:"name"`
 	Email        string `json:"email"`
 	Status       string `json:"status"`
+	Program      string `json:"program"`
+	Cohort       uint16 `json:"cohort,omitempty"`
 }
 
 // getVerifiedResponse Response to server from application to get verified content.
@@ -266,6 +315,8 @@ type getVerifiedResponse struct {
 		Phone			= string
 		LineID			= string
 		Note			= string
+		Program			= string
+		Cohort			= string
 	@example:
 		IdentityCode	= 140810140060
 		Name			= khairil azmi ashari
@@ -274,6 +325,8 @@ type getVerifiedResponse struct {
 		Phone			= 082214467300
 		LineID			= khaazas
 		Note			= nothing is impossible
+		Program			= Teknik Informatika
+		Cohort			= 2014
 	@return
 */
 type updateProfileParams struct {
@@ -284,6 +337,8 @@ type updateProfileParams struct {
 	Phone        string
 	LineID       string
 	Note         string
+	Program      string
+	Cohort       string
 }
 
 // updateProfileArgs Parameter that will be use to update user profile.
@@ -296,6 +351,8 @@ type updateProfileParams struct {
 		Phone			= string
 		LineID			= string
 		Note			= string
+		Program			= sql.NullString
+		Cohort			= sql.NullInt64
 	@example:
 		IdentityCode	= 140810140060
 		Name			= khairil azmi ashari
@@ -304,6 +361,8 @@ type updateProfileParams struct {
 		Phone			= 082214467300
 		LineID			= khaazas
 		Note			= nothing is impossible
+		Program			= Teknik Informatika
+		Cohort			= 2014
 	@return
 */
 type updateProfileArgs struct {
@@ -314,6 +373,8 @@ type updateProfileArgs struct {
 	Phone        sql.NullString
 	LineID       sql.NullString
 	Note         string
+	Program      sql.NullString
+	Cohort       sql.NullInt64
 }
 
 // getProfileResponse Application will send data to server to update.
@@ -328,6 +389,9 @@ type updateProfileArgs struct {
 		Note					= string
 		ImageProfile			= string
 		ImageProfileThumbnail	= string
+		Program					= string
+		Cohort					= uint16
+		Advisor					= string
 	@example:
 		Name					= khairil azmi ashari
 		Email					= khairilazmiashari@gmail.com
@@ -338,6 +402,9 @@ type updateProfileArgs struct {
 		Note					= nothing is impossible
 		ImageProfile			= profile.jpg
 		ImageProfileThumbnail	= profileThumb.jpg
+		Program					= Teknik Informatika
+		Cohort					= 2014
+		Advisor					= risal falah
 	@return
 */
 type getProfileResponse struct {
@@ -345,9 +412,13 @@ type getProfileResponse struct {
 	Email                 string `json:"email"`
 	Gender                string `json:"gender"`
 	Phone                 string `json:"phone"`
+	PhoneVerified         bool   `json:"phone_verified"`
 	IdentityCode          int64  `json:"id"`
 	LineID                string `json:"line_id"`
 	Note                  string `json:"about_me"`
+	Program               string `json:"program"`
+	Cohort                uint16 `json:"cohort,omitempty"`
+	Advisor               string `json:"advisor"`
 	ImageProfile          string `json:"img"`
 	ImageProfileThumbnail string `json:"img_t"`
 }
@@ -463,6 +534,10 @@ type detailArgs struct {
 		IdentityCode			= int64
 		LineID					= string
 		Note					= string
+		Status					= string
+		Program					= string
+		Cohort					= uint16
+		Advisor					= int64
 	@example:
 		Name					= khairil azmi ashari
 		Email					= khairilazmiashari@gmail.com
@@ -471,17 +546,25 @@ type detailArgs struct {
 		IdentityCode			= 140810140060
 		LineID					= khaazas
 		Note					= nothing is impossible
+		Status					= active
+		Program					= Teknik Informatika
+		Cohort					= 2014
+		Advisor					= 140810140001
 	@return
 */
 type detailResponse struct {
-	Name         string `json:"name"`
-	Email        string `json:"email"`
-	Gender       string `json:"gender"`
-	Phone        string `json:"phone"`
-	IdentityCode int64  `json:"id"`
-	LineID       string `json:"line_id"`
-	Note         string `json:"about_me"`
-	Status       string `json:"status"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Gender        string `json:"gender"`
+	Phone         string `json:"phone"`
+	PhoneVerified bool   `json:"phone_verified"`
+	IdentityCode  int64  `json:"id"`
+	LineID        string `json:"line_id"`
+	Note          string `json:"about_me"`
+	Status        string `json:"status"`
+	Program       string `json:"program"`
+	Cohort        uint16 `json:"cohort,omitempty"`
+	Advisor       int64  `json:"advisor,omitempty"`
 }
 
 // updateParams Parameter that will be needed to update user information.
@@ -495,6 +578,10 @@ type detailResponse struct {
 		LineID			= string
 		Note			= string
 		Status			= string
+		RoleGroupID		= string
+		Program			= string
+		Cohort			= string
+		Advisor			= string
 	@example:
 		IdentityCode	= 140810140060
 		Name			= khairil azmi ashari
@@ -503,6 +590,10 @@ type detailResponse struct {
 		Phone			= 082214467300
 		LineID			= khaazas
 		Note			= nothing is impossible
+		RoleGroupID		= 2
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		Advisor			= 140810140001
 	@return
 */
 type updateParams struct {
@@ -514,6 +605,10 @@ type updateParams struct {
 	LineID       string
 	Note         string
 	Status       string
+	RoleGroupID  string
+	Program      string
+	Cohort       string
+	Advisor      string
 }
 
 // updateArgs Parameter that will be use to update user information.
@@ -527,6 +622,10 @@ type updateParams struct {
 		LineID			= string
 		Note			= string
 		Status			= int8
+		RoleGroupID		= sql.NullInt64, invalid keeps the current rolegroup
+		Program			= sql.NullString
+		Cohort			= sql.NullInt64
+		Advisor			= sql.NullInt64
 	@example:
 		IdentityCode	= 140810140060
 		Name			= khairil azmi ashari
@@ -536,6 +635,10 @@ type updateParams struct {
 		LineID			= khaazas
 		Note			= nothing is impossible
 		Status			= 1
+		RoleGroupID		= 2
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		Advisor			= 140810140001
 	@return
 */
 type updateArgs struct {
@@ -547,6 +650,10 @@ type updateArgs struct {
 	LineID       sql.NullString
 	Note         string
 	Status       int8
+	RoleGroupID  sql.NullInt64
+	Program      sql.NullString
+	Cohort       sql.NullInt64
+	Advisor      sql.NullInt64
 }
 
 // deleteParams Parameter that needed to delete user.
@@ -613,3 +720,576 @@ type searchResponse struct {
 	IdentityCode int64  `json:"id"`
 	Name         string `json:"name"`
 }
+
+// sessionResponse Variable that will be send to show an active session of user
+/*
+	@params:
+		ID			= string
+		UserAgent	= string
+		IP			= string
+		CreatedAt	= int64
+		LastSeen	= int64
+		ExpiresAt	= int64
+		IsCurrent	= bool
+	@example:
+		ID			= 3f2a9c1d7b0e4a55
+		UserAgent	= Mozilla/5.0 (X11; Linux x86_64)
+		IP			= 10.10.1.20
+		CreatedAt	= 1508313600
+		LastSeen	= 1508317200
+		ExpiresAt	= 1508922000
+		IsCurrent	= true
+	@return
+*/
+type sessionResponse struct {
+	ID        string `json:"id"`
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+	CreatedAt int64  `json:"created_at"`
+	LastSeen  int64  `json:"last_seen"`
+	ExpiresAt int64  `json:"expires_at"`
+	IsCurrent bool   `json:"is_current"`
+}
+
+// unlockParams Parameter that needed to unlock user.
+/*
+	@params:
+		IdentityCode	= string
+	@example:
+		IdentityCode	= 140810140060
+	@return
+*/
+type unlockParams struct {
+	IdentityCode string
+}
+
+// unlockArgs Parameter that will be use to unlock user.
+/*
+	@params:
+		IdentityCode	= int64
+	@example:
+		IdentityCode	= 140810140060
+	@return
+*/
+type unlockArgs struct {
+	IdentityCode int64
+}
+
+// twoFactorSignInParams Parameter that needed to pass the second step of sign in
+/*
+	@params:
+		Token			= string
+		Code			= string
+		RecoveryCode	= string
+	@example:
+		Token			= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+		Code			= 287082
+		RecoveryCode	= k3pxp-jbswy
+	@return
+*/
+type twoFactorSignInParams struct {
+	Token        string
+	Code         string
+	RecoveryCode string
+}
+
+// twoFactorSignInArgs Parameter that will be use to pass the second step of sign in
+/*
+	@params:
+		Token			= string
+		Code			= string
+		RecoveryCode	= string
+	@example:
+		Token			= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+		Code			= 287082
+		RecoveryCode	=
+	@return
+*/
+type twoFactorSignInArgs struct {
+	Token        string
+	Code         string
+	RecoveryCode string
+}
+
+// twoFactorEnrollParams Parameter that needed to enroll two factor authentication while signing in
+/*
+	@params:
+		Token	= string
+	@example:
+		Token	= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+	@return
+*/
+type twoFactorEnrollParams struct {
+	Token string
+}
+
+// twoFactorEnrollArgs Parameter that will be use to enroll two factor authentication while signing in
+/*
+	@params:
+		Token	= string
+	@example:
+		Token	= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+	@return
+*/
+type twoFactorEnrollArgs struct {
+	Token string
+}
+
+// twoFactorCodeParams Parameter that needed to confirm two factor authentication changes
+/*
+	@params:
+		Code	= string
+	@example:
+		Code	= 287082
+	@return
+*/
+type twoFactorCodeParams struct {
+	Code string
+}
+
+// twoFactorCodeArgs Parameter that will be use to confirm two factor authentication changes
+/*
+	@params:
+		Code	= string
+	@example:
+		Code	= 287082
+	@return
+*/
+type twoFactorCodeArgs struct {
+	Code string
+}
+
+// disableTwoFactorParams Parameter that needed to turn off two factor authentication
+/*
+	@params:
+		Code		= string
+		Password	= string
+	@example:
+		Code		= 287082
+		Password	= Qwerty123
+	@return
+*/
+type disableTwoFactorParams struct {
+	Code     string
+	Password string
+}
+
+// disableTwoFactorArgs Parameter that will be use to turn off two factor authentication
+/*
+	@params:
+		Code		= string
+		Password	= string
+	@example:
+		Code		= 287082
+		Password	= 2af9b1ba42dc5eb01743e6b3759b6e4b
+	@return
+*/
+type disableTwoFactorArgs struct {
+	Code     string
+	Password string
+}
+
+// twoFactorResponse Variable that will be send to show two factor authentication status of user
+/*
+	@params:
+		IsEnabled			= bool
+		IsRequired			= bool
+		RecoveryCodesLeft	= int
+	@example:
+		IsEnabled			= true
+		IsRequired			= true
+		RecoveryCodesLeft	= 8
+	@return
+*/
+type twoFactorResponse struct {
+	IsEnabled         bool `json:"is_enabled"`
+	IsRequired        bool `json:"is_required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// twoFactorEnrollResponse Variable that will be send to set up the authenticator app
+/*
+	@params:
+		Secret	= string
+		URI		= string
+	@example:
+		Secret	= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+		URI		= otpauth://totp/Meiko:risal@live.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	@return
+*/
+type twoFactorEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// recoveryCodesResponse Variable that will be send once after the recovery codes are generated
+/*
+	@params:
+		RecoveryCodes	= []string
+	@example:
+		RecoveryCodes	= [k3pxp-jbswy, 7dpeh-pk3px]
+	@return
+*/
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ssoCallbackParams Parameter that needed to complete the single sign on
+/*
+	@params:
+		Code	= string
+		State	= string
+		Error	= string
+	@example:
+		Code	= SplxlOBeZQQYbYS6WxSbIA
+		State	= af0ifjsldkj
+		Error	=
+	@return
+*/
+type ssoCallbackParams struct {
+	Code  string
+	State string
+	Error string
+}
+
+// ssoCallbackArgs Parameter that will be use to complete the single sign on
+/*
+	@params:
+		Code	= string
+		State	= string
+	@example:
+		Code	= SplxlOBeZQQYbYS6WxSbIA
+		State	= af0ifjsldkj
+	@return
+*/
+type ssoCallbackArgs struct {
+	Code  string
+	State string
+}
+
+const (
+	// importFileSizeMax is the maximum size of the roster file
+	importFileSizeMax = 2 << 20
+	// importRowMax is the maximum number of users in the roster
+	importRowMax = 1000
+)
+
+// importParams Parameter that needed to import the users from the roster
+/*
+	@params:
+		IsDryRun	= string
+		Rows		= [][]string
+	@example:
+		IsDryRun	= true
+		Rows		= [[id name email] [140810140016 Risal Falah risal@live.com]]
+	@return
+*/
+type importParams struct {
+	IsDryRun string
+	Rows     [][]string
+}
+
+// importArgs Parameter that will be use to import the users from the roster
+/*
+	@params:
+		IsDryRun	= bool
+		Rows		= []importRow
+	@example:
+		IsDryRun	= true
+		Rows		= [{2 {140810140016 Risal Falah risal@live.com} []}]
+	@return
+*/
+type importArgs struct {
+	IsDryRun bool
+	Rows     []importRow
+}
+
+// importRow is a user of the roster, the user is created only when there is no error.
+// Row is the row number in the file including the header
+type importRow struct {
+	Row    int
+	User   createArgs
+	Errors []string
+}
+
+// importResponse Variable that will be send as the report of the import
+/*
+	@params:
+		IsDryRun	= bool
+		Total		= int
+		Valid		= int
+		Created		= int
+		Errors		= []importErrorResponse
+	@example:
+		IsDryRun	= true
+		Total		= 2
+		Valid		= 1
+		Created		= 0
+		Errors		= [{3 [risal@live.com has been registered]}]
+	@return
+*/
+type importResponse struct {
+	IsDryRun bool                  `json:"dry_run"`
+	Total    int                   `json:"total"`
+	Valid    int                   `json:"valid"`
+	Created  int                   `json:"created"`
+	Errors   []importErrorResponse `json:"errors"`
+}
+
+type importErrorResponse struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+// exportProfile is the profile of the user inside the exported data
+type exportProfile struct {
+	IdentityCode int64  `json:"identity_code"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Gender       int8   `json:"gender"`
+	Note         string `json:"note"`
+	Phone        string `json:"phone"`
+	LineID       string `json:"line_id"`
+	Status       int8   `json:"status"`
+	Program      string `json:"program"`
+	Cohort       uint16 `json:"cohort"`
+}
+
+// eraseParams Parameter that needed to erase the personal data of the user
+/*
+	@params:
+		IdentityCode	= string
+		Confirm			= string
+	@example:
+		IdentityCode	= 140810140016
+		Confirm			= 140810140016
+	@return
+*/
+type eraseParams struct {
+	IdentityCode string
+	Confirm      string
+}
+
+// eraseArgs Parameter that will be use to erase the personal data of the user
+/*
+	@params:
+		IdentityCode	= int64
+	@example:
+		IdentityCode	= 140810140016
+	@return
+*/
+type eraseArgs struct {
+	IdentityCode int64
+}
+
+// adviseeResponse is the student advised by the user
+/*
+	@params:
+		IdentityCode	= int64
+		Name			= string
+		Email			= string
+		Program			= string
+		Cohort			= uint16
+	@example:
+		IdentityCode	= 140810140060
+		Name			= khairil azmi ashari
+		Email			= khairilazmiashari@gmail.com
+		Program			= Teknik Informatika
+		Cohort			= 2014
+	@return
+*/
+type adviseeResponse struct {
+	IdentityCode int64  `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Program      string `json:"program"`
+	Cohort       uint16 `json:"cohort,omitempty"`
+}
+
+const (
+	// searchTotalDefault is the number of users of a search page when it is not specified
+	searchTotalDefault = 20
+	// searchTotalMax is the maximum number of users of a search page
+	searchTotalMax = 100
+)
+
+// searchSorts maps the sort parameter of the search into the column of users
+var searchSorts = map[string]string{
+	"created": user.ColID,
+	"id":      user.ColIdentityCode,
+	"name":    user.ColName,
+	"email":   user.ColEmail,
+	"gender":  user.ColGender,
+	"status":  user.ColStatus,
+}
+
+// searchUserParams Parameter that needed to search the users
+/*
+	@params:
+		Text		= string
+		Status		= string
+		Gender		= string
+		RolegroupID	= string
+		ScheduleID	= string
+		Sort		= string
+		Order		= string
+		Cursor		= string
+		Total		= string
+	@example:
+		Text		= risal
+		Status		= active
+		Gender		= male
+		RolegroupID	= 2
+		ScheduleID	= 12
+		Sort		= name
+		Order		= desc
+		Cursor		= eyJzIjoibmFtZSIsImQiOnRydWUsInYiOiJyaXNhbCIsImkiOjF9
+		Total		= 20
+	@return
+*/
+type searchUserParams struct {
+	Text        string
+	Status      string
+	Gender      string
+	RolegroupID string
+	ScheduleID  string
+	Sort        string
+	Order       string
+	Cursor      string
+	Total       string
+}
+
+// searchUserArgs Parameter that will be use to search the users
+/*
+	@params:
+		Filter	= user.SearchFilter
+		Sort	= string
+		IsDesc	= bool
+		Cursor	= user.SearchCursor
+		Total	= int
+	@example:
+		Filter	= {Text: risal, Status: 2}
+		Sort	= name
+		IsDesc	= true
+		Cursor	= {Value: risal, ID: 1}
+		Total	= 20
+	@return
+*/
+type searchUserArgs struct {
+	Filter user.SearchFilter
+	Sort   string
+	IsDesc bool
+	Cursor user.SearchCursor
+	Total  int
+}
+
+// searchCursor is the content of the cursor given to the client. The sort and the order
+// are kept inside, so the cursor can't be used for a page sorted in another way
+type searchCursor struct {
+	Sort   string `json:"s"`
+	IsDesc bool   `json:"d"`
+	Value  string `json:"v"`
+	ID     int64  `json:"i"`
+}
+
+// searchUserResponse Variable that will be send as the page of the search
+/*
+	@params:
+		Users		= []searchUser
+		NextCursor	= string
+	@example:
+		Users		= [{140810140016 Risal Falah risal@live.com male active 2}]
+		NextCursor	= eyJzIjoibmFtZSIsImQiOnRydWUsInYiOiJyaXNhbCIsImkiOjF9
+	@return
+*/
+type searchUserResponse struct {
+	Users      []searchUser `json:"users"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type searchUser struct {
+	IdentityCode int64  `json:"id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Gender       string `json:"gender"`
+	Status       string `json:"status"`
+	RolegroupID  int64  `json:"rolegroup_id,omitempty"`
+}
+
+// emailChangeParams Parameter that needed to request the change of email
+/*
+	@params:
+		Email		= string
+		Password	= string
+	@example:
+		Email		= risal.falah@gmail.com
+		Password	= Qwerty123
+	@return
+*/
+type emailChangeParams struct {
+	Email    string
+	Password string
+}
+
+// emailChangeArgs Parameter that will be use to request the change of email
+/*
+	@params:
+		Email		= string
+		Password	= string
+	@example:
+		Email		= risal.falah@gmail.com
+		Password	= 2af9b1ba42dc5eb01743e6b3759b6e4b
+	@return
+*/
+type emailChangeArgs struct {
+	Email    string
+	Password string
+}
+
+// confirmEmailChangeParams Parameter that needed to confirm the change of email
+/*
+	@params:
+		Code	= string
+	@example:
+		Code	= 1408
+	@return
+*/
+type confirmEmailChangeParams struct {
+	Code string
+}
+
+// confirmEmailChangeArgs Parameter that will be use to confirm the change of email
+/*
+	@params:
+		Code	= uint16
+	@example:
+		Code	= 1408
+	@return
+*/
+type confirmEmailChangeArgs struct {
+	Code uint16
+}
+
+// confirmPhoneParams Parameter that needed to confirm the phone by the code sent by SMS
+/*
+	@params:
+		Code	= string
+	@example:
+		Code	= 1408
+	@return
+*/
+type confirmPhoneParams struct {
+	Code string
+}
+
+// confirmPhoneArgs Parameter that will be use to confirm the phone
+/*
+	@params:
+		Code	= uint16
+	@example:
+		Code	= 1408
+	@return
+*/
+type confirmPhoneArgs struct {
+	Code uint16
+}
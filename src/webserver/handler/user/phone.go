@@ -0,0 +1,148 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/audit"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/sms"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// RequestPhoneVerificationHandler handles the http request for sending the verification code
+// to the phone of user by SMS. The phone is set by UpdateProfileHandler
+/*
+	@params:
+	@example:
+	@return
+*/
+func RequestPhoneVerificationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !sms.IsEnabled() {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("SMS is not enabled"))
+		return
+	}
+
+	u, err := user.GetByIdentityCode(sess.IdentityCode, user.ColID, user.ColPhone, user.ColPhoneVerified)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	if !u.Phone.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Phone has not been set"))
+		return
+	}
+
+	if u.PhoneVerified {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Phone has been verified"))
+		return
+	}
+
+	verification, err := user.GeneratePhoneVerification(u.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusTooManyRequests).
+			AddError("Please wait a minute before requesting another code"))
+		return
+	}
+
+	err = sms.Send(u.Phone.String, fmt.Sprintf("Your Meiko verification code is %d, it expires in %s", verification.Code, verification.ExpireDuration))
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadGateway).
+			AddError("Failed to send the SMS"))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage(fmt.Sprintf("The verification code has been sent to %s", u.Phone.String)))
+	return
+}
+
+// ConfirmPhoneVerificationHandler handles the http request for verifying the phone of user by the code sent by SMS
+/*
+	@params:
+		code	= required, numeric, characters=4
+	@example:
+		code=1408
+	@return
+*/
+func ConfirmPhoneVerificationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := confirmPhoneParams{
+		Code: r.FormValue("code"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	verification, err := user.GetPhoneVerification(sess.ID)
+	if err != nil || !verification.Phone.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("There is no phone verification or it has expired"))
+		return
+	}
+
+	if !user.IsValidPhoneVerificationCode(verification, args.Code) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid code"))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = user.UpdatePhoneVerified(sess.ID, verification.Phone.String, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, sess.ID,
+		map[string]interface{}{"phone": verification.Phone.String, "phone_verified": false},
+		map[string]interface{}{"phone": verification.Phone.String, "phone_verified": true}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	sess.Phone = verification.Phone.String
+	sess.PhoneVerified = true
+	sess.UpdateSession()
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Phone has been verified"))
+	return
+}
@@ -0,0 +1,260 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/apikey"
+	asg "github.com/melodiez14/meiko/src/module/assignment"
+	atd "github.com/melodiez14/meiko/src/module/attendance"
+	"github.com/melodiez14/meiko/src/module/audit"
+	"github.com/melodiez14/meiko/src/module/bot"
+	cs "github.com/melodiez14/meiko/src/module/course"
+	fl "github.com/melodiez14/meiko/src/module/file"
+	"github.com/melodiez14/meiko/src/module/lockout"
+	"github.com/melodiez14/meiko/src/module/notification"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/alias"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// personalFiles are the types of the files which belong to the user only,
+// the course materials uploaded by the user stay with the course
+var personalFiles = map[string]string{
+	fl.TypProfPict:         "profile",
+	fl.TypProfPictThumb:    "profile",
+	fl.TypAssignmentUpload: "assignment",
+}
+
+// ExportHandler handles the http request for downloading the personal data of the user.
+// The data is a ZIP of JSON files containing the profile, courses, grades, attendances,
+// submissions, notifications, and bot history
+/*
+	@params:
+	@example:
+	@return
+		meiko-140810140016.zip
+*/
+func ExportHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	data, err := exportData(sess.IdentityCode)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="meiko-%d.zip"`, sess.IdentityCode))
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(data)
+	return
+}
+
+// EraseHandler handles the http request for erasing the personal data of the user.
+// The grades, attendances, submissions, enrollments, notifications, and bot history are deleted
+// and the user is anonymized in one transaction, the uploaded files are removed afterwards
+/*
+	@params:
+		id		= required, numeric, 10<=characters<=18
+		confirm	= required, same as id
+	@example:
+		id		= 140810140016
+		confirm	= 140810140016
+	@return
+*/
+func EraseHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXDelete, rg.ModuleUser, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := eraseParams{
+		IdentityCode: ps.ByName("id"),
+		Confirm:      r.FormValue("confirm"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	if sess.IdentityCode == args.IdentityCode {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid Request"))
+		return
+	}
+
+	u, err := user.GetByIdentityCode(args.IdentityCode)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("User not found"))
+		return
+	}
+
+	typ := []string{}
+	for v := range personalFiles {
+		typ = append(typ, v)
+	}
+
+	files, err := fl.SelectByUserID(u.ID, typ)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	erasers := []func() error{
+		func() error { return bot.DeleteLogByUserID(u.ID, tx) },
+		func() error { return notification.DeleteByUserID(u.ID, tx) },
+		func() error { return atd.DeleteByUserID(u.ID, tx) },
+		func() error { return asg.DeleteSubmissionByUserID(u.ID, tx) },
+		func() error { return fl.DeleteByUserID(u.ID, typ, tx) },
+		func() error { return cs.DeleteEnrollmentByUserID(u.ID, tx) },
+		func() error { return cs.DeleteWaitlistByUserID(u.ID, tx) },
+		func() error { return apikey.DeleteByUserID(u.ID, tx) },
+		func() error { return user.ReplaceRecoveryCodes(u.ID, nil, tx) },
+		func() error { return lockout.DeleteByEmail(u.Email, tx) },
+		func() error { return audit.Redact(auditTable, u.ID, tx) },
+		func() error { return user.Anonymize(u.ID, tx) },
+		func() error { return audit.Insert(sess.ID, audit.ActionErase, auditTable, u.ID, nil, nil, tx) },
+	}
+	for _, erase := range erasers {
+		err = erase()
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	auth.DestroyAllSession(u.ID)
+	go func() {
+		for _, f := range files {
+			os.Remove(filePath(f))
+		}
+	}()
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetMessage("User data successfully erased").
+		SetCode(http.StatusOK))
+	return
+}
+
+// exportData returns the ZIP of the personal data of the user
+func exportData(identityCode int64) ([]byte, error) {
+	u, err := user.GetByIdentityCode(identityCode)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := cs.SelectEnrollmentByUserID(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	grades, err := asg.SelectSubmissionByUserID(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := atd.SelectByUserID(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := fl.SelectByUserID(u.ID, []string{fl.TypAssignmentUpload}, fl.StatusExist)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := notification.SelectByUserID(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := bot.SelectLogByUserID(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", exportProfile{
+			IdentityCode: u.IdentityCode,
+			Name:         u.Name,
+			Email:        u.Email,
+			Gender:       u.Gender,
+			Note:         u.Note,
+			Phone:        u.Phone.String,
+			LineID:       u.LineID.String,
+			Status:       u.Status,
+			Program:      u.Program.String,
+			Cohort:       uint16(u.Cohort.Int64),
+		}},
+		{"courses.json", courses},
+		{"grades.json", grades},
+		{"attendances.json", attendances},
+		{"submissions.json", submissions},
+		{"notifications.json", notifications},
+		{"bot_history.json", logs},
+	}
+
+	var buf bytes.Buffer
+	z := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		f, err := z.Create(entry.name)
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(entry.value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = z.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// filePath returns the location of the personal file in the storage
+func filePath(f fl.File) string {
+	if f.Type == fl.TypProfPict || f.Type == fl.TypProfPictThumb {
+		return fmt.Sprintf("%s/%s/%s.jpg", alias.Dir["data"], personalFiles[f.Type], f.ID)
+	}
+	return fmt.Sprintf("%s/%s/%s.%s", alias.Dir["data"], personalFiles[f.Type], f.ID, f.Extension)
+}
@@ -0,0 +1,124 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/policy"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+// SearchHandler handles the http request for searching the users page by page. Accessing this handler needs READ or XREAD ability.
+// The page continues from the cursor of the previous page, so it stays stable while the users are changing
+/*
+	@params:
+		q				= optional, name, email, or identity code, 0<characters<=50
+		status			= optional, unverified, inactive, or active
+		gender			= optional, undefined, male, or female
+		rolegroup_id	= optional, positive numeric
+		schedule_id		= optional, positive numeric
+		sort			= optional, created, id, name, email, gender, or status
+		order			= optional, asc or desc
+		cursor			= optional, next_cursor of the previous page
+		ttl				= optional, 1<=numeric<=100
+	@example:
+		q=risal
+		status=active
+		sort=name
+		order=desc
+		ttl=20
+	@return
+		users		= []{id, name, email, gender, status, rolegroup_id}
+		next_cursor	= eyJzIjoibmFtZSIsImQiOnRydWUsInYiOiJyaXNhbCIsImkiOjF9
+*/
+func SearchHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleUser, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := searchUserParams{
+		Text:        r.FormValue("q"),
+		Status:      r.FormValue("status"),
+		Gender:      r.FormValue("gender"),
+		RolegroupID: r.FormValue("rolegroup_id"),
+		ScheduleID:  r.FormValue("schedule_id"),
+		Sort:        r.FormValue("sort"),
+		Order:       r.FormValue("order"),
+		Cursor:      r.FormValue("cursor"),
+		Total:       r.FormValue("ttl"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	// one more user tells whether there is a next page
+	users, err := user.SelectBySearch(args.Filter, args.Sort, args.IsDesc, args.Cursor, args.Total+1)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	res := searchUserResponse{
+		Users: []searchUser{},
+	}
+	if len(users) > args.Total {
+		users = users[:args.Total]
+		last := users[len(users)-1]
+		res.NextCursor = encodeSearchCursor(searchCursor{
+			Sort:   args.Sort,
+			IsDesc: args.IsDesc,
+			Value:  last.SortValue(args.Sort),
+			ID:     last.ID,
+		})
+	}
+
+	for _, val := range users {
+		var gender string
+		switch val.Gender {
+		case user.GenderMale:
+			gender = "male"
+		case user.GenderFemale:
+			gender = "female"
+		default:
+			gender = "undefined"
+		}
+
+		var status string
+		switch val.Status {
+		case user.StatusActivated:
+			status = "active"
+		case user.StatusVerified:
+			status = "inactive"
+		default:
+			status = "unverified"
+		}
+
+		res.Users = append(res.Users, searchUser{
+			IdentityCode: val.IdentityCode,
+			Name:         val.Name,
+			Email:        val.Email,
+			Gender:       gender,
+			Status:       status,
+			RolegroupID:  val.RoleGroupsID.Int64,
+		})
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
@@ -0,0 +1,204 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/util/oidc"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+var (
+	errSSOInternal        = fmt.Errorf("Internal server error")
+	errSSOEmailUnverified = fmt.Errorf("Email is not verified by the single sign on provider")
+	errSSOLinked          = fmt.Errorf("Email has been linked to another single sign on account")
+	errSSOIdentity        = fmt.Errorf("Identity is missing or has been registered, please contact the admin")
+)
+
+// SSOSignInHandler handles the http request for redirecting the user to the single sign on provider
+/*
+	@params:
+	@example:
+	@return
+*/
+func SSOSignInHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if sess != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You have already logged in"))
+		return
+	}
+
+	if !oidc.IsEnabled() {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Single sign on is not enabled"))
+		return
+	}
+
+	state, nonce, cookie, err := auth.SetSSOState()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	url, err := oidc.AuthCodeURL(state, nonce)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusServiceUnavailable).
+			AddError("Single sign on provider is unavailable"))
+		return
+	}
+
+	http.SetCookie(w, cookie)
+	http.Redirect(w, r, url, http.StatusFound)
+	return
+}
+
+// SSOCallbackHandler handles the http request from the single sign on provider after the user has authenticated.
+// The user is found by the subject, linked by the verified email, or created and waits for the admin approval
+/*
+	@params:
+		code	= required
+		state	= required
+	@example:
+		code	= SplxlOBeZQQYbYS6WxSbIA
+		state	= af0ifjsldkj
+	@return
+*/
+func SSOCallbackHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if sess != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You have already logged in"))
+		return
+	}
+
+	if !oidc.IsEnabled() {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Single sign on is not enabled"))
+		return
+	}
+
+	params := ssoCallbackParams{
+		Code:  r.FormValue("code"),
+		State: r.FormValue("state"),
+		Error: r.FormValue("error"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	nonce, cookie, err := auth.ConsumeSSOState(r, args.State)
+	http.SetCookie(w, cookie)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusUnauthorized).
+			AddError("Invalid single sign on state"))
+		return
+	}
+
+	claims, err := oidc.Exchange(args.Code)
+	if err != nil || claims.Nonce != nonce {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusUnauthorized).
+			AddError("Invalid single sign on response"))
+		return
+	}
+
+	u, err := ssoUser(claims)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError(err.Error()))
+		return
+	}
+
+	completeSignIn(w, r, u)
+	return
+}
+
+// ssoUser returns the user of the claims. The subject is linked to the user who has the same
+// verified email, otherwise a new user is created when the claims contain the identity code
+func ssoUser(claims oidc.Claims) (user.User, error) {
+
+	u, err := user.GetBySSOSubject(claims.Subject)
+	if err == nil {
+		return u, nil
+	} else if err != sql.ErrNoRows {
+		return u, errSSOInternal
+	}
+
+	// an unverified email can be owned by anyone on the provider
+	if !claims.EmailVerified {
+		return u, errSSOEmailUnverified
+	}
+
+	email, err := helper.NormalizeEmail(claims.Email)
+	if err != nil {
+		return u, errSSOEmailUnverified
+	}
+
+	u, err = user.GetByEmail(email)
+	if err == nil {
+		err = user.UpdateSSOSubject(u.ID, claims.Subject)
+		if err != nil {
+			// the user has been linked to another subject
+			return u, errSSOLinked
+		}
+		return u, nil
+	} else if err != sql.ErrNoRows {
+		return u, errSSOInternal
+	}
+
+	identityCode, err := helper.NormalizeIdentity(claims.IdentityCode)
+	if err != nil {
+		return u, errSSOIdentity
+	}
+
+	name, err := helper.NormalizeName(claims.Name)
+	if err != nil {
+		return u, errSSOIdentity
+	}
+
+	_, err = user.GetByIdentityCode(identityCode, user.ColIdentityCode)
+	if err != sql.ErrNoRows {
+		return u, errSSOIdentity
+	}
+
+	tx := conn.DB.MustBegin()
+	id, err := user.Provision(identityCode, name, email, claims.Subject, tx)
+	if err != nil {
+		tx.Rollback()
+		return u, errSSOInternal
+	}
+	err = tx.Commit()
+	if err != nil {
+		return u, errSSOInternal
+	}
+
+	return user.User{
+		ID:           id,
+		Name:         name,
+		Email:        email,
+		IdentityCode: identityCode,
+		Status:       user.StatusVerified,
+	}, nil
+}
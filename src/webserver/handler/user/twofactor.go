@@ -0,0 +1,545 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	rg "github.com/melodiez14/meiko/src/module/rolegroup"
+	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/helper"
+	"github.com/melodiez14/meiko/src/webserver/template"
+)
+
+const (
+	totpIssuer        = "Meiko"
+	recoveryCodeTotal = 10
+)
+
+// SignInTwoFactorHandler handles the http request for the second step of sign in.
+// A user who is required to use two factor authentication but hasn't enrolled yet
+// activates it here with the code of the secret given by SignInEnrollTwoFactorHandler
+/*
+	@params:
+		token			= required, token of sign in response
+		code			= required if recovery_code is empty, numeric, characters=6
+		recovery_code	= required if code is empty
+	@example:
+		token			= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+		code			= 287082
+	@return
+		is_logged_in	= true
+		modules			= {"users":["READ"]}
+		recovery_codes	= only when two factor authentication has just been activated
+*/
+func SignInTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if sess != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You have already logged in"))
+		return
+	}
+
+	params := twoFactorSignInParams{
+		Token:        r.FormValue("token"),
+		Code:         r.FormValue("code"),
+		RecoveryCode: r.FormValue("recovery_code"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	u, code := getPendingUser(args.Token)
+	if code != http.StatusOK {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(code).
+			AddError("Sign in session has expired"))
+		return
+	}
+
+	if !isAttemptAllowed(w, r, auth.ScopeTwoFactor, u.Email) {
+		return
+	}
+
+	tf, err := user.GetTwoFactor(u.ID)
+	if err != nil || !tf.Secret.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Two factor authentication has not been enrolled"))
+		return
+	}
+
+	var isValid bool
+	switch {
+	case !helper.IsEmpty(args.RecoveryCode):
+		isValid = tf.EnabledAt.Valid && user.UseRecoveryCode(u.ID, helper.HashRecoveryCode(args.RecoveryCode))
+	default:
+		isValid = isValidTOTP(u.ID, tf.Secret.String, args.Code)
+	}
+
+	if !isValid {
+		auth.FailAttempt(auth.ScopeTwoFactor, r, u.Email)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusUnauthorized).
+			AddError("Invalid code"))
+		return
+	}
+	auth.SucceedAttempt(auth.ScopeTwoFactor, r, u.Email)
+
+	// the enrolment required by the rolegroup is completed by the first valid code
+	var recoveryCodes []string
+	if !tf.EnabledAt.Valid {
+		recoveryCodes, err = activateTwoFactor(u.ID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	auth.DestroyPendingSession(args.Token)
+
+	res, err := signIn(w, r, u)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError).
+			SetMessage("Internal server error"))
+		return
+	}
+	res.RecoveryCodes = recoveryCodes
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// SignInEnrollTwoFactorHandler handles the http request for enrolling two factor authentication
+// while signing in, used when the rolegroup of the user requires it
+/*
+	@params:
+		token	= required, token of sign in response
+	@example:
+		token	= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+	@return
+		secret	= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+		uri		= otpauth://totp/Meiko:risal@live.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+*/
+func SignInEnrollTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if sess != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You have already logged in"))
+		return
+	}
+
+	params := twoFactorEnrollParams{
+		Token: r.FormValue("token"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	u, code := getPendingUser(args.Token)
+	if code != http.StatusOK {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(code).
+			AddError("Sign in session has expired"))
+		return
+	}
+
+	enrollTwoFactor(w, u.ID, u.Email)
+	return
+}
+
+// ReadTwoFactorHandler handles the http request for getting two factor authentication status of user
+/*
+	@params:
+	@example:
+	@return
+		is_enabled			= true
+		is_required			= false
+		recovery_codes_left	= 8
+*/
+func ReadTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	tf, err := user.GetTwoFactor(sess.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	var left int
+	if tf.EnabledAt.Valid {
+		left, err = user.SelectCountRecoveryCodes(sess.ID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(twoFactorResponse{
+			IsEnabled:         tf.EnabledAt.Valid,
+			IsRequired:        isTwoFactorRequired(sess.ID),
+			RecoveryCodesLeft: left,
+		}))
+	return
+}
+
+// EnrollTwoFactorHandler handles the http request for starting two factor authentication enrolment.
+// It is not active until confirmed through ActivateTwoFactorHandler
+/*
+	@params:
+	@example:
+	@return
+		secret	= JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+		uri		= otpauth://totp/Meiko:risal@live.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+*/
+func EnrollTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	enrollTwoFactor(w, sess.ID, sess.Email)
+	return
+}
+
+// ActivateTwoFactorHandler handles the http request for confirming the enrolment with the first code
+/*
+	@params:
+		code			= required, numeric, characters=6
+	@example:
+		code			= 287082
+	@return
+		recovery_codes	= [k3pxp-jbswy, 7dpeh-pk3px]
+*/
+func ActivateTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := twoFactorCodeParams{
+		Code: r.FormValue("code"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	tf, err := user.GetTwoFactor(sess.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	if !tf.Secret.Valid || tf.EnabledAt.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("There is no pending two factor authentication enrolment"))
+		return
+	}
+
+	if !isAttemptAllowed(w, r, auth.ScopeTwoFactor, sess.Email) {
+		return
+	}
+
+	if !isValidTOTP(sess.ID, tf.Secret.String, args.Code) {
+		auth.FailAttempt(auth.ScopeTwoFactor, r, sess.Email)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid code"))
+		return
+	}
+	auth.SucceedAttempt(auth.ScopeTwoFactor, r, sess.Email)
+
+	codes, err := activateTwoFactor(sess.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Two factor authentication has been activated, the recovery codes will not be shown again").
+		SetData(recoveryCodesResponse{RecoveryCodes: codes}))
+	return
+}
+
+// RecoveryCodeHandler handles the http request for replacing all recovery codes of user
+/*
+	@params:
+		code			= required, numeric, characters=6
+	@example:
+		code			= 287082
+	@return
+		recovery_codes	= [k3pxp-jbswy, 7dpeh-pk3px]
+*/
+func RecoveryCodeHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := twoFactorCodeParams{
+		Code: r.FormValue("code"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	tf, err := user.GetTwoFactor(sess.ID)
+	if err != nil || !tf.EnabledAt.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Two factor authentication is not activated"))
+		return
+	}
+
+	if !isAttemptAllowed(w, r, auth.ScopeTwoFactor, sess.Email) {
+		return
+	}
+
+	if !isValidTOTP(sess.ID, tf.Secret.String, args.Code) {
+		auth.FailAttempt(auth.ScopeTwoFactor, r, sess.Email)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid code"))
+		return
+	}
+	auth.SucceedAttempt(auth.ScopeTwoFactor, r, sess.Email)
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	tx := conn.DB.MustBegin()
+	err = user.ReplaceRecoveryCodes(sess.ID, hashes, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	tx.Commit()
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Recovery codes have been replaced, they will not be shown again").
+		SetData(recoveryCodesResponse{RecoveryCodes: codes}))
+	return
+}
+
+// DisableTwoFactorHandler handles the http request for turning off two factor authentication.
+// It is not allowed when the rolegroup of the user requires it
+/*
+	@params:
+		code		= required, numeric, characters=6
+		password	= required, the current password
+	@example:
+		code		= 287082
+		password	= Qwerty123
+	@return
+*/
+func DisableTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	params := disableTwoFactorParams{
+		Code:     r.FormValue("code"),
+		Password: r.FormValue("password"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	if isTwoFactorRequired(sess.ID) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("Two factor authentication is required for your role"))
+		return
+	}
+
+	tf, err := user.GetTwoFactor(sess.ID)
+	if err != nil || !tf.EnabledAt.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Two factor authentication is not activated"))
+		return
+	}
+
+	if !isAttemptAllowed(w, r, auth.ScopeTwoFactor, sess.Email) {
+		return
+	}
+
+	_, err = user.SignIn(sess.Email, args.Password)
+	if err != nil {
+		auth.FailAttempt(auth.ScopeTwoFactor, r, sess.Email)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("Invalid password"))
+		return
+	}
+
+	if !isValidTOTP(sess.ID, tf.Secret.String, args.Code) {
+		auth.FailAttempt(auth.ScopeTwoFactor, r, sess.Email)
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError("Invalid code"))
+		return
+	}
+	auth.SucceedAttempt(auth.ScopeTwoFactor, r, sess.Email)
+
+	tx := conn.DB.MustBegin()
+	err = user.DisableTOTP(sess.ID, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	tx.Commit()
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Two factor authentication has been disabled"))
+	return
+}
+
+// getPendingUser returns the activated user of the pending sign in session
+func getPendingUser(token string) (user.User, int) {
+	id, err := auth.GetPendingSession(token)
+	if err != nil {
+		return user.User{}, http.StatusUnauthorized
+	}
+
+	users, err := user.SelectByID([]int64{id}, false)
+	if err != nil {
+		return user.User{}, http.StatusInternalServerError
+	}
+	if len(users) != 1 || users[0].Status != user.StatusActivated {
+		return user.User{}, http.StatusUnauthorized
+	}
+	return users[0], http.StatusOK
+}
+
+// enrollTwoFactor generates a new secret for the user, an activated secret is never replaced
+func enrollTwoFactor(w http.ResponseWriter, userID int64, email string) {
+	tf, err := user.GetTwoFactor(userID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	if tf.EnabledAt.Valid {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusConflict).
+			AddError("Two factor authentication has already been activated"))
+		return
+	}
+
+	secret, err := helper.GenerateTOTPSecret()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = user.UpdateTOTPSecret(userID, secret)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(twoFactorEnrollResponse{
+			Secret: secret,
+			URI:    helper.TOTPURI(totpIssuer, email, secret),
+		}))
+}
+
+// activateTwoFactor activates the enrolled secret and returns the first recovery codes
+func activateTwoFactor(userID int64) ([]string, error) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := conn.DB.MustBegin()
+	err = user.EnableTOTP(userID, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = user.ReplaceRecoveryCodes(userID, hashes, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return codes, tx.Commit()
+}
+
+// newRecoveryCodes returns the recovery codes to be shown and their hashes to be stored
+func newRecoveryCodes() ([]string, []string, error) {
+	var codes, hashes []string
+	for i := 0; i < recoveryCodeTotal; i++ {
+		code, err := helper.GenerateRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, helper.HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// isValidTOTP checks the code and makes sure it hasn't been used yet
+func isValidTOTP(userID int64, secret, code string) bool {
+	return helper.IsValidTOTP(secret, code, time.Now()) && auth.UseTOTPCode(userID, code)
+}
+
+// isTwoFactorRequired checks whether the rolegroup of the user requires two factor authentication
+func isTwoFactorRequired(userID int64) bool {
+	users, err := user.SelectByID([]int64{userID}, false, user.ColRoleGroupsID)
+	if err != nil || len(users) != 1 || !users[0].RoleGroupsID.Valid {
+		return false
+	}
+	return rg.IsTwoFactorRequired(users[0].RoleGroupsID.Int64)
+}
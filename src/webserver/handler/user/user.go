@@ -11,10 +11,13 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/melodiez14/meiko/src/email"
+	"github.com/melodiez14/meiko/src/module/audit"
 	rg "github.com/melodiez14/meiko/src/module/rolegroup"
 	"github.com/melodiez14/meiko/src/module/user"
 	"github.com/melodiez14/meiko/src/util/alias"
 	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/util/conn"
+	"github.com/melodiez14/meiko/src/util/policy"
 	"github.com/melodiez14/meiko/src/webserver/template"
 )
 
@@ -94,7 +97,7 @@ func SignUpHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	}
 
 	// change to email template
-	go email.SendEmailValidation(args.Name, args.Email, verification.Code)
+	go email.SendEmailValidation(args.Name, args.Email, verification.Code, verificationLink(auth.LinkVerification, args.Email, verification))
 
 	template.RenderJSONResponse(w, new(template.Response).
 		SetCode(http.StatusOK).
@@ -103,15 +106,18 @@ func SignUpHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 }
 
 // EmailVerificationHandler handles the http request for resend activation code or activate the email
+// The token of the link sent to the email can be used instead of the email and code
 /*
 	@params:
-		email	= required, email format, 0<characters<45
+		email	= required if token is empty, email format, 0<characters<45
 		resend	= optional, value=true or empty
-		code	= required if resend is empty, numeric, characters=4
+		code	= required if resend and token are empty, numeric, characters=4
+		token	= optional, signed token of the link
 	@example:
 		email=risal.falah@gmail.com
 		resend=true
 		code=1234 or empty if resend is true
+		token=eyJwIjoidmVyaWZ5In0.c2lnbmF0dXJl
 	@return
 */
 func EmailVerificationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -128,6 +134,7 @@ func EmailVerificationHandler(w http.ResponseWriter, r *http.Request, ps httprou
 		Email:        r.FormValue("email"),
 		IsResendCode: r.FormValue("resend"),
 		Code:         r.FormValue("code"),
+		Token:        r.FormValue("token"),
 	}
 
 	args, err := params.validate()
@@ -162,7 +169,7 @@ func EmailVerificationHandler(w http.ResponseWriter, r *http.Request, ps httprou
 			return
 		}
 
-		go email.SendEmailValidation(u.Name, args.Email, verification.Code)
+		go email.SendEmailValidation(u.Name, args.Email, verification.Code, verificationLink(auth.LinkVerification, args.Email, verification))
 
 		template.RenderJSONResponse(w, new(template.Response).
 			SetMessage(fmt.Sprintf("Code has been sent to email")).
@@ -189,19 +196,25 @@ func EmailVerificationHandler(w http.ResponseWriter, r *http.Request, ps httprou
 // ReadHandler handles the http request for listing all verified and activated users. Accessing this handler needs READ or XREAD ability
 /*
 	@params:
-		pg	= required, positive numeric
-		ttl	= required, positive numeric
+		pg		= required, positive numeric
+		ttl		= required, positive numeric
+		program	= optional, 0<characters<=100
+		cohort	= optional, year
+		advisor	= optional, identity code of the advisor
 	@example:
 		pg=1
 		ttl=10
+		program=Teknik Informatika
+		cohort=2014
+		advisor=140810140001
 	@return
-		[]{name, email, status, identity}
+		[]{name, email, status, identity, program, cohort}
 */
 func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
 
-	if !sess.IsHasRoles(rg.ModuleUser, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -214,8 +227,11 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	}
 
 	params := getVerifiedParams{
-		Page:  r.FormValue("pg"),
-		Total: r.FormValue("ttl"),
+		Page:    r.FormValue("pg"),
+		Total:   r.FormValue("ttl"),
+		Program: r.FormValue("program"),
+		Cohort:  r.FormValue("cohort"),
+		Advisor: r.FormValue("advisor"),
 	}
 
 	args, err := params.validate()
@@ -233,9 +249,24 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		return
 	}
 
+	filter := user.DashboardFilter{
+		Program: args.Program,
+		Cohort:  args.Cohort,
+	}
+	if args.Advisor > 0 {
+		advisor, err := user.GetByIdentityCode(args.Advisor, user.ColID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("Advisor not found"))
+			return
+		}
+		filter.AdvisorID = advisor.ID
+	}
+
 	// get verified user by page
 	offset := (args.Page - 1) * args.Total
-	u, count, err := user.SelectDashboard(sess.ID, args.Total, offset, true)
+	u, count, err := user.SelectDashboard(sess.ID, filter, args.Total, offset, true)
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
@@ -255,6 +286,8 @@ func ReadHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 			Email:        val.Email,
 			IdentityCode: val.IdentityCode,
 			Status:       status,
+			Program:      val.Program.String,
+			Cohort:       uint16(val.Cohort.Int64),
 		})
 	}
 
@@ -289,7 +322,7 @@ func ActivationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 
 	sess := r.Context().Value("User").(*auth.User)
 
-	if !sess.IsHasRoles(rg.ModuleUser, rg.RoleUpdate, rg.RoleXUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -330,27 +363,49 @@ func ActivationHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
+	tx := conn.DB.MustBegin()
+	err = user.UpdateStatus(u.IdentityCode, args.Status, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, u.ID,
+		map[string]int8{"status": u.Status},
+		map[string]int8{"status": args.Status}, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+	tx.Commit()
+
 	go func() {
 		// change if args.Status == activated update redis
 		// if args.Status == Verified delete redis
-		_ = user.UpdateStatus(u.IdentityCode, args.Status)
-
 		roles := make(map[string][]string)
 		if u.RoleGroupsID.Valid {
 			roles, _ = rg.SelectModuleAccess(u.RoleGroupsID.Int64)
 		}
 
 		sess = &auth.User{
-			ID:           u.ID,
-			Name:         u.Name,
-			Email:        u.Email,
-			Gender:       u.Gender,
-			Note:         u.Note,
-			Status:       u.Status,
-			IdentityCode: u.IdentityCode,
-			LineID:       u.LineID.String,
-			Phone:        u.Phone.String,
-			Roles:        roles,
+			ID:            u.ID,
+			Name:          u.Name,
+			Email:         u.Email,
+			Gender:        u.Gender,
+			Note:          u.Note,
+			Status:        u.Status,
+			IdentityCode:  u.IdentityCode,
+			LineID:        u.LineID.String,
+			Phone:         u.Phone.String,
+			PhoneVerified: u.PhoneVerified,
+			Program:       u.Program.String,
+			Cohort:        uint16(u.Cohort.Int64),
+			AdvisorID:     u.AdvisorID.Int64,
+			Roles:         roles,
 		}
 		sess.UpdateSession()
 	}()
@@ -394,96 +449,23 @@ func SignInHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	u, err := user.SignIn(args.Email, args.Password)
-	if err != nil {
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusUnauthorized).
-			AddError("Invalid email or password"))
-		return
-	}
-	// check whether user activated
-	switch u.Status {
-	case alias.UserStatusUnverified:
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusForbidden).
-			SetMessage("Email unactivated"))
-		return
-	case alias.UserStatusVerified:
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusForbidden).
-			AddError("Waiting for admin approval"))
-		return
-	case alias.UserStatusActivated:
-		break
-	default:
-		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusInternalServerError).
-			AddError("Internal server error"))
+	if !isAttemptAllowed(w, r, auth.ScopeSignIn, args.Email) {
 		return
 	}
 
-	roles := make(map[string][]string)
-	if u.RoleGroupsID.Valid {
-		roles, err = rg.SelectModuleAccess(u.RoleGroupsID.Int64)
-		if err != nil {
-			template.RenderJSONResponse(w, new(template.Response).
-				SetCode(http.StatusInternalServerError))
-			return
-		}
-	}
-
-	sess = &auth.User{
-		ID:           u.ID,
-		Name:         u.Name,
-		Email:        u.Email,
-		Gender:       u.Gender,
-		Note:         u.Note,
-		Status:       u.Status,
-		IdentityCode: u.IdentityCode,
-		LineID:       u.LineID.String,
-		Phone:        u.Phone.String,
-		Roles:        roles,
-	}
-
-	cookie, err := sess.SetSession()
+	u, err := authenticate(args)
 	if err != nil {
+		if err == errInvalidCredentials {
+			auth.FailAttempt(auth.ScopeSignIn, r, args.Email)
+		}
 		template.RenderJSONResponse(w, new(template.Response).
-			SetCode(http.StatusInternalServerError).
-			SetMessage("Internal server error"))
+			SetCode(http.StatusUnauthorized).
+			AddError("Invalid email or password"))
 		return
 	}
+	auth.SucceedAttempt(auth.ScopeSignIn, r, args.Email)
 
-	http.SetCookie(w, cookie)
-
-	// set response data
-	var role string
-	roles = map[string][]string{}
-	for i, val := range sess.Roles {
-		for _, v := range val {
-			switch v {
-			case rg.RoleCreate, rg.RoleXCreate:
-				role = "CREATE"
-			case rg.RoleRead, rg.RoleXRead:
-				role = "READ"
-			case rg.RoleUpdate, rg.RoleXUpdate:
-				role = "UPDATE"
-			case rg.RoleDelete, rg.RoleXDelete:
-				role = "DELETE"
-			}
-			if !helper.IsStringInSlice(role, roles[i]) {
-				roles[i] = append(roles[i], role)
-			}
-		}
-	}
-
-	res := signInResponse{
-		IsLoggedIn: true,
-		Modules:    roles,
-	}
-
-	template.RenderJSONResponse(w, new(template.Response).
-		SetCode(http.StatusOK).
-		SetData(res))
+	completeSignIn(w, r, u)
 	return
 }
 
@@ -491,16 +473,19 @@ func SignInHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 // If resend is true so only email and resend is used. It used for requesting the code to send to email
 // If resend is empty so code can't be empty
 // If resend is empty, code is not empty, and password is not empty, it will set the new password
+// The token of the link sent to the email can be used instead of the email and code
 /*
 	@params:
-		email	= required, email format, 0<characters<45
+		email	= required if token is empty, email format, 0<characters<45
 		resend	= optional, value=true or empty
-		code	= required if resend is empty, numeric, characters=4
+		code	= required if resend and token are empty, numeric, characters=4
+		token	= optional, signed token of the link
 		password= optional if code is empty, minimum 1 uppercase, lowercase, numeric, characters>=6
 	@example:
 		email=risal.falah@gmail.com
 		resend=true
 		code=1234 or empty if resend is true
+		token=eyJwIjoiZm9yZ290In0.c2lnbmF0dXJl
 		password= Qwerty123
 	@return
 */
@@ -519,6 +504,7 @@ func ForgotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		IsSendCode: r.FormValue("resend"),
 		Code:       r.FormValue("code"),
 		Password:   r.FormValue("password"),
+		Token:      r.FormValue("token"),
 	}
 
 	args, err := params.validate()
@@ -529,10 +515,15 @@ func ForgotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	if !isAttemptAllowed(w, r, auth.ScopeForgot, args.Email) {
+		return
+	}
+
 	// if send code to email then return
 	if args.IsSendCode {
 		u, err := user.GetByEmail(args.Email, user.ColIdentityCode, user.ColName)
 		if err != nil {
+			auth.FailAttempt(auth.ScopeForgot, r, args.Email)
 			template.RenderJSONResponse(w, new(template.Response).
 				SetCode(http.StatusBadRequest).
 				AddError("Email is not registered"))
@@ -549,7 +540,7 @@ func ForgotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		}
 
 		// change to email template
-		go email.SendForgotPassword(u.Name, args.Email, verification.Code)
+		go email.SendForgotPassword(u.Name, args.Email, verification.Code, verificationLink(auth.LinkForgot, args.Email, verification))
 
 		res := forgotResponse{
 			Email:          args.Email,
@@ -565,6 +556,7 @@ func ForgotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 
 	v := user.IsValidConfirmationCode(args.Email, args.Code)
 	if !v {
+		auth.FailAttempt(auth.ScopeForgot, r, args.Email)
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusBadRequest).
 			AddError("Invalid confirmation code"))
@@ -577,6 +569,7 @@ func ForgotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
+	auth.SucceedAttempt(auth.ScopeForgot, r, args.Email)
 	go user.ForgotNewPassword(args.Email, args.Password)
 
 	template.RenderJSONResponse(w, new(template.Response).
@@ -597,6 +590,9 @@ func ForgotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		phone 		= 085860141146
 		line_id 	= risalfa
 		about_me	= hello my name is risal falah, you can call me ical
+		program		= Teknik Informatika
+		cohort		= 2014
+		advisor		= Khairil Azmi Ashari
 		img			= /api/v1/img/pl
 		img_t		= /api/v1/img/pl_t
 */
@@ -620,14 +616,31 @@ func GetProfileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		gender = "female"
 	}
 
+	var advisor string
+	if sess.AdvisorID > 0 {
+		u, err := user.SelectConciseUserByID([]int64{sess.AdvisorID})
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		if len(u) > 0 {
+			advisor = u[0].Name
+		}
+	}
+
 	res := getProfileResponse{
 		Name:                  sess.Name,
 		Email:                 sess.Email,
 		Gender:                gender,
 		Phone:                 sess.Phone,
+		PhoneVerified:         sess.PhoneVerified,
 		IdentityCode:          sess.IdentityCode,
 		LineID:                sess.LineID,
 		Note:                  sess.Note,
+		Program:               sess.Program,
+		Cohort:                sess.Cohort,
+		Advisor:               advisor,
 		ImageProfile:          alias.URLProfile,
 		ImageProfileThumbnail: alias.URLProfileThumbnail,
 	}
@@ -649,12 +662,16 @@ func GetProfileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		phone	= optional, numeric, 10<=characters<=12
 		line_id	= optional, 0<characters<=45
 		about_me= optional, 0<characters<=100
+		program	= optional, 0<characters<=100
+		cohort	= optional, year
 	@example:
 		name=Risal Falah
 		gender=male
 		phone=085860141146
 		line_id=risalfa
 		about_me=Hello my name is risal
+		program=Teknik Informatika
+		cohort=2014
 	@return
 */
 func UpdateProfileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -668,6 +685,8 @@ func UpdateProfileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 		Phone:        r.FormValue("phone"),
 		LineID:       r.FormValue("line_id"),
 		Note:         r.FormValue("about_me"),
+		Program:      r.FormValue("program"),
+		Cohort:       r.FormValue("cohort"),
 	}
 
 	args, err := params.validate()
@@ -702,7 +721,29 @@ func UpdateProfileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 			return
 		}
 	}
-	err = user.UpdateProfile(args.IdentityCode, args.Name, args.Note, args.Phone, args.LineID, args.Gender)
+
+	tx := conn.DB.MustBegin()
+	err = user.UpdateProfile(args.IdentityCode, args.Name, args.Note, args.Phone, args.LineID, args.Gender, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError).
+			AddError("Internal server error"))
+		return
+	}
+
+	if args.Program.String != sess.Program || uint16(args.Cohort.Int64) != sess.Cohort {
+		err = user.UpdateStudy(args.IdentityCode, args.Program, args.Cohort, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError).
+				AddError("Internal server error"))
+			return
+		}
+	}
+
+	err = tx.Commit()
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError).
@@ -729,16 +770,20 @@ func UpdateProfileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 	}
 
 	sess = &auth.User{
-		ID:           u.ID,
-		Name:         u.Name,
-		Email:        u.Email,
-		Gender:       u.Gender,
-		Note:         u.Note,
-		Status:       u.Status,
-		IdentityCode: u.IdentityCode,
-		LineID:       u.LineID.String,
-		Phone:        u.Phone.String,
-		Roles:        roles,
+		ID:            u.ID,
+		Name:          u.Name,
+		Email:         u.Email,
+		Gender:        u.Gender,
+		Note:          u.Note,
+		Status:        u.Status,
+		IdentityCode:  u.IdentityCode,
+		LineID:        u.LineID.String,
+		Phone:         u.Phone.String,
+		PhoneVerified: u.PhoneVerified,
+		Program:       u.Program.String,
+		Cohort:        uint16(u.Cohort.Int64),
+		AdvisorID:     u.AdvisorID.Int64,
+		Roles:         roles,
 	}
 
 	sess.UpdateSession()
@@ -833,6 +878,108 @@ func SignOutHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params
 	return
 }
 
+// ReadSessionHandler handles the http request for listing active sessions of the user
+/*
+	@params:
+	@example:
+	@return
+		id			= 3f2a9c1d7b0e4a55
+		user_agent	= Mozilla/5.0 (X11; Linux x86_64)
+		ip			= 10.10.1.20
+		created_at	= 1508313600
+		last_seen	= 1508317200
+		expires_at	= 1508922000
+		is_current	= true
+*/
+func ReadSessionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	sessions, err := sess.SessionList(r)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError).
+			AddError("Internal server error"))
+		return
+	}
+
+	res := []sessionResponse{}
+	for _, val := range sessions {
+		var createdAt int64
+		if !val.CreatedAt.IsZero() {
+			createdAt = val.CreatedAt.Unix()
+		}
+		var lastSeen int64
+		if !val.LastSeen.IsZero() {
+			lastSeen = val.LastSeen.Unix()
+		}
+		res = append(res, sessionResponse{
+			ID:        val.ID,
+			UserAgent: val.UserAgent,
+			IP:        val.IP,
+			CreatedAt: createdAt,
+			LastSeen:  lastSeen,
+			ExpiresAt: val.ExpiresAt.Unix(),
+			IsCurrent: val.IsCurrent,
+		})
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetData(res))
+	return
+}
+
+// DeleteSessionHandler handles the http request for revoking one session of the user
+/*
+	@params:
+		session_id	= required, given by ReadSessionHandler
+	@example:
+		session_id	= 3f2a9c1d7b0e4a55
+	@return
+*/
+func DeleteSessionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	err := sess.DestroySessionByID(ps.ByName("session_id"))
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("Session not found"))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Session has been revoked"))
+	return
+}
+
+// DeleteOtherSessionHandler handles the http request for revoking all sessions of the user except the current one
+/*
+	@params:
+	@example:
+	@return
+*/
+func DeleteOtherSessionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+
+	err := sess.DestroyOtherSession(r)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError).
+			AddError("Internal server error"))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetCode(http.StatusOK).
+		SetMessage("Other sessions have been revoked"))
+	return
+}
+
 // DetailHandler handles the http request for showing details of specific user
 /*
 	@params:
@@ -845,11 +992,15 @@ func SignOutHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params
 		phone 		= 085860141146
 		line_id 	= risalfa
 		about_me	= hello my name is risal falah, you can call me ical
+		status		= active or inactive
+		program		= Teknik Informatika
+		cohort		= 2014
+		advisor		= 140810140001
 */
 func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleUser, rg.RoleRead, rg.RoleXRead) {
+	if !policy.Can(sess, rg.RoleRead, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -892,15 +1043,33 @@ func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	if u.Status == 1 {
 		status = "inactive"
 	}
+
+	var advisor int64
+	if u.AdvisorID.Valid {
+		a, err := user.SelectConciseUserByID([]int64{u.AdvisorID.Int64})
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError))
+			return
+		}
+		if len(a) > 0 {
+			advisor = a[0].IdentityCode
+		}
+	}
+
 	res := detailResponse{
-		Name:         u.Name,
-		Email:        u.Email,
-		Gender:       gender,
-		Phone:        u.Phone.String,
-		IdentityCode: u.IdentityCode,
-		LineID:       u.LineID.String,
-		Note:         u.Note,
-		Status:       status,
+		Name:          u.Name,
+		Email:         u.Email,
+		Gender:        gender,
+		Phone:         u.Phone.String,
+		PhoneVerified: u.PhoneVerified,
+		IdentityCode:  u.IdentityCode,
+		LineID:        u.LineID.String,
+		Note:          u.Note,
+		Status:        status,
+		Program:       u.Program.String,
+		Cohort:        uint16(u.Cohort.Int64),
+		Advisor:       advisor,
 	}
 
 	template.RenderJSONResponse(w, new(template.Response).
@@ -919,6 +1088,9 @@ func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		phone	= optional, numeric, 10<=characters<=12
 		line_id	= optional, 0<characters<=45
 		about_me= optional, 0<characters<=100
+		program	= optional, 0<characters<=100
+		cohort	= optional, year
+		advisor	= optional, identity code of the advisor
 	@example:
 		id=140810140016
 		name=Risal Falah
@@ -926,12 +1098,15 @@ func DetailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		phone=085860141146
 		line_id=risalfa
 		about_me=Hello my name is risal
+		program=Teknik Informatika
+		cohort=2014
+		advisor=140810140001
 	@return
 */
 func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleUser, rg.RoleUpdate, rg.RoleXUpdate) {
+	if !policy.Can(sess, rg.RoleUpdate, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -947,6 +1122,10 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		LineID:       r.FormValue("line_id"),
 		Note:         r.FormValue("about_me"),
 		Status:       r.FormValue("status"),
+		RoleGroupID:  r.FormValue("rolegroup_id"),
+		Program:      r.FormValue("program"),
+		Cohort:       r.FormValue("cohort"),
+		Advisor:      r.FormValue("advisor"),
 	}
 
 	args, err := params.validate()
@@ -982,21 +1161,109 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		}
 	}
 
-	err = user.Update(args.IdentityCode, args.Name, args.Note, args.Phone, args.LineID, args.Gender, args.Status)
+	before, err := user.GetByIdentityCode(args.IdentityCode)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("User not found"))
+		return
+	}
+
+	// assigning a rolegroup grants its privileges, so it needs the role privilege as well
+	isRolegroupChanged := args.RoleGroupID.Valid && args.RoleGroupID != before.RoleGroupsID
+	if isRolegroupChanged {
+		if !policy.Can(sess, rg.RoleXUpdate, rg.ModuleRole, 0) {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusForbidden).
+				AddError("You don't have privilege"))
+			return
+		}
+		if !rg.IsExist(args.RoleGroupID.Int64) {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("Rolegroup does not exist"))
+			return
+		}
+	}
+
+	var advisorID sql.NullInt64
+	if args.Advisor.Valid {
+		advisor, err := user.GetByIdentityCode(args.Advisor.Int64, user.ColID)
+		if err != nil {
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusBadRequest).
+				AddError("Advisor not found"))
+			return
+		}
+		advisorID = sql.NullInt64{Int64: advisor.ID, Valid: true}
+	}
+
+	u := before
+	u.Name = args.Name
+	u.Note = args.Note
+	u.Phone = args.Phone
+	u.LineID = args.LineID
+	u.Gender = args.Gender
+	u.Status = args.Status
+	u.Program = args.Program
+	u.Cohort = args.Cohort
+	u.AdvisorID = advisorID
+	if isRolegroupChanged {
+		u.RoleGroupsID = args.RoleGroupID
+	}
+
+	tx := conn.DB.MustBegin()
+	err = user.Update(args.IdentityCode, args.Name, args.Note, args.Phone, args.LineID, args.Gender, args.Status, tx)
 	if err != nil {
+		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError).
 			AddError("Internal server error"))
 		return
 	}
 
-	u, err := user.GetByIdentityCode(args.IdentityCode)
+	if args.Program != before.Program || args.Cohort != before.Cohort {
+		err = user.UpdateStudy(args.IdentityCode, args.Program, args.Cohort, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError).
+				AddError("Internal server error"))
+			return
+		}
+	}
+
+	if advisorID != before.AdvisorID {
+		err = user.UpdateAdvisor(args.IdentityCode, advisorID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError).
+				AddError("Internal server error"))
+			return
+		}
+	}
+
+	if isRolegroupChanged {
+		err = user.UpdateRolegroup(args.IdentityCode, args.RoleGroupID, tx)
+		if err != nil {
+			tx.Rollback()
+			template.RenderJSONResponse(w, new(template.Response).
+				SetCode(http.StatusInternalServerError).
+				AddError("Internal server error"))
+			return
+		}
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionUpdate, auditTable, u.ID, auditValue(before), auditValue(u), tx)
 	if err != nil {
+		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError).
 			AddError("Internal server error"))
 		return
 	}
+	tx.Commit()
 
 	roles := make(map[string][]string)
 	if u.RoleGroupsID.Valid {
@@ -1009,16 +1276,20 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	}
 
 	sess = &auth.User{
-		ID:           u.ID,
-		Name:         u.Name,
-		Email:        u.Email,
-		Gender:       u.Gender,
-		Note:         u.Note,
-		Status:       u.Status,
-		IdentityCode: u.IdentityCode,
-		LineID:       u.LineID.String,
-		Phone:        u.Phone.String,
-		Roles:        roles,
+		ID:            u.ID,
+		Name:          u.Name,
+		Email:         u.Email,
+		Gender:        u.Gender,
+		Note:          u.Note,
+		Status:        u.Status,
+		IdentityCode:  u.IdentityCode,
+		LineID:        u.LineID.String,
+		Phone:         u.Phone.String,
+		PhoneVerified: u.PhoneVerified,
+		Program:       u.Program.String,
+		Cohort:        uint16(u.Cohort.Int64),
+		AdvisorID:     u.AdvisorID.Int64,
+		Roles:         roles,
 	}
 
 	go sess.UpdateSession()
@@ -1040,7 +1311,7 @@ func UpdateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleUser, rg.RoleDelete, rg.RoleXDelete) {
+	if !policy.Can(sess, rg.RoleDelete, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1066,7 +1337,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	u, err := user.GetByIdentityCode(args.IdentityCode, user.ColID)
+	u, err := user.GetByIdentityCode(args.IdentityCode)
 	if err != nil {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusBadRequest).
@@ -1074,12 +1345,23 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	err = user.Delete(args.IdentityCode)
+	tx := conn.DB.MustBegin()
+	err = user.Delete(args.IdentityCode, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionDelete, auditTable, u.ID, auditValue(u), nil, tx)
 	if err != nil {
+		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
 		return
 	}
+	tx.Commit()
 
 	err = auth.DestroyAllSession(u.ID)
 	if err != nil {
@@ -1094,6 +1376,57 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 	return
 }
 
+// UnlockHandler handles the http request for removing the sign in and forgot password lockout of the user
+/*
+	@params:
+		id	= required, numeric, 10<=characters<=18
+	@example:
+		id	= 140810140016
+	@return
+*/
+func UnlockHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+
+	sess := r.Context().Value("User").(*auth.User)
+	if !policy.Can(sess, rg.RoleXUpdate, rg.ModuleUser, 0) {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusForbidden).
+			AddError("You don't have privilege"))
+		return
+	}
+
+	params := unlockParams{
+		IdentityCode: ps.ByName("id"),
+	}
+
+	args, err := params.validate()
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusBadRequest).
+			AddError(err.Error()))
+		return
+	}
+
+	u, err := user.GetByIdentityCode(args.IdentityCode, user.ColEmail)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusNotFound).
+			AddError("User not found"))
+		return
+	}
+
+	err = auth.Unlock(u.Email, sess.ID)
+	if err != nil {
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	template.RenderJSONResponse(w, new(template.Response).
+		SetMessage("User successfully unlocked").
+		SetCode(http.StatusOK))
+	return
+}
+
 // CreateHandler handles the http request for creating new user account
 /*
 	@params:
@@ -1109,7 +1442,7 @@ func DeleteHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
 	sess := r.Context().Value("User").(*auth.User)
-	if !sess.IsHasRoles(rg.ModuleUser, rg.RoleCreate, rg.RoleXCreate) {
+	if !policy.Can(sess, rg.RoleCreate, rg.ModuleUser, 0) {
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusForbidden).
 			AddError("You don't have privilege"))
@@ -1148,12 +1481,29 @@ func CreateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params)
 		return
 	}
 
-	err = user.Create(args.IdentityCode, args.Name, args.Email)
+	tx := conn.DB.MustBegin()
+	id, err := user.Create(args.IdentityCode, args.Name, args.Email, tx)
+	if err != nil {
+		tx.Rollback()
+		template.RenderJSONResponse(w, new(template.Response).
+			SetCode(http.StatusInternalServerError))
+		return
+	}
+
+	err = audit.Insert(sess.ID, audit.ActionCreate, auditTable, id, nil, auditValue(user.User{
+		ID:           id,
+		Name:         args.Name,
+		Email:        args.Email,
+		Status:       user.StatusActivated,
+		IdentityCode: args.IdentityCode,
+	}), tx)
 	if err != nil {
+		tx.Rollback()
 		template.RenderJSONResponse(w, new(template.Response).
 			SetCode(http.StatusInternalServerError))
 		return
 	}
+	tx.Commit()
 
 	// generate verification code
 	verification, err := user.GenerateVerification(args.IdentityCode)
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"html"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/melodiez14/meiko/src/module/user"
 
 	"github.com/melodiez14/meiko/src/util/alias"
+	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/helper"
 )
 
@@ -100,6 +103,20 @@ func (params emailVerificationParams) validate() (emailVerificationArgs, error)
 		Code:         params.Code,
 		Email:        helper.Trim(params.Email),
 		IsResendCode: params.IsResendCode,
+		Token:        helper.Trim(params.Token),
+	}
+
+	// Token validation: the signed link carries the email and the code
+	if !helper.IsEmpty(params.Token) {
+		email, code, err := auth.VerifyLink(auth.LinkVerification, params.Token)
+		if err != nil {
+			return args, err
+		}
+		return emailVerificationArgs{
+			Email:        email,
+			IsResendCode: false,
+			Code:         code,
+		}, nil
 	}
 
 	// Email validation
@@ -143,12 +160,21 @@ func (params emailVerificationParams) validate() (emailVerificationArgs, error)
 	@params:
 		Page	= required, positive numeric
 		Total	= required, positive numeric
+		Program	= optional, 0<characters<=100
+		Cohort	= optional, year
+		Advisor	= optional, numeric, 10<=characters<=18
 	@example:
 		Page	= 35
 		Total	= 60
+		Program	= Teknik Informatika
+		Cohort	= 2014
+		Advisor	= 140810140001
 	@return:
 		Page	= 35
 		Total	= 60
+		Program	= Teknik Informatika
+		Cohort	= 2014
+		Advisor	= 140810140001
 */
 func (params getVerifiedParams) validate() (getVerifiedArgs, error) {
 
@@ -172,9 +198,30 @@ func (params getVerifiedParams) validate() (getVerifiedArgs, error) {
 		return args, fmt.Errorf("Invalid request")
 	}
 
+	program, err := validateProgram(params.Program)
+	if err != nil {
+		return args, err
+	}
+
+	cohort, err := validateCohort(params.Cohort)
+	if err != nil {
+		return args, err
+	}
+
+	var advisor int64
+	if !helper.IsEmpty(params.Advisor) {
+		advisor, err = helper.NormalizeIdentity(params.Advisor)
+		if err != nil {
+			return args, fmt.Errorf("Invalid advisor")
+		}
+	}
+
 	args = getVerifiedArgs{
-		Page:  int(page),
-		Total: int(total),
+		Page:    int(page),
+		Total:   int(total),
+		Program: program.String,
+		Cohort:  uint16(cohort.Int64),
+		Advisor: advisor,
 	}
 	return args, nil
 }
@@ -237,6 +284,7 @@ func (params activationParams) validate() (activationArgs, error) {
 func (params signInParams) validate() (signInArgs, error) {
 
 	var args signInArgs
+	rawPassword := params.Password
 	params = signInParams{
 		Email:    helper.Trim(params.Email),
 		Password: html.EscapeString(params.Password),
@@ -260,8 +308,9 @@ func (params signInParams) validate() (signInArgs, error) {
 	}
 
 	args = signInArgs{
-		Email:    email,
-		Password: helper.StringToMD5(params.Password),
+		Email:       email,
+		Password:    helper.StringToMD5(params.Password),
+		RawPassword: rawPassword,
 	}
 	return args, nil
 }
@@ -276,6 +325,8 @@ func (params signInParams) validate() (signInArgs, error) {
 		Gender	= optional, male or female
 		Phone	= optional, numeric, 10<=characters<=12
 		Line_id	= optional, 0<characters<=45
+		Program	= optional, 0<characters<=100
+		Cohort	= optional, year
 	@example:
 		IdentityCode	= 140810140060
 		Email			= khairilazmiashari@gmail.com
@@ -284,6 +335,8 @@ func (params signInParams) validate() (signInArgs, error) {
 		Gender			= male or female
 		Phone			= 082214467300
 		Lide_id			= khaazas
+		Program			= Teknik Informatika
+		Cohort			= 2014
 	@return:
 		IdentityCode	= 140810140060
 		Email			= khairilazmiashari@gmail.com
@@ -292,6 +345,8 @@ func (params signInParams) validate() (signInArgs, error) {
 		Gender			= male or female
 		Phone			= 082214467300
 		Lide_id			= khaazas
+		Program			= Teknik Informatika
+		Cohort			= 2014
 */
 func (params updateProfileParams) validate() (updateProfileArgs, error) {
 
@@ -304,6 +359,8 @@ func (params updateProfileParams) validate() (updateProfileArgs, error) {
 		Gender:       params.Gender,
 		Phone:        helper.Trim(params.Phone),
 		LineID:       html.EscapeString(params.LineID),
+		Program:      params.Program,
+		Cohort:       params.Cohort,
 	}
 
 	// Identity code validation
@@ -362,6 +419,16 @@ func (params updateProfileParams) validate() (updateProfileArgs, error) {
 		lineID = sql.NullString{String: params.LineID, Valid: true}
 	}
 
+	program, err := validateProgram(params.Program)
+	if err != nil {
+		return args, err
+	}
+
+	cohort, err := validateCohort(params.Cohort)
+	if err != nil {
+		return args, err
+	}
+
 	args = updateProfileArgs{
 		IdentityCode: identityCode,
 		Name:         name,
@@ -370,6 +437,8 @@ func (params updateProfileParams) validate() (updateProfileArgs, error) {
 		Phone:        phone,
 		LineID:       lineID,
 		Note:         params.Note,
+		Program:      program,
+		Cohort:       cohort,
 	}
 
 	return args, nil
@@ -480,35 +549,48 @@ func (params forgotParams) validate() (forgotArgs, error) {
 		IsSendCode: params.IsSendCode,
 		Code:       params.Code,
 		Password:   html.EscapeString(params.Password),
+		Token:      helper.Trim(params.Token),
 	}
 
-	// Email validation
-	email, err := helper.NormalizeEmail(params.Email)
-	if err != nil {
-		return args, fmt.Errorf("Error validation: %s", err.Error())
-	}
+	var email string
+	var code uint16
+	var err error
+	if !helper.IsEmpty(params.Token) {
+		// Token validation: the signed link carries the email and the code
+		email, code, err = auth.VerifyLink(auth.LinkForgot, params.Token)
+		if err != nil {
+			return args, err
+		}
+	} else {
+		// Email validation
+		email, err = helper.NormalizeEmail(params.Email)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: %s", err.Error())
+		}
 
-	// IsSendCode validation
-	if !helper.IsEmpty(params.IsSendCode) {
-		if params.IsSendCode == "true" {
-			return forgotArgs{
-				Email:      email,
-				IsSendCode: true,
-				Code:       0,
-				Password:   "",
-			}, nil
+		// IsSendCode validation
+		if !helper.IsEmpty(params.IsSendCode) {
+			if params.IsSendCode == "true" {
+				return forgotArgs{
+					Email:      email,
+					IsSendCode: true,
+					Code:       0,
+					Password:   "",
+				}, nil
+			}
 		}
-	}
 
-	// Code Validation
-	if helper.IsEmpty(params.Code) {
-		return args, fmt.Errorf("Error validation: code cant't be empty")
-	} else if len(params.Code) != alias.UserCodeLength {
-		return args, fmt.Errorf("Error validation: code must be 4 digits")
-	}
-	code, err := strconv.ParseInt(params.Code, 10, 16)
-	if err != nil {
-		return args, fmt.Errorf("Error validation: code should be numeric")
+		// Code Validation
+		if helper.IsEmpty(params.Code) {
+			return args, fmt.Errorf("Error validation: code cant't be empty")
+		} else if len(params.Code) != alias.UserCodeLength {
+			return args, fmt.Errorf("Error validation: code must be 4 digits")
+		}
+		c, err := strconv.ParseInt(params.Code, 10, 16)
+		if err != nil {
+			return args, fmt.Errorf("Error validation: code should be numeric")
+		}
+		code = uint16(c)
 	}
 
 	// Password Validation (Optional Field)
@@ -516,7 +598,7 @@ func (params forgotParams) validate() (forgotArgs, error) {
 		return forgotArgs{
 			Email:      email,
 			IsSendCode: false,
-			Code:       uint16(code),
+			Code:       code,
 			Password:   "",
 		}, nil
 	}
@@ -532,7 +614,7 @@ func (params forgotParams) validate() (forgotArgs, error) {
 	args = forgotArgs{
 		Email:      email,
 		IsSendCode: false,
-		Code:       uint16(code),
+		Code:       code,
 		Password:   helper.StringToMD5(params.Password),
 	}
 	return args, nil
@@ -571,6 +653,9 @@ func (params detailParams) validate() (detailArgs, error) {
 		Phone			= optional, numeric, 10<=characters<=12
 		Line_id			= optional, 0<characters<=45
 		Status			= required, actived or inactived
+		Program			= optional, 0<characters<=100
+		Cohort			= optional, year
+		Advisor			= optional, numeric, 10<=characters<=18
 	@example:
 		IdentityCode	= 140810140060
 		Name 			= Khairil Azmi Ashari
@@ -580,6 +665,9 @@ func (params detailParams) validate() (detailArgs, error) {
 		Phone 			= 082214467300
 		Line_id 		= khaazas
 		Status			= actived
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		Advisor			= 140810140001
 	@return:
 		IdentityCode	= 140810140060
 		Name 			= Khairil Azmi Ashari
@@ -589,6 +677,9 @@ func (params detailParams) validate() (detailArgs, error) {
 		Phone 			= 082214467300
 		Line_id 		= khaazas
 		Status			= actived
+		Program			= Teknik Informatika
+		Cohort			= 2014
+		Advisor			= 140810140001
 */
 func (params updateParams) validate() (updateArgs, error) {
 
@@ -602,6 +693,10 @@ func (params updateParams) validate() (updateArgs, error) {
 		Phone:        helper.Trim(params.Phone),
 		LineID:       html.EscapeString(params.LineID),
 		Status:       params.Status,
+		RoleGroupID:  helper.Trim(params.RoleGroupID),
+		Program:      params.Program,
+		Cohort:       params.Cohort,
+		Advisor:      helper.Trim(params.Advisor),
 	}
 
 	// Identity code validation
@@ -671,6 +766,36 @@ func (params updateParams) validate() (updateArgs, error) {
 		return args, fmt.Errorf("Error validation: wrong status")
 	}
 
+	// Rolegroup validation (can be empty)
+	var rolegroupID sql.NullInt64
+	if !helper.IsEmpty(params.RoleGroupID) {
+		id, err := strconv.ParseInt(params.RoleGroupID, 10, 64)
+		if err != nil || id < 1 {
+			return args, fmt.Errorf("Error validation: wrong rolegroup")
+		}
+		rolegroupID = sql.NullInt64{Int64: id, Valid: true}
+	}
+
+	program, err := validateProgram(params.Program)
+	if err != nil {
+		return args, err
+	}
+
+	cohort, err := validateCohort(params.Cohort)
+	if err != nil {
+		return args, err
+	}
+
+	// Advisor validation (can be empty)
+	var advisor sql.NullInt64
+	if !helper.IsEmpty(params.Advisor) {
+		code, err := helper.NormalizeIdentity(params.Advisor)
+		if err != nil || code == identityCode {
+			return args, fmt.Errorf("Error validation: wrong advisor")
+		}
+		advisor = sql.NullInt64{Int64: code, Valid: true}
+	}
+
 	args = updateArgs{
 		IdentityCode: identityCode,
 		Name:         name,
@@ -680,6 +805,10 @@ func (params updateParams) validate() (updateArgs, error) {
 		LineID:       lineID,
 		Note:         params.Note,
 		Status:       status,
+		RoleGroupID:  rolegroupID,
+		Program:      program,
+		Cohort:       cohort,
+		Advisor:      advisor,
 	}
 
 	return args, nil
@@ -741,3 +870,465 @@ func (params createParams) validate() (createArgs, error) {
 
 	return args, nil
 }
+
+// validate function for unlock user params
+/*
+	@params:
+		IdentityCode= required, numeric, characters=12
+	@example:
+		IdentityCode	= 140810140060
+	@return:
+		IdentityCode	= 140810140060
+*/
+func (params unlockParams) validate() (unlockArgs, error) {
+	var args unlockArgs
+	identityCode, err := helper.NormalizeIdentity(params.IdentityCode)
+	if err != nil {
+		return args, fmt.Errorf("Error validation: ID should be numeric")
+	}
+
+	args = unlockArgs{
+		IdentityCode: identityCode,
+	}
+	return args, nil
+}
+
+// validate function for the second step of sign in params
+/*
+	@params:
+		Token			= required, characters=43
+		Code			= required if RecoveryCode is empty, numeric, characters=6
+		RecoveryCode	= required if Code is empty
+	@example:
+		Token			= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+		Code			= 287082
+	@return:
+		Token			= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+		Code			= 287082
+*/
+func (params twoFactorSignInParams) validate() (twoFactorSignInArgs, error) {
+	var args twoFactorSignInArgs
+	params = twoFactorSignInParams{
+		Token:        helper.Trim(params.Token),
+		Code:         helper.Trim(params.Code),
+		RecoveryCode: helper.Trim(params.RecoveryCode),
+	}
+
+	if helper.IsEmpty(params.Token) {
+		return args, fmt.Errorf("Error validation: token can't be empty")
+	}
+
+	if helper.IsEmpty(params.Code) == helper.IsEmpty(params.RecoveryCode) {
+		return args, fmt.Errorf("Error validation: either code or recovery code is required")
+	}
+
+	if !helper.IsEmpty(params.Code) {
+		code, err := validateTOTPCode(params.Code)
+		if err != nil {
+			return args, err
+		}
+		return twoFactorSignInArgs{Token: params.Token, Code: code}, nil
+	}
+
+	return twoFactorSignInArgs{
+		Token:        params.Token,
+		RecoveryCode: params.RecoveryCode,
+	}, nil
+}
+
+// validate function for two factor enrolment while signing in params
+/*
+	@params:
+		Token	= required
+	@example:
+		Token	= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+	@return:
+		Token	= Zp3Y0Q8bS9i1GxV6oU2fQm5hT4wK7rN8cA1dE0jL9sH
+*/
+func (params twoFactorEnrollParams) validate() (twoFactorEnrollArgs, error) {
+	var args twoFactorEnrollArgs
+	token := helper.Trim(params.Token)
+	if helper.IsEmpty(token) {
+		return args, fmt.Errorf("Error validation: token can't be empty")
+	}
+	return twoFactorEnrollArgs{Token: token}, nil
+}
+
+// validate function for two factor code params
+/*
+	@params:
+		Code	= required, numeric, characters=6
+	@example:
+		Code	= 287082
+	@return:
+		Code	= 287082
+*/
+func (params twoFactorCodeParams) validate() (twoFactorCodeArgs, error) {
+	var args twoFactorCodeArgs
+	code, err := validateTOTPCode(helper.Trim(params.Code))
+	if err != nil {
+		return args, err
+	}
+	return twoFactorCodeArgs{Code: code}, nil
+}
+
+func (params disableTwoFactorParams) validate() (disableTwoFactorArgs, error) {
+	var args disableTwoFactorArgs
+	code, err := validateTOTPCode(helper.Trim(params.Code))
+	if err != nil {
+		return args, err
+	}
+
+	// the password confirms that the owner of the account turns it off, not only the holder of the session
+	password := html.EscapeString(params.Password)
+	if helper.IsEmpty(password) {
+		return args, fmt.Errorf("Error validation: password can't be empty")
+	}
+
+	return disableTwoFactorArgs{
+		Code:     code,
+		Password: helper.StringToMD5(password),
+	}, nil
+}
+
+// validateProgram validates the study program, empty program means no program
+func validateProgram(program string) (sql.NullString, error) {
+	program = helper.Trim(html.EscapeString(program))
+	if helper.IsEmpty(program) {
+		return sql.NullString{}, nil
+	}
+	if len(program) > alias.UserProgramLengthMax {
+		return sql.NullString{}, fmt.Errorf("Error validation: program too long")
+	}
+	return sql.NullString{String: program, Valid: true}, nil
+}
+
+// validateCohort validates the cohort year, empty cohort means no cohort
+func validateCohort(cohort string) (sql.NullInt64, error) {
+	cohort = helper.Trim(cohort)
+	if helper.IsEmpty(cohort) {
+		return sql.NullInt64{}, nil
+	}
+	year, err := strconv.ParseInt(cohort, 10, 64)
+	if err != nil || year < alias.UserCohortMin || year > int64(time.Now().Year()) {
+		return sql.NullInt64{}, fmt.Errorf("Error validation: wrong cohort")
+	}
+	return sql.NullInt64{Int64: year, Valid: true}, nil
+}
+
+func validateTOTPCode(code string) (string, error) {
+	if helper.IsEmpty(code) {
+		return "", fmt.Errorf("Error validation: code can't be empty")
+	}
+	if len(code) != helper.TOTPDigits {
+		return "", fmt.Errorf("Error validation: code must be %d digits", helper.TOTPDigits)
+	}
+	if _, err := strconv.ParseUint(code, 10, 32); err != nil {
+		return "", fmt.Errorf("Error validation: code should be numeric")
+	}
+	return code, nil
+}
+
+func (params ssoCallbackParams) validate() (ssoCallbackArgs, error) {
+	var args ssoCallbackArgs
+	params = ssoCallbackParams{
+		Code:  helper.Trim(params.Code),
+		State: helper.Trim(params.State),
+		Error: helper.Trim(params.Error),
+	}
+
+	// the provider sends error instead of code when the user denies the request
+	if !helper.IsEmpty(params.Error) {
+		return args, fmt.Errorf("Single sign on is canceled")
+	}
+
+	if helper.IsEmpty(params.Code) {
+		return args, fmt.Errorf("Error validation: code can't be empty")
+	}
+
+	if helper.IsEmpty(params.State) {
+		return args, fmt.Errorf("Error validation: state can't be empty")
+	}
+
+	return ssoCallbackArgs{
+		Code:  params.Code,
+		State: params.State,
+	}, nil
+}
+
+func (params importParams) validate() (importArgs, error) {
+	var args importArgs
+	isDryRun := helper.Trim(params.IsDryRun)
+	if !helper.IsEmpty(isDryRun) && isDryRun != "true" && isDryRun != "false" {
+		return args, fmt.Errorf("Error validation: dry_run should be true or false")
+	}
+
+	if len(params.Rows) < 2 {
+		return args, fmt.Errorf("Error validation: roster doesn't have any user")
+	}
+	if len(params.Rows)-1 > importRowMax {
+		return args, fmt.Errorf("Error validation: roster can't have more than %d users", importRowMax)
+	}
+
+	// the columns can be in any order and named as in the create form
+	columns := map[string]int{}
+	for i, v := range params.Rows[0] {
+		name := strings.ToLower(helper.Trim(v))
+		if name == "identity" || name == "identity_code" {
+			name = "id"
+		}
+		columns[name] = i
+	}
+	for _, v := range []string{"id", "name", "email"} {
+		if _, ok := columns[v]; !ok {
+			return args, fmt.Errorf("Error validation: roster doesn't have %s column", v)
+		}
+	}
+
+	cell := func(row []string, column string) string {
+		i := columns[column]
+		if i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	identities := map[int64]int{}
+	emails := map[string]int{}
+	rows := []importRow{}
+	for i, v := range params.Rows[1:] {
+		row := importRow{Row: i + 2, Errors: []string{}}
+
+		u, err := createParams{
+			IdentityCode: cell(v, "id"),
+			Name:         cell(v, "name"),
+			Email:        cell(v, "email"),
+		}.validate()
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+			rows = append(rows, row)
+			continue
+		}
+		row.User = u
+
+		if dup, ok := identities[u.IdentityCode]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("%d is duplicated with row %d", u.IdentityCode, dup))
+		} else {
+			identities[u.IdentityCode] = row.Row
+		}
+		if dup, ok := emails[u.Email]; ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("%s is duplicated with row %d", u.Email, dup))
+		} else {
+			emails[u.Email] = row.Row
+		}
+		rows = append(rows, row)
+	}
+
+	args = importArgs{
+		IsDryRun: isDryRun == "true",
+		Rows:     rows,
+	}
+	return args, nil
+}
+
+func (params eraseParams) validate() (eraseArgs, error) {
+	var args eraseArgs
+	params = eraseParams{
+		IdentityCode: helper.Trim(params.IdentityCode),
+		Confirm:      helper.Trim(params.Confirm),
+	}
+
+	identityCode, err := helper.NormalizeIdentity(params.IdentityCode)
+	if err != nil {
+		return args, fmt.Errorf("Error validation: %s", err.Error())
+	}
+
+	// erasure can't be undone, the admin retypes the identity code to confirm it
+	if params.Confirm != params.IdentityCode {
+		return args, fmt.Errorf("Error validation: confirm should be the identity code of the user")
+	}
+
+	args = eraseArgs{
+		IdentityCode: identityCode,
+	}
+	return args, nil
+}
+
+func (params searchUserParams) validate() (searchUserArgs, error) {
+	var args searchUserArgs
+	params = searchUserParams{
+		Text:        helper.Trim(params.Text),
+		Status:      helper.Trim(params.Status),
+		Gender:      helper.Trim(params.Gender),
+		RolegroupID: helper.Trim(params.RolegroupID),
+		ScheduleID:  helper.Trim(params.ScheduleID),
+		Sort:        helper.Trim(params.Sort),
+		Order:       helper.Trim(params.Order),
+		Cursor:      helper.Trim(params.Cursor),
+		Total:       helper.Trim(params.Total),
+	}
+
+	var filter user.SearchFilter
+	if len(params.Text) > alias.UserNameLengthMax {
+		return args, fmt.Errorf("Error validation: search text too long")
+	}
+	filter.Text = params.Text
+
+	// Status validation (can be empty)
+	switch params.Status {
+	case "":
+	case "unverified":
+		filter.Status = sql.NullInt64{Int64: user.StatusUnverified, Valid: true}
+	case "inactive":
+		filter.Status = sql.NullInt64{Int64: user.StatusVerified, Valid: true}
+	case "active":
+		filter.Status = sql.NullInt64{Int64: user.StatusActivated, Valid: true}
+	default:
+		return args, fmt.Errorf("Error validation: wrong status")
+	}
+
+	// Gender validation (can be empty)
+	switch params.Gender {
+	case "":
+	case "undefined":
+		filter.Gender = sql.NullInt64{Int64: user.GenderUndefined, Valid: true}
+	case "male":
+		filter.Gender = sql.NullInt64{Int64: user.GenderMale, Valid: true}
+	case "female":
+		filter.Gender = sql.NullInt64{Int64: user.GenderFemale, Valid: true}
+	default:
+		return args, fmt.Errorf("Error validation: wrong gender")
+	}
+
+	if !helper.IsEmpty(params.RolegroupID) {
+		id, err := strconv.ParseInt(params.RolegroupID, 10, 64)
+		if err != nil || id < 1 {
+			return args, fmt.Errorf("Error validation: wrong rolegroup")
+		}
+		filter.RolegroupID = id
+	}
+
+	if !helper.IsEmpty(params.ScheduleID) {
+		id, err := strconv.ParseInt(params.ScheduleID, 10, 64)
+		if err != nil || id < 1 {
+			return args, fmt.Errorf("Error validation: wrong schedule")
+		}
+		filter.ScheduleID = id
+	}
+
+	// Sort validation, sorted by the created time by default
+	if helper.IsEmpty(params.Sort) {
+		params.Sort = "created"
+	}
+	sort, ok := searchSorts[params.Sort]
+	if !ok {
+		return args, fmt.Errorf("Error validation: wrong sort")
+	}
+
+	var isDesc bool
+	switch params.Order {
+	case "", "asc":
+	case "desc":
+		isDesc = true
+	default:
+		return args, fmt.Errorf("Error validation: wrong order")
+	}
+
+	// Cursor validation, the cursor belongs to the same sort and order
+	var cursor user.SearchCursor
+	if !helper.IsEmpty(params.Cursor) {
+		c, err := decodeSearchCursor(params.Cursor)
+		if err != nil || c.Sort != sort || c.IsDesc != isDesc {
+			return args, fmt.Errorf("Error validation: wrong cursor")
+		}
+		cursor = user.SearchCursor{Value: c.Value, ID: c.ID}
+	}
+
+	total := searchTotalDefault
+	if !helper.IsEmpty(params.Total) {
+		t, err := strconv.Atoi(params.Total)
+		if err != nil || t < 1 || t > searchTotalMax {
+			return args, fmt.Errorf("Error validation: total should be between 1 and %d", searchTotalMax)
+		}
+		total = t
+	}
+
+	args = searchUserArgs{
+		Filter: filter,
+		Sort:   sort,
+		IsDesc: isDesc,
+		Cursor: cursor,
+		Total:  total,
+	}
+	return args, nil
+}
+
+func (params emailChangeParams) validate() (emailChangeArgs, error) {
+	var args emailChangeArgs
+	params = emailChangeParams{
+		Email:    helper.Trim(params.Email),
+		Password: html.EscapeString(params.Password),
+	}
+
+	email, err := helper.NormalizeEmail(params.Email)
+	if err != nil {
+		return args, fmt.Errorf("Error validation: %s", err.Error())
+	}
+
+	// the password confirms that the owner of the account asks for the change
+	if helper.IsEmpty(params.Password) {
+		return args, fmt.Errorf("Error validation: password can't be empty")
+	}
+
+	args = emailChangeArgs{
+		Email:    email,
+		Password: helper.StringToMD5(params.Password),
+	}
+	return args, nil
+}
+
+func (params confirmEmailChangeParams) validate() (confirmEmailChangeArgs, error) {
+	var args confirmEmailChangeArgs
+	params = confirmEmailChangeParams{
+		Code: helper.Trim(params.Code),
+	}
+
+	if helper.IsEmpty(params.Code) {
+		return args, fmt.Errorf("Error validation: code can't be empty")
+	}
+	if len(params.Code) != alias.UserCodeLength {
+		return args, fmt.Errorf("Error validation: wrong code")
+	}
+	code, err := strconv.ParseUint(params.Code, 10, 16)
+	if err != nil {
+		return args, fmt.Errorf("Error validation: wrong code")
+	}
+
+	args = confirmEmailChangeArgs{
+		Code: uint16(code),
+	}
+	return args, nil
+}
+
+func (params confirmPhoneParams) validate() (confirmPhoneArgs, error) {
+	var args confirmPhoneArgs
+	params = confirmPhoneParams{
+		Code: helper.Trim(params.Code),
+	}
+
+	if helper.IsEmpty(params.Code) {
+		return args, fmt.Errorf("Error validation: code can't be empty")
+	}
+	if len(params.Code) != alias.UserCodeLength {
+		return args, fmt.Errorf("Error validation: wrong code")
+	}
+	code, err := strconv.ParseUint(params.Code, 10, 16)
+	if err != nil {
+		return args, fmt.Errorf("Error validation: wrong code")
+	}
+
+	args = confirmPhoneArgs{
+		Code: uint16(code),
+	}
+	return args, nil
+}
@@ -5,8 +5,10 @@ import (
 	"html"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/melodiez14/meiko/src/module/user"
+	"github.com/melodiez14/meiko/src/util/auth"
 	"github.com/melodiez14/meiko/src/util/helper"
 )
 
@@ -189,10 +191,15 @@ func Test_signUpParams_validate(t *testing.T) {
 }
 
 func Test_emailVerificationParams_validate(t *testing.T) {
+	auth.Init(auth.Config{LinkSecret: "secret"})
+	token, _ := auth.SignLink(auth.LinkVerification, "risal@live.com", 1234, time.Now().Add(time.Minute))
+	forgotToken, _ := auth.SignLink(auth.LinkForgot, "risal@live.com", 1234, time.Now().Add(time.Minute))
+
 	type fields struct {
 		Email        string
 		IsResendCode string
 		Code         string
+		Token        string
 	}
 	tests := []struct {
 		name    string
@@ -300,6 +307,36 @@ func Test_emailVerificationParams_validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test Case 12",
+			fields: fields{
+				Token: token,
+			},
+			want: emailVerificationArgs{
+				Email:        "risal@live.com",
+				IsResendCode: false,
+				Code:         1234,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test Case 13",
+			fields: fields{
+				Token: forgotToken,
+			},
+			want:    emailVerificationArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 14",
+			fields: fields{
+				Email: "risal@live.com",
+				Code:  "1234",
+				Token: token + "x",
+			},
+			want:    emailVerificationArgs{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -307,6 +344,7 @@ func Test_emailVerificationParams_validate(t *testing.T) {
 				Email:        tt.fields.Email,
 				IsResendCode: tt.fields.IsResendCode,
 				Code:         tt.fields.Code,
+				Token:        tt.fields.Token,
 			}
 			got, err := params.validate()
 			if (err != nil) != tt.wantErr {
@@ -322,8 +360,11 @@ func Test_emailVerificationParams_validate(t *testing.T) {
 
 func Test_getVerifiedParams_validate(t *testing.T) {
 	type fields struct {
-		Page  string
-		Total string
+		Page    string
+		Total   string
+		Program string
+		Cohort  string
+		Advisor string
 	}
 	tests := []struct {
 		name    string
@@ -410,12 +451,53 @@ func Test_getVerifiedParams_validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test Case 10",
+			fields: fields{
+				Page:   "1",
+				Total:  "10",
+				Cohort: "14",
+			},
+			want:    getVerifiedArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 11",
+			fields: fields{
+				Page:    "1",
+				Total:   "10",
+				Advisor: "risal",
+			},
+			want:    getVerifiedArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 12",
+			fields: fields{
+				Page:    "1",
+				Total:   "10",
+				Program: " Teknik Informatika ",
+				Cohort:  "2014",
+				Advisor: "140810140001",
+			},
+			want: getVerifiedArgs{
+				Page:    1,
+				Total:   10,
+				Program: "Teknik Informatika",
+				Cohort:  2014,
+				Advisor: 140810140001,
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			params := getVerifiedParams{
-				Page:  tt.fields.Page,
-				Total: tt.fields.Total,
+				Page:    tt.fields.Page,
+				Total:   tt.fields.Total,
+				Program: tt.fields.Program,
+				Cohort:  tt.fields.Cohort,
+				Advisor: tt.fields.Advisor,
 			}
 			got, err := params.validate()
 			if (err != nil) != tt.wantErr {
@@ -589,8 +671,9 @@ func Test_signInParams_validate(t *testing.T) {
 				Password: "<script>alert('Mantap123')</script>",
 			},
 			want: signInArgs{
-				Email:    "risal@live.com",
-				Password: helper.StringToMD5(html.EscapeString(("<script>alert('Mantap123')</script>"))),
+				Email:       "risal@live.com",
+				Password:    helper.StringToMD5(html.EscapeString(("<script>alert('Mantap123')</script>"))),
+				RawPassword: "<script>alert('Mantap123')</script>",
 			},
 			wantErr: false,
 		},
@@ -622,6 +705,8 @@ func Test_updateProfileParams_validate(t *testing.T) {
 		Phone        string
 		LineID       string
 		Note         string
+		Program      string
+		Cohort       string
 	}
 	tests := []struct {
 		name    string
@@ -871,6 +956,46 @@ func Test_updateProfileParams_validate(t *testing.T) {
 			want:    updateProfileArgs{},
 			wantErr: true,
 		},
+		{
+			name: "Test Case 21",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Cohort:       "3014",
+			},
+			want:    updateProfileArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 22",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Program:      "Teknik Informatika Teknik Informatika Teknik Informatika Teknik Informatika Teknik Informatika Teknik",
+			},
+			want:    updateProfileArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 23",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Program:      "Teknik Informatika",
+				Cohort:       " 2014 ",
+			},
+			want: updateProfileArgs{
+				IdentityCode: 140810140016,
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Program:      sql.NullString{String: "Teknik Informatika", Valid: true},
+				Cohort:       sql.NullInt64{Int64: 2014, Valid: true},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -882,6 +1007,8 @@ func Test_updateProfileParams_validate(t *testing.T) {
 				Phone:        tt.fields.Phone,
 				LineID:       tt.fields.LineID,
 				Note:         tt.fields.Note,
+				Program:      tt.fields.Program,
+				Cohort:       tt.fields.Cohort,
 			}
 			got, err := params.validate()
 			if (err != nil) != tt.wantErr {
@@ -1107,11 +1234,16 @@ func Test_changePasswordParams_validate(t *testing.T) {
 }
 
 func Test_forgotParams_validate(t *testing.T) {
+	auth.Init(auth.Config{LinkSecret: "secret"})
+	token, _ := auth.SignLink(auth.LinkForgot, "risal@live.com", 1234, time.Now().Add(time.Minute))
+	expiredToken, _ := auth.SignLink(auth.LinkForgot, "risal@live.com", 1234, time.Now().Add(-time.Minute))
+
 	type fields struct {
 		Email      string
 		IsSendCode string
 		Password   string
 		Code       string
+		Token      string
 	}
 	tests := []struct {
 		name    string
@@ -1262,6 +1394,28 @@ func Test_forgotParams_validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test Case 15",
+			fields: fields{
+				Token:    token,
+				Password: "Mantap123",
+			},
+			want: forgotArgs{
+				Email:      "risal@live.com",
+				IsSendCode: false,
+				Code:       1234,
+				Password:   helper.StringToMD5("Mantap123"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test Case 16",
+			fields: fields{
+				Token: expiredToken,
+			},
+			want:    forgotArgs{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1270,6 +1424,7 @@ func Test_forgotParams_validate(t *testing.T) {
 				IsSendCode: tt.fields.IsSendCode,
 				Password:   tt.fields.Password,
 				Code:       tt.fields.Code,
+				Token:      tt.fields.Token,
 			}
 			got, err := params.validate()
 			if (err != nil) != tt.wantErr {
@@ -1369,6 +1524,10 @@ func Test_updateParams_validate(t *testing.T) {
 		LineID       string
 		Note         string
 		Status       string
+		RoleGroupID  string
+		Program      string
+		Cohort       string
+		Advisor      string
 	}
 	tests := []struct {
 		name    string
@@ -1643,6 +1802,82 @@ func Test_updateParams_validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Test Case 24",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       "active",
+				RoleGroupID:  "admin",
+			},
+			want:    updateArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 25",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       "active",
+				RoleGroupID:  " 2 ",
+			},
+			want: updateArgs{
+				IdentityCode: 140810140016,
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       user.StatusActivated,
+				RoleGroupID:  sql.NullInt64{Int64: 2, Valid: true},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Test Case 26",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       "active",
+				Advisor:      "140810140016",
+			},
+			want:    updateArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 27",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       "active",
+				Cohort:       "two thousand",
+			},
+			want:    updateArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 28",
+			fields: fields{
+				IdentityCode: "140810140016",
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       "active",
+				Program:      "Teknik Informatika",
+				Cohort:       "2014",
+				Advisor:      " 140810140001 ",
+			},
+			want: updateArgs{
+				IdentityCode: 140810140016,
+				Email:        "risal@live.com",
+				Name:         "Risal Falah",
+				Status:       user.StatusActivated,
+				Program:      sql.NullString{String: "Teknik Informatika", Valid: true},
+				Cohort:       sql.NullInt64{Int64: 2014, Valid: true},
+				Advisor:      sql.NullInt64{Int64: 140810140001, Valid: true},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1655,6 +1890,10 @@ func Test_updateParams_validate(t *testing.T) {
 				LineID:       tt.fields.LineID,
 				Note:         tt.fields.Note,
 				Status:       tt.fields.Status,
+				RoleGroupID:  tt.fields.RoleGroupID,
+				Program:      tt.fields.Program,
+				Cohort:       tt.fields.Cohort,
+				Advisor:      tt.fields.Advisor,
 			}
 			got, err := params.validate()
 			if (err != nil) != tt.wantErr {
@@ -1888,3 +2127,574 @@ func Test_createParams_validate(t *testing.T) {
 		})
 	}
 }
+
+func Test_unlockParams_validate(t *testing.T) {
+	type fields struct {
+		IdentityCode string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    unlockArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			fields:  fields{},
+			want:    unlockArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 2",
+			fields: fields{
+				IdentityCode: "Hello Moto",
+			},
+			want:    unlockArgs{},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 3",
+			fields: fields{
+				IdentityCode: "140810140016",
+			},
+			want: unlockArgs{
+				IdentityCode: 140810140016,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := unlockParams{
+				IdentityCode: tt.fields.IdentityCode,
+			}
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("unlockParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unlockParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_twoFactorSignInParams_validate(t *testing.T) {
+	type fields struct {
+		Token        string
+		Code         string
+		RecoveryCode string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    twoFactorSignInArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			fields:  fields{Code: "287082"},
+			want:    twoFactorSignInArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			fields:  fields{Token: "token"},
+			want:    twoFactorSignInArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			fields:  fields{Token: "token", Code: "287082", RecoveryCode: "k3pxp-jbswy"},
+			want:    twoFactorSignInArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 4",
+			fields:  fields{Token: "token", Code: "28708"},
+			want:    twoFactorSignInArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 5",
+			fields:  fields{Token: "token", Code: "28708a"},
+			want:    twoFactorSignInArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 6",
+			fields:  fields{Token: " token ", Code: " 287082 "},
+			want:    twoFactorSignInArgs{Token: "token", Code: "287082"},
+			wantErr: false,
+		},
+		{
+			name:    "Test Case 7",
+			fields:  fields{Token: "token", RecoveryCode: "k3pxp-jbswy"},
+			want:    twoFactorSignInArgs{Token: "token", RecoveryCode: "k3pxp-jbswy"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := twoFactorSignInParams{
+				Token:        tt.fields.Token,
+				Code:         tt.fields.Code,
+				RecoveryCode: tt.fields.RecoveryCode,
+			}
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("twoFactorSignInParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("twoFactorSignInParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_twoFactorCodeParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    twoFactorCodeArgs
+		wantErr bool
+	}{
+		{name: "Test Case 1", code: "", want: twoFactorCodeArgs{}, wantErr: true},
+		{name: "Test Case 2", code: "1234567", want: twoFactorCodeArgs{}, wantErr: true},
+		{name: "Test Case 3", code: "-12345", want: twoFactorCodeArgs{}, wantErr: true},
+		{name: "Test Case 4", code: "005924", want: twoFactorCodeArgs{Code: "005924"}, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := twoFactorCodeParams{
+				Code: tt.code,
+			}
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("twoFactorCodeParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("twoFactorCodeParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_disableTwoFactorParams_validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		password string
+		want     disableTwoFactorArgs
+		wantErr  bool
+	}{
+		{name: "Test Case 1", code: "12345", password: "Qwerty123", wantErr: true},
+		{name: "Test Case 2", code: "005924", password: "", wantErr: true},
+		{name: "Test Case 3", code: " 005924 ", password: "Qwerty123", want: disableTwoFactorArgs{Code: "005924", Password: helper.StringToMD5("Qwerty123")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := disableTwoFactorParams{
+				Code:     tt.code,
+				Password: tt.password,
+			}
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("disableTwoFactorParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("disableTwoFactorParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ssoCallbackParams_validate(t *testing.T) {
+	type fields struct {
+		Code  string
+		State string
+		Error string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    ssoCallbackArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			fields:  fields{State: "af0ifjsldkj", Error: "access_denied"},
+			want:    ssoCallbackArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			fields:  fields{State: "af0ifjsldkj"},
+			want:    ssoCallbackArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			fields:  fields{Code: "SplxlOBeZQQYbYS6WxSbIA"},
+			want:    ssoCallbackArgs{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 4",
+			fields:  fields{Code: " SplxlOBeZQQYbYS6WxSbIA ", State: "af0ifjsldkj"},
+			want:    ssoCallbackArgs{Code: "SplxlOBeZQQYbYS6WxSbIA", State: "af0ifjsldkj"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := ssoCallbackParams{
+				Code:  tt.fields.Code,
+				State: tt.fields.State,
+				Error: tt.fields.Error,
+			}
+			got, err := params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ssoCallbackParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ssoCallbackParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_importParams_validate(t *testing.T) {
+	header := []string{"Email", "Name", "ID"}
+	tests := []struct {
+		name    string
+		params  importParams
+		want    importArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			params:  importParams{IsDryRun: "yes", Rows: [][]string{header, {"risal@live.com", "Risal Falah", "140810140016"}}},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			params:  importParams{Rows: [][]string{header}},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			params:  importParams{Rows: [][]string{{"email", "name"}, {"risal@live.com", "Risal Falah"}}},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 4",
+			params: importParams{
+				IsDryRun: "true",
+				Rows: [][]string{
+					header,
+					{"Risal@Live.com", " Risal  Falah ", "140810140016"},
+					{"khairil@live.com", "Khairil Azmi", "14081014"},
+					{"risal@live.com", "Risal Falah", "140810140060"},
+					{"asep@live.com", "Asep"},
+				},
+			},
+			want: importArgs{
+				IsDryRun: true,
+				Rows: []importRow{
+					{Row: 2, User: createArgs{IdentityCode: 140810140016, Name: "Risal Falah", Email: "risal@live.com"}, Errors: []string{}},
+					{Row: 3, Errors: []string{"Error validation: ID should be numeric"}},
+					{Row: 4, User: createArgs{IdentityCode: 140810140060, Name: "Risal Falah", Email: "risal@live.com"}, Errors: []string{"risal@live.com is duplicated with row 2"}},
+					{Row: 5, Errors: []string{"Error validation: ID should be numeric"}},
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("importParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("importParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_eraseParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  eraseParams
+		want    eraseArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			params:  eraseParams{IdentityCode: "14081014", Confirm: "14081014"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			params:  eraseParams{IdentityCode: "140810140016"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			params:  eraseParams{IdentityCode: "140810140016", Confirm: "140810140060"},
+			wantErr: true,
+		},
+		{
+			name:   "Test Case 4",
+			params: eraseParams{IdentityCode: "140810140016", Confirm: " 140810140016 "},
+			want:   eraseArgs{IdentityCode: 140810140016},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("eraseParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("eraseParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_searchUserParams_validate(t *testing.T) {
+	cursor := encodeSearchCursor(searchCursor{Sort: user.ColName, IsDesc: true, Value: "Risal Falah", ID: 2000000001})
+	tests := []struct {
+		name    string
+		params  searchUserParams
+		want    searchUserArgs
+		wantErr bool
+	}{
+		{
+			name:   "Test Case 1",
+			params: searchUserParams{},
+			want: searchUserArgs{
+				Sort:  user.ColID,
+				Total: searchTotalDefault,
+			},
+		},
+		{
+			name:    "Test Case 2",
+			params:  searchUserParams{Status: "deleted"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			params:  searchUserParams{Gender: "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 4",
+			params:  searchUserParams{Sort: "password"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 5",
+			params:  searchUserParams{Order: "random"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 6",
+			params:  searchUserParams{Total: "101"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 7",
+			params:  searchUserParams{Cursor: "not a cursor"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 8",
+			params:  searchUserParams{Sort: "name", Cursor: cursor},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 9",
+			params:  searchUserParams{RolegroupID: "admin"},
+			wantErr: true,
+		},
+		{
+			name: "Test Case 10",
+			params: searchUserParams{
+				Text:        " risal ",
+				Status:      "unverified",
+				Gender:      "male",
+				RolegroupID: "2",
+				ScheduleID:  "12",
+				Sort:        "name",
+				Order:       "desc",
+				Cursor:      cursor,
+				Total:       "50",
+			},
+			want: searchUserArgs{
+				Filter: user.SearchFilter{
+					Text:        "risal",
+					Status:      sql.NullInt64{Int64: user.StatusUnverified, Valid: true},
+					Gender:      sql.NullInt64{Int64: user.GenderMale, Valid: true},
+					RolegroupID: 2,
+					ScheduleID:  12,
+				},
+				Sort:   user.ColName,
+				IsDesc: true,
+				Cursor: user.SearchCursor{Value: "Risal Falah", ID: 2000000001},
+				Total:  50,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("searchUserParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("searchUserParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_emailChangeParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  emailChangeParams
+		want    emailChangeArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			params:  emailChangeParams{Password: "Qwerty123"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			params:  emailChangeParams{Email: "risal.falah", Password: "Qwerty123"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			params:  emailChangeParams{Email: "risal.falah@gmail.com"},
+			wantErr: true,
+		},
+		{
+			name:   "Test Case 4",
+			params: emailChangeParams{Email: " Risal.Falah@gmail.com ", Password: "Qwerty123"},
+			want: emailChangeArgs{
+				Email:    "risal.falah@gmail.com",
+				Password: helper.StringToMD5("Qwerty123"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("emailChangeParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("emailChangeParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_confirmEmailChangeParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  confirmEmailChangeParams
+		want    confirmEmailChangeArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			params:  confirmEmailChangeParams{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			params:  confirmEmailChangeParams{Code: "14081"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			params:  confirmEmailChangeParams{Code: "14a8"},
+			wantErr: true,
+		},
+		{
+			name:   "Test Case 4",
+			params: confirmEmailChangeParams{Code: " 1408 "},
+			want:   confirmEmailChangeArgs{Code: 1408},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmEmailChangeParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("confirmEmailChangeParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_confirmPhoneParams_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  confirmPhoneParams
+		want    confirmPhoneArgs
+		wantErr bool
+	}{
+		{
+			name:    "Test Case 1",
+			params:  confirmPhoneParams{},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 2",
+			params:  confirmPhoneParams{Code: "140"},
+			wantErr: true,
+		},
+		{
+			name:    "Test Case 3",
+			params:  confirmPhoneParams{Code: "-140"},
+			wantErr: true,
+		},
+		{
+			name:   "Test Case 4",
+			params: confirmPhoneParams{Code: "1408 "},
+			want:   confirmPhoneArgs{Code: 1408},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmPhoneParams.validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("confirmPhoneParams.validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
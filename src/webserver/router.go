@@ -5,14 +5,18 @@ import (
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/melodiez14/meiko/src/util/auth"
+	"github.com/melodiez14/meiko/src/webserver/handler/apikey"
 	"github.com/melodiez14/meiko/src/webserver/handler/assignment"
 	"github.com/melodiez14/meiko/src/webserver/handler/attendance"
+	"github.com/melodiez14/meiko/src/webserver/handler/audit"
 	"github.com/melodiez14/meiko/src/webserver/handler/bot"
+	"github.com/melodiez14/meiko/src/webserver/handler/calendar"
 	"github.com/melodiez14/meiko/src/webserver/handler/course"
 	"github.com/melodiez14/meiko/src/webserver/handler/file"
 	"github.com/melodiez14/meiko/src/webserver/handler/information"
 	"github.com/melodiez14/meiko/src/webserver/handler/place"
 	"github.com/melodiez14/meiko/src/webserver/handler/rolegroup"
+	"github.com/melodiez14/meiko/src/webserver/handler/term"
 	"github.com/melodiez14/meiko/src/webserver/handler/tutorial"
 	"github.com/melodiez14/meiko/src/webserver/handler/user"
 )
@@ -25,19 +29,47 @@ func loadRouter(r *httprouter.Router) {
 	r.POST("/api/v1/user/register", auth.OptionalAuthorize(user.SignUpHandler))
 	r.POST("/api/v1/user/verify", auth.OptionalAuthorize(user.EmailVerificationHandler))
 	r.POST("/api/v1/user/signin", auth.OptionalAuthorize(user.SignInHandler))
+	r.POST("/api/v1/user/signin/2fa", auth.OptionalAuthorize(user.SignInTwoFactorHandler))
+	r.POST("/api/v1/user/signin/2fa/enroll", auth.OptionalAuthorize(user.SignInEnrollTwoFactorHandler))
+	r.GET("/api/v1/user/signin/sso", auth.OptionalAuthorize(user.SSOSignInHandler))
+	r.GET("/api/v1/user/signin/sso/callback", auth.OptionalAuthorize(user.SSOCallbackHandler))
 	r.POST("/api/v1/user/forgot", auth.OptionalAuthorize(user.ForgotHandler))
 	r.POST("/api/v1/user/signout", auth.MustAuthorize(user.SignOutHandler)) // delete
 	r.POST("/api/v1/user/profile", auth.MustAuthorize(user.UpdateProfileHandler))
 	r.GET("/api/v1/user/profile", auth.MustAuthorize(user.GetProfileHandler))
-	r.POST("/api/v1/user/changepassword", auth.MustAuthorize(user.ChangePasswordHandler))
+	r.GET("/api/v1/user/export", auth.MustAuthorizeSession(user.ExportHandler))
+	r.POST("/api/v1/user/changepassword", auth.MustAuthorizeSession(user.ChangePasswordHandler))
+	r.POST("/api/v1/user/email", auth.MustAuthorizeSession(user.RequestEmailChangeHandler))
+	r.POST("/api/v1/user/email/confirm", auth.MustAuthorizeSession(user.ConfirmEmailChangeHandler))
+	r.POST("/api/v1/user/phone/verify", auth.MustAuthorizeSession(user.RequestPhoneVerificationHandler))
+	r.POST("/api/v1/user/phone/confirm", auth.MustAuthorizeSession(user.ConfirmPhoneVerificationHandler))
+	r.GET("/api/v1/user/sessions", auth.MustAuthorizeSession(user.ReadSessionHandler))
+	r.DELETE("/api/v1/user/sessions", auth.MustAuthorizeSession(user.DeleteOtherSessionHandler))
+	r.DELETE("/api/v1/user/sessions/:session_id", auth.MustAuthorizeSession(user.DeleteSessionHandler))
+	r.GET("/api/v1/user/2fa", auth.MustAuthorizeSession(user.ReadTwoFactorHandler))
+	r.POST("/api/v1/user/2fa", auth.MustAuthorizeSession(user.EnrollTwoFactorHandler))
+	r.DELETE("/api/v1/user/2fa", auth.MustAuthorizeSession(user.DisableTwoFactorHandler))
+	r.POST("/api/v1/user/2fa/activate", auth.MustAuthorizeSession(user.ActivateTwoFactorHandler))
+	r.POST("/api/v1/user/2fa/recovery", auth.MustAuthorizeSession(user.RecoveryCodeHandler))
+	r.GET("/api/v1/user/apikeys", auth.MustAuthorizeSession(apikey.ReadHandler))
+	r.POST("/api/v1/user/apikeys", auth.MustAuthorizeSession(apikey.CreateHandler))
+	r.DELETE("/api/v1/user/apikeys/:apikey_id", auth.MustAuthorizeSession(apikey.DeleteHandler))
+	r.GET("/api/v1/advisee", auth.MustAuthorize(user.ReadAdviseeHandler))
 
 	// Admin section
 	r.GET("/api/admin/v1/user", auth.MustAuthorize(user.ReadHandler))
 	r.POST("/api/admin/v1/user", auth.MustAuthorize(user.CreateHandler))
+	r.POST("/api/admin/v1/import/user", auth.MustAuthorize(user.ImportHandler))
+	r.GET("/api/admin/v1/search/user", auth.MustAuthorize(user.SearchHandler))
 	r.GET("/api/admin/v1/user/:id", auth.MustAuthorize(user.DetailHandler))
 	r.PATCH("/api/admin/v1/user/:id", auth.MustAuthorize(user.UpdateHandler))
 	r.PATCH("/api/admin/v1/user/:id/:status", auth.MustAuthorize(user.ActivationHandler))
 	r.DELETE("/api/admin/v1/user/:id", auth.MustAuthorize(user.DeleteHandler))
+	r.POST("/api/admin/v1/user/:id/unlock", auth.MustAuthorize(user.UnlockHandler))
+	r.POST("/api/admin/v1/user/:id/erase", auth.MustAuthorize(user.EraseHandler))
+	r.GET("/api/admin/v1/user/:id/apikeys", auth.MustAuthorizeSession(apikey.ReadHandler))
+	r.POST("/api/admin/v1/user/:id/apikeys", auth.MustAuthorizeSession(apikey.CreateHandler))
+	r.DELETE("/api/admin/v1/user/:id/apikeys/:apikey_id", auth.MustAuthorizeSession(apikey.DeleteHandler))
 
 	// Public
 	r.GET("/api/v1/util/time", user.GetTimeHandler)
@@ -57,6 +89,10 @@ func loadRouter(r *httprouter.Router) {
 	r.GET("/api/admin/v1/list/abilities", auth.MustAuthorize(rolegroup.ListAbilitiesHandler))
 	// ====================== End Rolegroup Handler =====================
 
+	// ========================== Audit Handler =========================
+	r.GET("/api/admin/v1/audit", auth.MustAuthorize(audit.ReadHandler))
+	// ======================== End Audit Handler =======================
+
 	// ========================== File Handler ==========================
 	r.GET("/api/v1/filerouter", auth.OptionalAuthorize(file.RouterFileHandler))
 	r.GET("/api/v1/file/:payload/:filename", file.GetFileHandler)
@@ -89,9 +125,30 @@ func loadRouter(r *httprouter.Router) {
 	r.GET("/api/admin/v1/list/course/enrolled", auth.MustAuthorize(course.ListEnrolledHandler))
 	r.PATCH("/api/admin/v1/course/:schedule_id/involved", auth.MustAuthorize(course.AddInvolvedHandler))
 	r.GET("/api/admin/v1/course/:schedule_id/involved", auth.MustAuthorize(course.GetInvolvedHandler))
+	r.DELETE("/api/admin/v1/course/:schedule_id/involved", auth.MustAuthorize(course.RemoveInvolvedHandler))
+	r.GET("/api/admin/v1/course/:schedule_id/capacity", auth.MustAuthorize(course.ReadCapacityHandler))
+	r.PATCH("/api/admin/v1/course/:schedule_id/capacity", auth.MustAuthorize(course.UpdateCapacityHandler))
+	r.GET("/api/admin/v1/course/:schedule_id/prerequisite", auth.MustAuthorize(course.ReadPrerequisiteHandler))
+	r.PATCH("/api/admin/v1/course/:schedule_id/prerequisite", auth.MustAuthorize(course.UpdatePrerequisiteHandler))
+	r.PATCH("/api/admin/v1/course/:schedule_id/roster", auth.MustAuthorize(course.UpdateRosterHandler))
+	r.POST("/api/admin/v1/course/:schedule_id/roster", auth.MustAuthorize(course.ImportRosterHandler))
+	r.POST("/api/admin/v1/course/:schedule_id/clone", auth.MustAuthorize(course.CloneHandler))
 	r.GET("/api/admin/v1/course/:schedule_id/search", auth.MustAuthorize(course.SearchUninvolvedHandler))
 	// ======================== End Course Handler ======================
 
+	// ======================= Calendar Handler =========================
+	r.POST("/api/v1/calendar", auth.MustAuthorizeSession(calendar.CreateHandler))
+	r.DELETE("/api/v1/calendar", auth.MustAuthorizeSession(calendar.DeleteHandler))
+	r.GET("/api/v1/calendar/:token", calendar.FeedHandler)
+	// ===================== End Calendar Handler =======================
+
+	// ========================= Term Handler ===========================
+	r.GET("/api/v1/term", auth.MustAuthorize(term.ReadHandler))
+	r.POST("/api/admin/v1/term", auth.MustAuthorize(term.CreateHandler))
+	r.PATCH("/api/admin/v1/term/:id", auth.MustAuthorize(term.UpdateHandler))
+	r.POST("/api/admin/v1/term/:id/rollover", auth.MustAuthorize(term.RolloverHandler))
+	// ======================= End Term Handler =========================
+
 	// ======================== Tutorial Handler ========================
 	r.GET("/api/v1/tutorial", auth.MustAuthorize(tutorial.ReadHandler)) // for admin and user
 	r.POST("/api/admin/v1/tutorial", auth.MustAuthorize(tutorial.CreateHandler))
@@ -136,6 +193,7 @@ func loadRouter(r *httprouter.Router) {
 	// r.GET("/api/v1/assignment/:id/:schedule_id/:assignment_id", auth.MustAuthorize(assignment.GetUploadedDetailHandler)) // detail user assignments
 	// r.GET("/api/v1/assignment-schedule", auth.MustAuthorize(assignment.GetAssignmentByScheduleHandler))                  // List assignments
 	r.GET("/api/v1/grade", auth.MustAuthorize(assignment.GetReportHandler))
+	r.GET("/api/v1/advisee/:id/grade", auth.MustAuthorize(assignment.GetAdviseeReportHandler))
 	// r.GET("/api/v1/grade/:id", auth.MustAuthorize(assignment.GradeBySchedule))
 	// ===================== End Assignment Handler =====================
 